@@ -45,6 +45,33 @@ package core
  *
  * @author Mark S. Millard
  */
-type IMleActor interface{
+type IMleActor interface {
 	IMleObject
+
+	/**
+	 * Get the actor's associated role.
+	 *
+	 * @return The actor's role is returned, or <b>nil</b> if it has none.
+	 */
+	GetRole() *MleRole
+
+	/**
+	 * Attach a role to the actor.
+	 *
+	 * @param role The role to attach.
+	 */
+	AttachRole(role *MleRole)
+
+	/**
+	 * Bind the actor's properties to the fields of an object.
+	 *
+	 * @param owner A pointer to the struct embedding the actor.
+	 * @param class The registered class of the actor.
+	 */
+	BindProperties(owner interface{}, class string)
+
+	/**
+	 * Initialize the actor after its properties have been loaded.
+	 */
+	Init()
 }
@@ -46,4 +46,19 @@ package core
  *
  * @author Mark S. Millard
  */
-type IMleGroup interface{}
+type IMleGroup interface {
+	/**
+	 * Add an Actor to the Group.
+	 *
+	 * @param actor The <code>MleActor</code> to add.
+	 */
+	Add(actor *MleActor)
+
+	/**
+	 * Initialize the group after its actors have been loaded.
+	 *
+	 * @throws MleRuntimeException This exception is thrown if the
+	 * group can not be successfully initialized.
+	 */
+	Init() *MleError
+}
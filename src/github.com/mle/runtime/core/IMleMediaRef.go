@@ -47,4 +47,20 @@ package core
  *
  * @author Mark S. Millard
  */
-type IMleMediaRef interface{}
+type IMleMediaRef interface {
+	/**
+	 * Register the media for this reference.
+	 *
+	 * @param flags The media reference flags.
+	 * @param size The size of the media data, in bytes.
+	 * @param media The media data.
+	 *
+	 * @return <b>true</b> is returned if the media was registered.
+	 */
+	RegisterMedia(flags int32, size int, media []byte) bool
+
+	/**
+	 * Initialize the media reference.
+	 */
+	Init()
+}
@@ -86,10 +86,9 @@ type IMleObject interface {
 	 * @param nElements The number of elements in the array, each of size <b>length</b>.
 	 * @param value The value of the property to set.
 	 *
-	 * @throws MleRuntimeException This exception is thrown if the specified
-	 * property can not be set.
+	 * @return An error is returned if the specified property can not be set.
 	 */
-	SetPropertyArray(name string, length int, nElements int, value io.ByteReader)
+	SetPropertyArray(name string, length int, nElements int, value io.ByteReader) *MleError
 
 	/**
 	 * Report a bound property change. If <i>oldValue</i> and <i>newValue</i> are not
@@ -108,7 +107,8 @@ type IMleObject interface {
 	/**
 	 * Adds a <code>IMlePropChangeListener</code> for a specific property.
 	 * The listener will be invoked only when a call on <code>NotifyPropertyChange</code>
-	 * names that specific property. If listener is <b>null</b>, no exception is thrown
+	 * names that specific property, or for every property if <i>name</i> is
+	 * <code>MLE_PROP_CHANGE_ALL</code>. If listener is <b>null</b>, no exception is thrown
 	 * and no action is performed.
 	 *
 	 * @param name The name of the property to listen on.
@@ -118,7 +118,7 @@ type IMleObject interface {
 	 * listener can not be added. It is also thrown if the <i>name</i> argument
 	 * is <b>null</b>.
 	 */
-	AddPropertyChangeListener(name string, listener IMleListener) MleError
+	AddPropertyChangeListener(name string, listener IMlePropChangeListener) *MleError
 
 	/**
 	 * Removes a <code>IMlePropChangeListener</code> for a specific property.
@@ -131,5 +131,32 @@ type IMleObject interface {
 	 * listener can not be removed. It is also thrown if the <i>name</i> argument
 	 * is <b>null</b>.
 	 */
-	RemovePropertyChangeListener(name string, listener IMleListener) MleError
+	RemovePropertyChangeListener(name string, listener IMlePropChangeListener) *MleError
+
+	/**
+	 * Adds a <code>IMleVetoablePropChangeListener</code> for a specific property,
+	 * or for every property if <i>name</i> is <code>MLE_PROP_CHANGE_ALL</code>.
+	 * The listener is consulted before the property is changed and may veto
+	 * the change. If listener is <b>null</b>, no exception is thrown and no
+	 * action is performed.
+	 *
+	 * @param name The name of the property to listen on.
+	 * @param listener The <code>IMleVetoablePropChangeListener</code> to be added.
+	 *
+	 * @throws MleRuntimeException This exception is thrown if the <i>name</i>
+	 * argument is empty.
+	 */
+	AddVetoablePropertyChangeListener(name string, listener IMleVetoablePropChangeListener) *MleError
+
+	/**
+	 * Removes a <code>IMleVetoablePropChangeListener</code> for a specific property.
+	 * If listener is <b>null</b>, no exception is thrown and no action is performed.
+	 *
+	 * @param name The name of the property that was listened on.
+	 * @param listener The <code>IMleVetoablePropChangeListener</code> to be removed.
+	 *
+	 * @throws MleRuntimeException This exception is thrown if the <i>name</i>
+	 * argument is empty.
+	 */
+	RemoveVetoablePropertyChangeListener(name string, listener IMleVetoablePropChangeListener) *MleError
 }
@@ -45,9 +45,39 @@ import "io"
 /** An unknown property type. */
 const PROP_TYPE_UNKNOWN int = -1
 
+/** A 32-bit integer property. */
+const PROP_TYPE_INT int = 1
+
+/** A 32-bit floating-point property. */
+const PROP_TYPE_FLOAT int = 2
+
+/** A 64-bit floating-point property. */
+const PROP_TYPE_DOUBLE int = 3
+
+/** A boolean property. */
+const PROP_TYPE_BOOL int = 4
+
+/** A string property. */
+const PROP_TYPE_STRING int = 5
+
+/** A 2 element floating-point vector property. */
+const PROP_TYPE_VECTOR2 int = 6
+
+/** A 3 element floating-point vector property. */
+const PROP_TYPE_VECTOR3 int = 7
+
+/** A 4 element floating-point vector property. */
+const PROP_TYPE_VECTOR4 int = 8
+
 /** The Media Reference property. */
 const PROP_TYPE_MEDIAREF int = 10
 
+/** An array of 32-bit integers property. */
+const PROP_TYPE_INT_ARRAY int = 11
+
+/** An array of 32-bit floating-point values property. */
+const PROP_TYPE_FLOAT_ARRAY int = 12
+
 /**
  * This interface identifies the contract for dealing with Magic Lantern
  * properties in a consistent manner.
@@ -61,7 +91,17 @@ type IMleProp interface {
 	 * Valid types include:
 	 * <ul>
 	 * <li>PROP_TYPE_UNKNOWN</li>
+	 * <li>PROP_TYPE_INT</li>
+	 * <li>PROP_TYPE_FLOAT</li>
+	 * <li>PROP_TYPE_DOUBLE</li>
+	 * <li>PROP_TYPE_BOOL</li>
+	 * <li>PROP_TYPE_STRING</li>
+	 * <li>PROP_TYPE_VECTOR2</li>
+	 * <li>PROP_TYPE_VECTOR3</li>
+	 * <li>PROP_TYPE_VECTOR4</li>
 	 * <li>PROP_TYPE_MEDIAREF</li>
+	 * <li>PROP_TYPE_INT_ARRAY</li>
+	 * <li>PROP_TYPE_FLOAT_ARRAY</li>
 	 * </ul>
 	 * </p>
 	 *
@@ -45,4 +45,23 @@ package core
  *
  * @author Mark S. Millard
  */
-type IMleRole interface{}
+type IMleRole interface {
+	/**
+	 * Set the actor for this role.
+	 *
+	 * @param actor The actor to set.
+	 */
+	SetActor(actor *MleActor)
+
+	/**
+	 * Get the actor for this role.
+	 *
+	 * @return A reference to the actor for this role is returned.
+	 */
+	GetActor() *MleActor
+
+	/**
+	 * Initialize the role.
+	 */
+	Init()
+}
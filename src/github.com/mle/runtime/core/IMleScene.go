@@ -46,4 +46,16 @@ package core
  *
  * @author Mark S. Millard
  */
-type IMleScene interface{}
+type IMleScene interface {
+	/**
+	 * Add a Group to the Scene.
+	 *
+	 * @param group The <code>MleGroup</code> to add.
+	 */
+	Add(group *MleGroup)
+
+	/**
+	 * Initialize the scene after its groups have been loaded.
+	 */
+	Init()
+}
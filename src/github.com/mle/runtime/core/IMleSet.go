@@ -47,6 +47,32 @@ package core
  *
  * @author Mark S. Millard
  */
-type IMleSet interface{
+type IMleSet interface {
 	IMleObject
+
+	/**
+	 * Make this the current Set.
+	 */
+	SetCurrentSet()
+
+	/**
+	 * Attach a child role to its parent role.
+	 *
+	 * @param parent The role to attach the child role to.
+	 * @param child The role which is being attached.
+	 */
+	AttachRoles(parent *MleRole, child *MleRole)
+
+	/**
+	 * Bind the set's properties to the fields of an object.
+	 *
+	 * @param owner A pointer to the struct embedding the set.
+	 * @param class The registered class of the set.
+	 */
+	BindProperties(owner interface{}, class string)
+
+	/**
+	 * Initialize the set after its properties have been loaded.
+	 */
+	Init()
 }
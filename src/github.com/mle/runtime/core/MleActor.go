@@ -42,8 +42,6 @@ package core
 // Import go packages.
 import (
 	"io"
-
-	mle_util "github.com/mle/runtime/util"
 )
 
 /**
@@ -77,9 +75,12 @@ import (
 type MleActor struct {
 	/** A reference to the role for this actor to play. */
 	m_role *MleRole
-	/** The collection of "PropChange" event listeners, per property. */
-	//protected HashMap<String,Vector<IMlePropChangeListener>> m_propChangeListeners;
-	m_propChangeListeners map[string](mle_util.Vector)
+	/** The "PropChange" event listeners, per property. */
+	m_propChange *MlePropChangeSupport
+	/** The object whose fields hold the actor's properties. */
+	m_owner interface{}
+	/** The registered class of the actor. */
+	m_class string
 }
 
 /**
@@ -89,8 +90,7 @@ func NewMleActor() *MleActor {
 	p := new(MleActor)
 	// The role should be set to null.
 	p.m_role = nil
-	//m_propChangeListeners = new HashMap<String,Vector<IMlePropChangeListener>>()
-	p.m_propChangeListeners = make(map[string]mle_util.Vector)
+	p.m_propChange = NewMlePropChangeSupport(p)
 	return p
 }
 
@@ -179,87 +179,100 @@ func (actor *MleActor) String() string {
 	return ""
 }
 
-// Implement IMleObject interface.
-
-func (actor *MleActor) GetProperty(name string) IMleProp {
-	// TBD - log something here.
-	return nil
+/**
+ * Bind the actor's properties to the fields of an object.
+ * <p>
+ * Go has no inheritance, so a Actor class embeds <code>MleActor</code> and
+ * declares its properties as fields of the embedding struct. The actor's
+ * property methods access the fields of <b>owner</b>. A field is bound to
+ * the property named by its "mle" tag or, without a tag, by its name
+ * (ignoring case). <code>MleTables</code> binds the actors it creates.
+ * </p>
+ *
+ * @param owner A pointer to the struct embedding this actor.
+ * @param class The registered class of the actor. If the class has
+ * entries in the Actor property table, only declared properties may be set.
+ */
+func (actor *MleActor) BindProperties(owner interface{}, class string) {
+	actor.m_owner = owner
+	actor.m_class = class
+	actor.getPropChange().SetSource(owner)
 }
 
-func (actor *MleActor) SetProperty(name string, property IMleProp) {
-	// TBD - log something here.
+// Get the object whose fields hold the actor's properties.
+func (actor *MleActor) getOwner() interface{} {
+	if actor.m_owner == nil {
+		return actor
+	}
+	return actor.m_owner
 }
 
-func (actor *MleActor) SetPropertyArray(name string, length int, nElements int, value io.ByteReader) {
-	// TBD - log something here.
+// Get the property change support, creating it for a zero value Actor.
+func (actor *MleActor) getPropChange() *MlePropChangeSupport {
+	if actor.m_propChange == nil {
+		actor.m_propChange = NewMlePropChangeSupport(actor.getOwner())
+	}
+	return actor.m_propChange
 }
 
-func (actor *MleActor) AddPropertyChangeListener(name string, listener IMleListener) MleError {
-	var err *MleError
+/**
+ * Set the dispatcher used to defer delivery of the actor's property
+ * change events.
+ *
+ * @param dispatcher The dispatcher, for example an event router which
+ * queues the events as delayed events. If <b>nil</b>, events are
+ * delivered immediately.
+ */
+func (actor *MleActor) SetPropertyChangeDispatcher(dispatcher IMlePropChangeDispatcher) {
+	actor.getPropChange().SetDispatcher(dispatcher)
+}
 
-	// ToDo: can we validate that the listener is an IMlePropChangeListener?
+// Implement IMleObject interface.
 
-	if name == "" {
-		err := NewMleError("Property name must not be empty.", 0, nil)
-		return *err
+func (actor *MleActor) GetProperty(name string) IMleProp {
+	prop, err := getObjectProperty(actor.getOwner(), name)
+	if err != nil {
+		MleLogError("MleActor: "+err.What, false)
+		return nil
 	}
+	return prop
+}
 
-	//Vector<IMlePropChangeListener> listeners;
-	var listeners *mle_util.Vector
-
-	value, found := actor.m_propChangeListeners[name]
-	if !found {
-		// Add a new container to collect the listeners for the named property.
-		listeners = mle_util.NewVector()
-		actor.m_propChangeListeners[name] = *listeners
-	} else {
-		// Use the existing collection container.
-		listeners = &value
+func (actor *MleActor) SetProperty(name string, property IMleProp) {
+	if property == nil {
+		MleLogError("MleActor: property "+name+" is nil.", false)
+		return
+	}
+	tables := GetMleTablesInstance()
+	err := setObjectProperty(actor, actor.getPropChange(), actor.getOwner(), tables.g_mleRTActorProperties, actor.m_class,
+		name, property.GetType(), property.GetLength(), 1, property.GetStream())
+	if err != nil {
+		MleLogError("MleActor: "+err.What, false)
 	}
-
-	// Add the property change listener.
-	listeners.AppendVector(listener)
-
-	return *err
 }
 
-func (actor *MleActor) RemovePropertyChangeListener(name string, listener IMleListener) MleError {
-	var err *MleError
+func (actor *MleActor) SetPropertyArray(name string, length int, nElements int, value io.ByteReader) *MleError {
+	tables := GetMleTablesInstance()
+	return setObjectProperty(actor, actor.getPropChange(), actor.getOwner(), tables.g_mleRTActorProperties, actor.m_class,
+		name, PROP_TYPE_UNKNOWN, length, nElements, value)
+}
 
-	// ToDo: can we validate that the listener is an IMlePropChangeListener?
+func (actor *MleActor) AddPropertyChangeListener(name string, listener IMlePropChangeListener) *MleError {
+	return actor.getPropChange().AddListener(name, listener)
+}
 
-	if name == "" {
-		err := NewMleError("Property name must not be empty.", 0, nil)
-		return *err
-	}
+func (actor *MleActor) RemovePropertyChangeListener(name string, listener IMlePropChangeListener) *MleError {
+	return actor.getPropChange().RemoveListener(name, listener)
+}
 
-	value, found := actor.m_propChangeListeners[name]
-	if found {
-		listeners := value
-		index := listeners.Peek(listener)
-		if index >= 0 {
-			listeners.Delete(index)
-		}
-	}
+func (actor *MleActor) AddVetoablePropertyChangeListener(name string, listener IMleVetoablePropChangeListener) *MleError {
+	return actor.getPropChange().AddVetoableListener(name, listener)
+}
 
-	return *err
+func (actor *MleActor) RemoveVetoablePropertyChangeListener(name string, listener IMleVetoablePropChangeListener) *MleError {
+	return actor.getPropChange().RemoveVetoableListener(name, listener)
 }
 
 func (actor *MleActor) NotifyPropertyChange(name string, oldProperty IMleProp, newProperty IMleProp) {
-	// Create a new "event".
-	args := make(map[string]interface{})
-	args["property"] = name
-	args["old_property"] = oldProperty
-	args["new_property"] = newProperty
-
-	value, found := actor.m_propChangeListeners[name]
-	if found {
-		listeners := value
-		for i := 0; i < len(listeners); i++ {
-			// The expectation is that the listener is an instance of IMlePropChangeListener.
-			// The method PropChangedEvent will be called upon recieving the SendEvent.
-			listener := listeners.ElementAt(i).(IMleListener)
-			listener.SendEvent(actor, args)
-		}
-	}
+	actor.getPropChange().FirePropChange(name, oldProperty, newProperty)
 }
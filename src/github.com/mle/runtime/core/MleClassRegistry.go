@@ -0,0 +1,416 @@
+/**
+ * @file MleClassRegistry.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package core
+
+// Import go packages.
+import (
+	"reflect"
+	"sort"
+	"sync"
+
+	mle_util "github.com/mle/runtime/util"
+)
+
+/** The kind of class that is registered. */
+type MleClassKind int
+
+const (
+	/** An Actor class. */
+	MLE_CLASS_ACTOR MleClassKind = iota
+	/** A Role class. */
+	MLE_CLASS_ROLE
+	/** A Set class. */
+	MLE_CLASS_SET
+	/** A Group class. */
+	MLE_CLASS_GROUP
+	/** A Scene class. */
+	MLE_CLASS_SCENE
+	/** A MediaRef class. */
+	MLE_CLASS_MEDIAREF
+	/** A Runnable class, run by a scheduler task. */
+	MLE_CLASS_RUNNABLE
+)
+
+// The names of the class kinds, used for reporting errors.
+var g_classKindNames = map[MleClassKind]string{
+	MLE_CLASS_ACTOR:    "Actor",
+	MLE_CLASS_ROLE:     "Role",
+	MLE_CLASS_SET:      "Set",
+	MLE_CLASS_GROUP:    "Group",
+	MLE_CLASS_SCENE:    "Scene",
+	MLE_CLASS_MEDIAREF: "MediaRef",
+	MLE_CLASS_RUNNABLE: "Runnable",
+}
+
+// String implements IObject interface.
+func (kind MleClassKind) String() string {
+	return g_classKindNames[kind]
+}
+
+// The base classes satisfy the interfaces required for registration.
+var _ IMleActor = (*MleActor)(nil)
+var _ IMleRole = (*MleRole)(nil)
+var _ IMleSet = (*MleSet)(nil)
+var _ IMleGroup = (*MleGroup)(nil)
+var _ IMleScene = (*MleScene)(nil)
+var _ IMleMediaRef = (*MleMediaRef)(nil)
+
+/**
+ * A class registered with the <code>MleClassRegistry</code>.
+ */
+type MleClassEntry struct {
+	/** The registered class name. */
+	m_name string
+	/** The kind of class. */
+	m_kind MleClassKind
+	/** The type of the instances created by the factory. */
+	m_type reflect.Type
+	/** The factory used to create instances of the class. */
+	m_factory func() interface{}
+}
+
+/**
+ * Get the registered name of the class.
+ *
+ * @return The class name is returned.
+ */
+func (entry *MleClassEntry) GetName() string {
+	return entry.m_name
+}
+
+/**
+ * Get the kind of class.
+ *
+ * @return The kind of class is returned (e.g. MLE_CLASS_ACTOR).
+ */
+func (entry *MleClassEntry) GetKind() MleClassKind {
+	return entry.m_kind
+}
+
+/**
+ * Get the type of the instances created by the class.
+ *
+ * @return The instance type is returned.
+ */
+func (entry *MleClassEntry) GetType() reflect.Type {
+	return entry.m_type
+}
+
+/**
+ * Create an instance of the class.
+ *
+ * @return A new instance is returned.
+ */
+func (entry *MleClassEntry) NewInstance() interface{} {
+	return entry.m_factory()
+}
+
+/**
+ * <code>MleClassRegistry</code> is the registry of the classes that
+ * may be instantiated by name, for example by the <code>MleTables</code>
+ * class entries when loading a playprint.
+ * <p>
+ * Classes are registered with a typed factory using
+ * <code>RegisterActorClass()</code>, <code>RegisterRoleClass()</code>, etc.
+ * The registry is safe for concurrent use.
+ * </p>
+ *
+ * @author Mark S. Millard
+ */
+type MleClassRegistry struct {
+	/** Guards the collection of classes. */
+	m_lock sync.RWMutex
+	/** The registered classes, indexed by name. */
+	m_classes map[string]*MleClassEntry
+}
+
+// The Singleton instance of the class registry.
+var g_theClassRegistry *MleClassRegistry
+var g_classRegistryOnce sync.Once
+
+/**
+ * Get the Singleton instance of the class registry.
+ *
+ * @return A reference to the <code>MleClassRegistry</code> is returned.
+ */
+func GetMleClassRegistryInstance() *MleClassRegistry {
+	g_classRegistryOnce.Do(func() {
+		g_theClassRegistry = new(MleClassRegistry)
+		g_theClassRegistry.m_classes = make(map[string]*MleClassEntry)
+	})
+	return g_theClassRegistry
+}
+
+// Register a class factory.
+func (registry *MleClassRegistry) register(name string, kind MleClassKind, classType reflect.Type, factory func() interface{}) *MleError {
+	if name == "" {
+		return NewMleError("MleClassRegistry: class name must not be empty.", 0, nil)
+	}
+
+	registry.m_lock.Lock()
+	defer registry.m_lock.Unlock()
+
+	if existing, found := registry.m_classes[name]; found {
+		msg := "MleClassRegistry: class " + name + " is already registered as a " + existing.m_kind.String() + "."
+		return NewMleError(msg, 0, nil)
+	}
+	entry := new(MleClassEntry)
+	entry.m_name = name
+	entry.m_kind = kind
+	entry.m_type = classType
+	entry.m_factory = factory
+	registry.m_classes[name] = entry
+	return nil
+}
+
+// Register a typed class factory.
+func registerClass[T any](name string, kind MleClassKind, factory func() T) *MleError {
+	if factory == nil {
+		return NewMleError("MleClassRegistry: class "+name+" has no factory.", 0, nil)
+	}
+	classType := reflect.TypeOf((*T)(nil)).Elem()
+	return GetMleClassRegistryInstance().register(name, kind, classType, func() interface{} {
+		return factory()
+	})
+}
+
+/**
+ * Register an Actor class.
+ *
+ * @param name The name of the class, as referenced by the Actor class table.
+ * @param factory The function used to create instances of the class.
+ *
+ * @throws MleRuntimeException This exception is thrown if the name is
+ * empty or already registered, or the factory is <b>nil</b>.
+ */
+func RegisterActorClass[T IMleActor](name string, factory func() T) *MleError {
+	return registerClass(name, MLE_CLASS_ACTOR, factory)
+}
+
+/**
+ * Register a Role class.
+ *
+ * @param name The name of the class, as referenced by the Role class table.
+ * @param factory The function used to create instances of the class.
+ *
+ * @throws MleRuntimeException This exception is thrown if the name is
+ * empty or already registered, or the factory is <b>nil</b>.
+ */
+func RegisterRoleClass[T IMleRole](name string, factory func() T) *MleError {
+	return registerClass(name, MLE_CLASS_ROLE, factory)
+}
+
+/**
+ * Register a Set class.
+ *
+ * @param name The name of the class, as referenced by the Set class table.
+ * @param factory The function used to create instances of the class.
+ *
+ * @throws MleRuntimeException This exception is thrown if the name is
+ * empty or already registered, or the factory is <b>nil</b>.
+ */
+func RegisterSetClass[T IMleSet](name string, factory func() T) *MleError {
+	return registerClass(name, MLE_CLASS_SET, factory)
+}
+
+/**
+ * Register a Group class.
+ *
+ * @param name The name of the class, as referenced by the Group class table.
+ * @param factory The function used to create instances of the class.
+ *
+ * @throws MleRuntimeException This exception is thrown if the name is
+ * empty or already registered, or the factory is <b>nil</b>.
+ */
+func RegisterGroupClass[T IMleGroup](name string, factory func() T) *MleError {
+	return registerClass(name, MLE_CLASS_GROUP, factory)
+}
+
+/**
+ * Register a Scene class.
+ *
+ * @param name The name of the class, as referenced by the Scene class table.
+ * @param factory The function used to create instances of the class.
+ *
+ * @throws MleRuntimeException This exception is thrown if the name is
+ * empty or already registered, or the factory is <b>nil</b>.
+ */
+func RegisterSceneClass[T IMleScene](name string, factory func() T) *MleError {
+	return registerClass(name, MLE_CLASS_SCENE, factory)
+}
+
+/**
+ * Register a MediaRef class.
+ *
+ * @param name The name of the class, as referenced by the MediaRef class table.
+ * @param factory The function used to create instances of the class.
+ *
+ * @throws MleRuntimeException This exception is thrown if the name is
+ * empty or already registered, or the factory is <b>nil</b>.
+ */
+func RegisterMediaRefClass[T IMleMediaRef](name string, factory func() T) *MleError {
+	return registerClass(name, MLE_CLASS_MEDIAREF, factory)
+}
+
+/**
+ * Register a Runnable class.
+ *
+ * @param name The name of the class, as referenced by a scheduler
+ * configuration.
+ * @param factory The function used to create instances of the class.
+ *
+ * @throws MleRuntimeException This exception is thrown if the name is
+ * empty or already registered, or the factory is <b>nil</b>.
+ */
+func RegisterRunnableClass[T mle_util.Runnable](name string, factory func() T) *MleError {
+	return registerClass(name, MLE_CLASS_RUNNABLE, factory)
+}
+
+/**
+ * Unregister a class.
+ *
+ * @param name The name of the class.
+ *
+ * @return <b>true</b> is returned if the class was registered.
+ */
+func (registry *MleClassRegistry) Unregister(name string) bool {
+	registry.m_lock.Lock()
+	defer registry.m_lock.Unlock()
+
+	_, found := registry.m_classes[name]
+	delete(registry.m_classes, name)
+	return found
+}
+
+/**
+ * Find a registered class.
+ *
+ * @param name The name of the class.
+ *
+ * @return The class entry is returned, or <b>nil</b> if the class
+ * is not registered.
+ */
+func (registry *MleClassRegistry) Lookup(name string) *MleClassEntry {
+	registry.m_lock.RLock()
+	defer registry.m_lock.RUnlock()
+
+	return registry.m_classes[name]
+}
+
+/**
+ * Get the names of the registered classes of the specified kind.
+ *
+ * @param kind The kind of class (e.g. MLE_CLASS_ACTOR).
+ *
+ * @return The class names are returned, in sorted order.
+ */
+func (registry *MleClassRegistry) GetClassNames(kind MleClassKind) []string {
+	registry.m_lock.RLock()
+	defer registry.m_lock.RUnlock()
+
+	var names []string
+	for name, entry := range registry.m_classes {
+		if entry.m_kind == kind {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+/**
+ * Create an instance of a registered class.
+ * <p>
+ * Classes registered in the legacy <code>util.GClassRegistry</code> are
+ * still supported; their <code>NewInstance</code> method is invoked through
+ * reflection, and the result is checked against the requested kind.
+ * </p>
+ *
+ * @param name The name of the class.
+ * @param kind The kind of class expected.
+ *
+ * @return A new instance of the class is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the class is not
+ * registered or is not of the expected kind.
+ */
+func (registry *MleClassRegistry) NewInstance(name string, kind MleClassKind) (interface{}, *MleError) {
+	entry := registry.Lookup(name)
+	if entry != nil {
+		if entry.m_kind != kind {
+			msg := "class " + name + " is a " + entry.m_kind.String() + ", not a " + kind.String() + "."
+			return nil, NewMleError(msg, 0, nil)
+		}
+		return entry.NewInstance(), nil
+	}
+
+	// Fall back to the legacy registry.
+	obj, found := mle_util.GClassRegistry[name]
+	if !found || !mle_util.MethodExists(obj, "NewInstance") {
+		return nil, NewMleError("class "+name+" not found.", 0, nil)
+	}
+	instance, err := mle_util.Invoke(obj, "NewInstance")
+	if err != nil {
+		return nil, NewMleError(err.Error(), 0, err)
+	}
+	newInstance := instance.Interface()
+
+	var ok bool
+	switch kind {
+	case MLE_CLASS_ACTOR:
+		_, ok = newInstance.(IMleActor)
+	case MLE_CLASS_ROLE:
+		_, ok = newInstance.(IMleRole)
+	case MLE_CLASS_SET:
+		_, ok = newInstance.(IMleSet)
+	case MLE_CLASS_GROUP:
+		_, ok = newInstance.(IMleGroup)
+	case MLE_CLASS_SCENE:
+		_, ok = newInstance.(IMleScene)
+	case MLE_CLASS_MEDIAREF:
+		_, ok = newInstance.(IMleMediaRef)
+	case MLE_CLASS_RUNNABLE:
+		_, ok = newInstance.(mle_util.Runnable)
+	}
+	if !ok {
+		return nil, NewMleError("class "+name+" is not a "+kind.String()+".", 0, nil)
+	}
+	return newInstance, nil
+}
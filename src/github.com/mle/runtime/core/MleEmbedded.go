@@ -0,0 +1,122 @@
+/**
+ * @file MleEmbedded.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package core
+
+// Import go packages.
+import (
+	"reflect"
+
+	mle_util "github.com/mle/runtime/util"
+)
+
+// The loaders create objects of registered classes, which embed the base
+// classes of the runtime. These functions find the embedded base class.
+
+/**
+ * Get the <code>MleActor</code> of an Actor created from the class registry.
+ *
+ * @param obj The Actor, which embeds a <code>MleActor</code>.
+ *
+ * @return The embedded <code>MleActor</code> is returned, or nil if there is none.
+ */
+func ToMleActor(obj interface{}) *MleActor {
+	actor, _ := mle_util.GetEmbedded(obj, reflect.TypeOf(MleActor{})).(*MleActor)
+	return actor
+}
+
+/**
+ * Get the <code>MleRole</code> of a Role created from the class registry.
+ *
+ * @param obj The Role, which embeds a <code>MleRole</code>.
+ *
+ * @return The embedded <code>MleRole</code> is returned, or nil if there is none.
+ */
+func ToMleRole(obj interface{}) *MleRole {
+	role, _ := mle_util.GetEmbedded(obj, reflect.TypeOf(MleRole{})).(*MleRole)
+	return role
+}
+
+/**
+ * Get the <code>MleSet</code> of a Set created from the class registry.
+ *
+ * @param obj The Set, which embeds a <code>MleSet</code>.
+ *
+ * @return The embedded <code>MleSet</code> is returned, or nil if there is none.
+ */
+func ToMleSet(obj interface{}) *MleSet {
+	set, _ := mle_util.GetEmbedded(obj, reflect.TypeOf(MleSet{})).(*MleSet)
+	return set
+}
+
+/**
+ * Get the <code>MleGroup</code> of a Group created from the class registry.
+ *
+ * @param obj The Group, which embeds a <code>MleGroup</code>.
+ *
+ * @return The embedded <code>MleGroup</code> is returned, or nil if there is none.
+ */
+func ToMleGroup(obj interface{}) *MleGroup {
+	group, _ := mle_util.GetEmbedded(obj, reflect.TypeOf(MleGroup{})).(*MleGroup)
+	return group
+}
+
+/**
+ * Get the <code>MleScene</code> of a Scene created from the class registry.
+ *
+ * @param obj The Scene, which embeds a <code>MleScene</code>.
+ *
+ * @return The embedded <code>MleScene</code> is returned, or nil if there is none.
+ */
+func ToMleScene(obj interface{}) *MleScene {
+	scene, _ := mle_util.GetEmbedded(obj, reflect.TypeOf(MleScene{})).(*MleScene)
+	return scene
+}
+
+/**
+ * Get the <code>MleMediaRef</code> of a MediaRef created from the class registry.
+ *
+ * @param obj The MediaRef, which embeds a <code>MleMediaRef</code>.
+ *
+ * @return The embedded <code>MleMediaRef</code> is returned, or nil if there is none.
+ */
+func ToMleMediaRef(obj interface{}) *MleMediaRef {
+	mediaref, _ := mle_util.GetEmbedded(obj, reflect.TypeOf(MleMediaRef{})).(*MleMediaRef)
+	return mediaref
+}
@@ -131,3 +131,23 @@ func (group *MleGroup) Remove(actor *MleActor) {
 		group.m_actors.Delete(index)
 	}
 }
+
+/**
+ * Get the number of Actors in the Group.
+ *
+ * @return The number of Actors is returned.
+ */
+func (group *MleGroup) GetNumberOfActors() int {
+	return len(*group.m_actors)
+}
+
+/**
+ * Get the Actor at the specified index.
+ *
+ * @param index The index of the Actor, in the order it was added.
+ *
+ * @return The <code>MleActor</code> is returned.
+ */
+func (group *MleGroup) GetActor(index int) *MleActor {
+	return group.m_actors.ElementAt(index).(*MleActor)
+}
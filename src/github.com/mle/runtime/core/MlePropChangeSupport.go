@@ -0,0 +1,344 @@
+/**
+ * @file MlePropChangeSupport.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package core
+
+// Import go packages.
+import (
+	"reflect"
+	"sync"
+)
+
+/**
+ * <code>MlePropChangeSupport</code> manages the property change listeners
+ * of an <code>IMleObject</code> and delivers <code>MlePropChangeEvent</code>s
+ * to them.
+ * <p>
+ * Listeners are registered for a specific property, or for all properties
+ * using the name <code>MLE_PROP_CHANGE_ALL</code>. Vetoable listeners are
+ * consulted before a property is changed and may reject the change. Property
+ * change events are delivered immediately unless a
+ * <code>IMlePropChangeDispatcher</code> has been set, in which case they are
+ * posted to the dispatcher for later delivery. Vetoable events are always
+ * delivered immediately.
+ * </p>
+ *
+ * @see MleActor
+ * @see MleSet
+ *
+ * @author Mark S. Millard
+ */
+type MlePropChangeSupport struct {
+	/** The source of the fired events. */
+	m_source interface{}
+	/** The "PropChange" event listeners, per property. */
+	m_listeners map[string][]IMlePropChangeListener
+	/** The vetoable "PropChange" event listeners, per property. */
+	m_vetoableListeners map[string][]IMleVetoablePropChangeListener
+	/** The dispatcher used to defer delivery, may be nil. */
+	m_dispatcher IMlePropChangeDispatcher
+	/** Guards the listener collections and the dispatcher. */
+	m_lock sync.Mutex
+}
+
+/**
+ * Constructs a new <code>MlePropChangeSupport</code>.
+ *
+ * @param source The object reported as the source of fired events.
+ */
+func NewMlePropChangeSupport(source interface{}) *MlePropChangeSupport {
+	p := new(MlePropChangeSupport)
+	p.m_source = source
+	p.m_listeners = make(map[string][]IMlePropChangeListener)
+	p.m_vetoableListeners = make(map[string][]IMleVetoablePropChangeListener)
+	return p
+}
+
+/**
+ * Set the object reported as the source of fired events.
+ *
+ * @param source The event source.
+ */
+func (support *MlePropChangeSupport) SetSource(source interface{}) {
+	support.m_lock.Lock()
+	defer support.m_lock.Unlock()
+	support.m_source = source
+}
+
+/**
+ * Set the dispatcher used to defer delivery of property change events.
+ *
+ * @param dispatcher The dispatcher. If <b>nil</b>, events are delivered
+ * immediately.
+ */
+func (support *MlePropChangeSupport) SetDispatcher(dispatcher IMlePropChangeDispatcher) {
+	support.m_lock.Lock()
+	defer support.m_lock.Unlock()
+	support.m_dispatcher = dispatcher
+}
+
+/**
+ * Add a listener for the named property.
+ *
+ * @param name The name of the property, or <code>MLE_PROP_CHANGE_ALL</code>.
+ * @param listener The listener to add. If <b>nil</b>, no action is performed.
+ *
+ * @return An error is returned if <b>name</b> is empty.
+ */
+func (support *MlePropChangeSupport) AddListener(name string, listener IMlePropChangeListener) *MleError {
+	if name == "" {
+		return NewMleError("Property name must not be empty.", 0, nil)
+	}
+	if listener == nil {
+		return nil
+	}
+
+	support.m_lock.Lock()
+	defer support.m_lock.Unlock()
+	support.m_listeners[name] = append(support.m_listeners[name], listener)
+	return nil
+}
+
+/**
+ * Remove a listener for the named property.
+ *
+ * @param name The name the listener was added with.
+ * @param listener The listener to remove. If <b>nil</b>, no action is performed.
+ *
+ * @return An error is returned if <b>name</b> is empty.
+ */
+func (support *MlePropChangeSupport) RemoveListener(name string, listener IMlePropChangeListener) *MleError {
+	if name == "" {
+		return NewMleError("Property name must not be empty.", 0, nil)
+	}
+	if listener == nil {
+		return nil
+	}
+
+	support.m_lock.Lock()
+	defer support.m_lock.Unlock()
+	listeners := support.m_listeners[name]
+	for i := range listeners {
+		if listeners[i] == listener {
+			removed := make([]IMlePropChangeListener, 0, len(listeners)-1)
+			removed = append(removed, listeners[:i]...)
+			removed = append(removed, listeners[i+1:]...)
+			if len(removed) == 0 {
+				delete(support.m_listeners, name)
+			} else {
+				support.m_listeners[name] = removed
+			}
+			break
+		}
+	}
+	return nil
+}
+
+/**
+ * Add a vetoable listener for the named property.
+ *
+ * @param name The name of the property, or <code>MLE_PROP_CHANGE_ALL</code>.
+ * @param listener The listener to add. If <b>nil</b>, no action is performed.
+ *
+ * @return An error is returned if <b>name</b> is empty.
+ */
+func (support *MlePropChangeSupport) AddVetoableListener(name string, listener IMleVetoablePropChangeListener) *MleError {
+	if name == "" {
+		return NewMleError("Property name must not be empty.", 0, nil)
+	}
+	if listener == nil {
+		return nil
+	}
+
+	support.m_lock.Lock()
+	defer support.m_lock.Unlock()
+	support.m_vetoableListeners[name] = append(support.m_vetoableListeners[name], listener)
+	return nil
+}
+
+/**
+ * Remove a vetoable listener for the named property.
+ *
+ * @param name The name the listener was added with.
+ * @param listener The listener to remove. If <b>nil</b>, no action is performed.
+ *
+ * @return An error is returned if <b>name</b> is empty.
+ */
+func (support *MlePropChangeSupport) RemoveVetoableListener(name string, listener IMleVetoablePropChangeListener) *MleError {
+	if name == "" {
+		return NewMleError("Property name must not be empty.", 0, nil)
+	}
+	if listener == nil {
+		return nil
+	}
+
+	support.m_lock.Lock()
+	defer support.m_lock.Unlock()
+	listeners := support.m_vetoableListeners[name]
+	for i := range listeners {
+		if listeners[i] == listener {
+			removed := make([]IMleVetoablePropChangeListener, 0, len(listeners)-1)
+			removed = append(removed, listeners[:i]...)
+			removed = append(removed, listeners[i+1:]...)
+			if len(removed) == 0 {
+				delete(support.m_vetoableListeners, name)
+			} else {
+				support.m_vetoableListeners[name] = removed
+			}
+			break
+		}
+	}
+	return nil
+}
+
+/**
+ * Determine whether there are listeners for the named property.
+ *
+ * @param name The name of the property.
+ *
+ * @return <b>true</b> is returned if a listener for the property, or for
+ * all properties, has been added.
+ */
+func (support *MlePropChangeSupport) HasListeners(name string) bool {
+	support.m_lock.Lock()
+	defer support.m_lock.Unlock()
+	return (len(support.m_listeners[name]) > 0) || (len(support.m_listeners[MLE_PROP_CHANGE_ALL]) > 0)
+}
+
+/**
+ * Ask the vetoable listeners whether the named property may be changed.
+ * <p>
+ * The listeners for the property are consulted first, followed by the
+ * listeners for all properties. The first veto stops the consultation.
+ * </p>
+ *
+ * @param name The name of the property.
+ * @param oldValue The current value of the property.
+ * @param newValue The proposed value of the property.
+ *
+ * @return <b>nil</b> is returned if the change is accepted. Otherwise the
+ * error of the vetoing listener is returned.
+ */
+func (support *MlePropChangeSupport) FireVetoableChange(name string, oldValue interface{}, newValue interface{}) *MleError {
+	support.m_lock.Lock()
+	source := support.m_source
+	named := support.m_vetoableListeners[name]
+	all := support.m_vetoableListeners[MLE_PROP_CHANGE_ALL]
+	listeners := make([]IMleVetoablePropChangeListener, 0, len(named)+len(all))
+	listeners = append(append(listeners, named...), all...)
+	support.m_lock.Unlock()
+
+	if len(listeners) == 0 {
+		return nil
+	}
+	event := NewMlePropChangeEvent(source, name, oldValue, newValue)
+	for _, listener := range listeners {
+		if err := listener.VetoablePropChange(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Report a bound property change.
+ * <p>
+ * If <b>oldValue</b> and <b>newValue</b> are not equal and there are
+ * listeners for the property, a <code>MlePropChangeEvent</code> is fired.
+ * Values which are <code>MleTypedProp</code>s are compared by value.
+ * </p>
+ *
+ * @param name The name of the property.
+ * @param oldValue The old value of the property.
+ * @param newValue The new value of the property.
+ */
+func (support *MlePropChangeSupport) FirePropChange(name string, oldValue interface{}, newValue interface{}) {
+	if propValuesEqual(oldValue, newValue) || !support.HasListeners(name) {
+		return
+	}
+
+	support.m_lock.Lock()
+	source := support.m_source
+	dispatcher := support.m_dispatcher
+	support.m_lock.Unlock()
+
+	event := NewMlePropChangeEvent(source, name, oldValue, newValue)
+	if dispatcher != nil {
+		if dispatcher.PostPropChange(event, support.Deliver) {
+			return
+		}
+		MleLogWarn("MlePropChangeSupport: unable to post change of "+name+", delivering immediately.", false)
+	}
+	support.Deliver(event)
+}
+
+/**
+ * Deliver a property change event to the listeners.
+ * <p>
+ * The listeners for the property are notified first, followed by the
+ * listeners for all properties.
+ * </p>
+ *
+ * @param event The event to deliver.
+ */
+func (support *MlePropChangeSupport) Deliver(event *MlePropChangeEvent) {
+	name := event.GetPropertyName()
+
+	support.m_lock.Lock()
+	named := support.m_listeners[name]
+	all := support.m_listeners[MLE_PROP_CHANGE_ALL]
+	listeners := make([]IMlePropChangeListener, 0, len(named)+len(all))
+	listeners = append(append(listeners, named...), all...)
+	support.m_lock.Unlock()
+
+	for _, listener := range listeners {
+		listener.PropChangedEvent(event)
+	}
+}
+
+// Compare two property values, unwrapping typed properties.
+func propValuesEqual(oldValue interface{}, newValue interface{}) bool {
+	if prop, ok := oldValue.(*MleTypedProp); ok && (prop != nil) {
+		oldValue = prop.GetValue()
+	}
+	if prop, ok := newValue.(*MleTypedProp); ok && (prop != nil) {
+		newValue = prop.GetValue()
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
@@ -0,0 +1,202 @@
+/**
+ * @file MlePropertyBinding.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package core
+
+// Import go packages.
+import (
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	mle_util "github.com/mle/runtime/util"
+)
+
+// The property type bound to each supported field type.
+var g_propFieldTypes = map[reflect.Type]int{
+	reflect.TypeOf(int32(0)):            PROP_TYPE_INT,
+	reflect.TypeOf(int(0)):              PROP_TYPE_INT,
+	reflect.TypeOf(float32(0)):          PROP_TYPE_FLOAT,
+	reflect.TypeOf(float64(0)):          PROP_TYPE_DOUBLE,
+	reflect.TypeOf(false):               PROP_TYPE_BOOL,
+	reflect.TypeOf(""):                  PROP_TYPE_STRING,
+	reflect.TypeOf(MleVector2{}):        PROP_TYPE_VECTOR2,
+	reflect.TypeOf(MleVector3{}):        PROP_TYPE_VECTOR3,
+	reflect.TypeOf(MleVector4{}):        PROP_TYPE_VECTOR4,
+	reflect.TypeOf(MleMediaRefIndex(0)): PROP_TYPE_MEDIAREF,
+	reflect.TypeOf([]int32{}):           PROP_TYPE_INT_ARRAY,
+	reflect.TypeOf([]float32{}):         PROP_TYPE_FLOAT_ARRAY,
+}
+
+// Find the struct field bound to a property.
+//
+// A field is bound to a property if its "mle" tag is the property name
+// (e.g. `mle:"position"`) or, if it has no tag, its name matches the
+// property name, ignoring case. A tag of "-" excludes a field. Bound
+// fields must be exported.
+//
+// Parameters
+//   owner - A pointer to the struct holding the property fields.
+//   name  - The name of the property.
+//
+// Return
+//   The field and its property type are returned.
+func findPropertyField(owner interface{}, name string) (reflect.Value, int, *MleError) {
+	value := reflect.ValueOf(owner)
+	if (value.Kind() != reflect.Ptr) || value.IsNil() || (value.Elem().Kind() != reflect.Struct) {
+		return reflect.Value{}, PROP_TYPE_UNKNOWN, NewMleError("Property "+name+" not found; owner is not a struct.", 0, nil)
+	}
+	value = value.Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		tag := field.Tag.Get("mle")
+		if (tag == "-") || ((tag != "") && (tag != name)) || ((tag == "") && !strings.EqualFold(field.Name, name)) {
+			continue
+		}
+		if field.PkgPath != "" {
+			return reflect.Value{}, PROP_TYPE_UNKNOWN, NewMleError("Property "+name+" is bound to unexported field "+field.Name+".", 0, nil)
+		}
+		propType, found := g_propFieldTypes[field.Type]
+		if !found {
+			msg := "Property " + name + " has unsupported type " + field.Type.String() + "."
+			return reflect.Value{}, PROP_TYPE_UNKNOWN, NewMleError(msg, 0, nil)
+		}
+		return value.Field(i), propType, nil
+	}
+
+	return reflect.Value{}, PROP_TYPE_UNKNOWN, NewMleError("Property "+name+" not found.", 0, nil)
+}
+
+// Verify that a property is declared in a property table.
+//
+// A class with no entries in the table is not checked, so that objects
+// which are not loaded from a playprint (e.g. during rehearsal) may still
+// use their properties.
+func checkPropertyTable(table *mle_util.Vector, class string, name string) *MleError {
+	if class == "" {
+		return nil
+	}
+	hasClass := false
+	for _, element := range *table {
+		entry := element.(*MleRTPropertyEntry)
+		if entry.m_classname == class {
+			if entry.m_fieldname == name {
+				return nil
+			}
+			hasClass = true
+		}
+	}
+	if hasClass {
+		return NewMleError("Property "+name+" is not declared for class "+class+".", 0, nil)
+	}
+	return nil
+}
+
+// Get the value of a property as a typed property.
+func getObjectProperty(owner interface{}, name string) (*MleTypedProp, *MleError) {
+	field, propType, err := findPropertyField(owner, name)
+	if err != nil {
+		return nil, err
+	}
+	value := field.Interface()
+	switch v := value.(type) {
+	case int:
+		value = int32(v)
+	case []int32:
+		value = append([]int32(nil), v...)
+	case []float32:
+		value = append([]float32(nil), v...)
+	}
+	return newMleTypedProp(propType, value), nil
+}
+
+// Set the value of a property from its encoded data and notify listeners
+// of the change. Vetoable listeners are consulted before the field is set.
+//
+// Parameters
+//   obj       - The object the property belongs to, used for notification.
+//   support   - The property change support of obj.
+//   owner     - A pointer to the struct holding the property fields.
+//   table     - The property table the property must be declared in.
+//   class     - The registered class of the owner.
+//   name      - The name of the property.
+//   propType  - The expected property type, or PROP_TYPE_UNKNOWN.
+//   length    - The length of each element of the data, in bytes.
+//   nElements - The number of elements in the data.
+//   stream    - The property data.
+func setObjectProperty(obj IMleObject, support *MlePropChangeSupport, owner interface{}, table *mle_util.Vector, class string,
+	name string, propType int, length int, nElements int, stream io.ByteReader) *MleError {
+	err := checkPropertyTable(table, class, name)
+	if err != nil {
+		return err
+	}
+	field, fieldType, err := findPropertyField(owner, name)
+	if err != nil {
+		return err
+	}
+	if (propType != PROP_TYPE_UNKNOWN) && (propType != fieldType) {
+		msg := "Property " + name + " has type " + strconv.Itoa(fieldType) + ", not " + strconv.Itoa(propType) + "."
+		return NewMleError(msg, 0, nil)
+	}
+
+	newProp, err := DecodeMleProp(fieldType, length, nElements, stream)
+	if err != nil {
+		return err
+	}
+	oldProp, _ := getObjectProperty(owner, name)
+	changed := !reflect.DeepEqual(oldProp.m_value, newProp.m_value)
+	if changed {
+		err = support.FireVetoableChange(name, oldProp, newProp)
+		if err != nil {
+			return NewMleError("Change of property "+name+" vetoed: "+err.What, 0, err)
+		}
+	}
+	field.Set(reflect.ValueOf(newProp.m_value).Convert(field.Type()))
+
+	if changed {
+		// Notify through the owner so that an overridden NotifyPropertyChange is used.
+		if notifier, ok := owner.(IMleObject); ok {
+			obj = notifier
+		}
+		obj.NotifyPropertyChange(name, oldProp, newProp)
+	}
+	return nil
+}
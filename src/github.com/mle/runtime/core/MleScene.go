@@ -217,3 +217,23 @@ func (scene *MleScene) Add(group *MleGroup) {
 func (scene *MleScene) Remove(group *MleGroup) {
 	scene.m_groups.RemoveElement(group)
 }
+
+/**
+ * Get the number of Groups in the Scene.
+ *
+ * @return The number of Groups is returned.
+ */
+func (scene *MleScene) GetNumberOfGroups() int {
+	return len(*scene.m_groups)
+}
+
+/**
+ * Get the Group at the specified index.
+ *
+ * @param index The index of the Group, in the order it was added.
+ *
+ * @return The <code>MleGroup</code> is returned.
+ */
+func (scene *MleScene) GetGroup(index int) *MleGroup {
+	return scene.m_groups.ElementAt(index).(*MleGroup)
+}
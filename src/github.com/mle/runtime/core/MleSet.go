@@ -42,8 +42,6 @@ package core
 // Import go packages.
 import (
 	"io"
-
-	mle_util "github.com/mle/runtime/util"
 )
 
 // Import Magic Lantern packages.
@@ -77,9 +75,12 @@ var g_currentSet *MleSet
  * @version 1.0
  */
 type MleSet struct {
-	/** The collection of "PropChange" event listeners, per property. */
-	//protected HashMap<String,Vector<IMlePropChangeListener>> m_propChangeListeners;
-	m_propChangeListeners map[string](mle_util.Vector)
+	/** The "PropChange" event listeners, per property. */
+	m_propChange *MlePropChangeSupport
+	/** The object whose fields hold the set's properties. */
+	m_owner interface{}
+	/** The registered class of the set. */
+	m_class string
 }
 
 /**
@@ -87,8 +88,7 @@ type MleSet struct {
  */
 func NewMleSet() *MleSet {
 	p := new(MleSet)
-	//m_propChangeListeners = new HashMap<String,Vector<IMlePropChangeListener>>()
-	p.m_propChangeListeners = make(map[string]mle_util.Vector)
+	p.m_propChange = NewMlePropChangeSupport(p)
 	return p
 }
 
@@ -157,82 +157,100 @@ func (set *MleSet) Init() {}
  */
 func (set MleSet) Dispose() {}
 
-// Implement IMleObject interface.
+/**
+ * Bind the set's properties to the fields of an object.
+ * <p>
+ * Go has no inheritance, so a Set class embeds <code>MleSet</code> and
+ * declares its properties as fields of the embedding struct. The set's
+ * property methods access the fields of <b>owner</b>. A field is bound to
+ * the property named by its "mle" tag or, without a tag, by its name
+ * (ignoring case). <code>MleTables</code> binds the sets it creates.
+ * </p>
+ *
+ * @param owner A pointer to the struct embedding this set.
+ * @param class The registered class of the set. If the class has
+ * entries in the Set property table, only declared properties may be set.
+ */
+func (set *MleSet) BindProperties(owner interface{}, class string) {
+	set.m_owner = owner
+	set.m_class = class
+	set.getPropChange().SetSource(owner)
+}
 
-func (set *MleSet) GetProperty(name string) IMleProp {
-	// TBD - log something here.
-	return nil
+// Get the object whose fields hold the set's properties.
+func (set *MleSet) getOwner() interface{} {
+	if set.m_owner == nil {
+		return set
+	}
+	return set.m_owner
 }
 
-func (set *MleSet) SetProperty(name string, property IMleProp) {
-	// TBD - log something here.
+// Get the property change support, creating it for a zero value Set.
+func (set *MleSet) getPropChange() *MlePropChangeSupport {
+	if set.m_propChange == nil {
+		set.m_propChange = NewMlePropChangeSupport(set.getOwner())
+	}
+	return set.m_propChange
 }
 
-func (set *MleSet) SetPropertyArray(name string, length int, nElements int, value io.ByteReader) {
-	// TBD - log something here.
+/**
+ * Set the dispatcher used to defer delivery of the set's property
+ * change events.
+ *
+ * @param dispatcher The dispatcher, for example an event router which
+ * queues the events as delayed events. If <b>nil</b>, events are
+ * delivered immediately.
+ */
+func (set *MleSet) SetPropertyChangeDispatcher(dispatcher IMlePropChangeDispatcher) {
+	set.getPropChange().SetDispatcher(dispatcher)
 }
 
-func (set *MleSet) AddPropertyChangeListener(name string, listener IMleListener) MleError {
-	var err *MleError
+// Implement IMleObject interface.
 
-	if name == "" {
-		err = NewMleError("Property name must not be empty.", 0, nil)
-		return *err
+func (set *MleSet) GetProperty(name string) IMleProp {
+	prop, err := getObjectProperty(set.getOwner(), name)
+	if err != nil {
+		MleLogError("MleSet: "+err.What, false)
+		return nil
 	}
+	return prop
+}
 
-	var listeners *mle_util.Vector
-
-	value, found := set.m_propChangeListeners[name]
-	if !found {
-		// Add a new container to collect the listeners for the named property.
-		listeners = mle_util.NewVector()
-		set.m_propChangeListeners[name] = *listeners
-	} else {
-		// Use the existing collection container.
-		*listeners = value
+func (set *MleSet) SetProperty(name string, property IMleProp) {
+	if property == nil {
+		MleLogError("MleSet: property "+name+" is nil.", false)
+		return
 	}
+	tables := GetMleTablesInstance()
+	err := setObjectProperty(set, set.getPropChange(), set.getOwner(), tables.g_mleRTSetProperties, set.m_class,
+		name, property.GetType(), property.GetLength(), 1, property.GetStream())
+	if err != nil {
+		MleLogError("MleSet: "+err.What, false)
+	}
+}
 
-	// Add the property change listener.
-	listeners.AppendVector(listener)
-
-	return *err
+func (set *MleSet) SetPropertyArray(name string, length int, nElements int, value io.ByteReader) *MleError {
+	tables := GetMleTablesInstance()
+	return setObjectProperty(set, set.getPropChange(), set.getOwner(), tables.g_mleRTSetProperties, set.m_class,
+		name, PROP_TYPE_UNKNOWN, length, nElements, value)
 }
 
-func (set *MleSet) RemovePropertyChangeListener(name string, listener IMleListener) MleError {
-	var err *MleError
+func (set *MleSet) AddPropertyChangeListener(name string, listener IMlePropChangeListener) *MleError {
+	return set.getPropChange().AddListener(name, listener)
+}
 
-	if name == "" {
-		err = NewMleError("Property name must not be empty.", 0, nil)
-		return *err
-	}
+func (set *MleSet) RemovePropertyChangeListener(name string, listener IMlePropChangeListener) *MleError {
+	return set.getPropChange().RemoveListener(name, listener)
+}
 
-	value, found := set.m_propChangeListeners[name]
-	if found {
-		listeners := value
-		index := listeners.Peek(listener)
-		if index >= 0 {
-			listeners.Delete(index)
-		}
-	}
+func (set *MleSet) AddVetoablePropertyChangeListener(name string, listener IMleVetoablePropChangeListener) *MleError {
+	return set.getPropChange().AddVetoableListener(name, listener)
+}
 
-	return *err
+func (set *MleSet) RemoveVetoablePropertyChangeListener(name string, listener IMleVetoablePropChangeListener) *MleError {
+	return set.getPropChange().RemoveVetoableListener(name, listener)
 }
 
 func (set *MleSet) NotifyPropertyChange(name string, oldProperty IMleProp, newProperty IMleProp) {
-	// Create a new "event".
-	args := make(map[string]interface{})
-	args["property"] = name
-	args["old_property"] = oldProperty
-	args["new_property"] = newProperty
-
-	value, found := set.m_propChangeListeners[name]
-	if found {
-		listeners := value
-		for i := 0; i < len(listeners); i++ {
-			// The expectation is that the listener is an instance of IMlePropChangeListener.
-			// The method PropChangedEvent will be called upon recieving the SendEvent.
-			listener := listeners.ElementAt(i).(IMleListener)
-			listener.SendEvent(set, args)
-		}
-	}
+	set.getPropChange().FirePropChange(name, oldProperty, newProperty)
 }
@@ -41,6 +41,8 @@ package core
 
 // Import go packages.
 import (
+	"strconv"
+
 	mle_util "github.com/mle/runtime/util"
 )
 
@@ -181,6 +183,15 @@ func (prop *MleRTPropertyEntry) GetProperty() string {
 	return prop.m_fieldname
 }
 
+/**
+ * Get the name of the class that owns the property.
+ *
+ * @return The name of the class is returned.
+ */
+func (prop *MleRTPropertyEntry) GetClassName() string {
+	return prop.m_classname
+}
+
 /**
  * This class is a runtime Actor Type Table Entry.
  */
@@ -211,39 +222,36 @@ func (acentry *MleRTActorClassEntry) String() string {
 	return ""
 }
 
+/**
+ * Get the name of the Actor class.
+ *
+ * @return The registered class name is returned.
+ */
+func (acentry *MleRTActorClassEntry) GetClassName() string {
+	return acentry.m_classname
+}
+
+/**
+ * Get the offset of the first Actor property in the property table.
+ *
+ * @return The property table offset is returned.
+ */
+func (acentry *MleRTActorClassEntry) GetOffset() int {
+	return acentry.m_offset
+}
+
 // CreateActor creates an instance of an Actor based on an ActorClassEntry.
-// A Class object must have been registered with the ClassFactory.
+// The class must have been registered with the MleClassRegistry.
 func (acentry *MleRTActorClassEntry) CreateActor() (*mle_util.Object, *MleError) {
 	var newActor mle_util.Object
-	var mlerr *MleError
-	var obj interface{}
-
-	// See if class exists in our registry.
-	var found = false
-	for key, data := range mle_util.GClassRegistry {
-		if key == acentry.m_classname {
-			// Found registered class, make sure that it can create a new instance.
-			found = mle_util.MethodExists(data, "NewInstance")
-			if found {
-				// The method name exists and we can proceed.
-				obj = data
-				break
-			}
-		}
-	}
 
-	if !found {
-		// Return an error.
-		msg := "CreateActor: class " + acentry.m_classname + " not found."
-		mlerr = NewMleError(msg, 0, nil)
+	obj, mlerr := GetMleClassRegistryInstance().NewInstance(acentry.m_classname, MLE_CLASS_ACTOR)
+	if mlerr != nil {
+		mlerr = NewMleError("CreateActor: "+mlerr.What, 0, mlerr.Err)
 	} else {
-		// Call method to create an Actor. There are no input or output parameters.
-		var err error
-		newActor, err = mle_util.Invoke(obj, "NewInstance")
-		if err != nil {
-			// Calling method on Class object failed.
-			mlerr = NewMleError(err.Error(), 0, err)
-		}
+		// Bind the Actor's properties to the new instance.
+		obj.(IMleActor).BindProperties(obj, acentry.m_classname)
+		newActor = obj
 	}
 
 	return &newActor, mlerr
@@ -275,44 +283,27 @@ func (rcentry *MleRTRoleClassEntry) String() string {
 	return ""
 }
 
+/**
+ * Get the name of the Role class.
+ *
+ * @return The registered class name is returned.
+ */
+func (rcentry *MleRTRoleClassEntry) GetClassName() string {
+	return rcentry.m_classname
+}
+
 // CreateRole creates an instance of a Role based on a RoleClassEntry.
-// A Class object must have been registered with the ClassFactory.
+// The class must have been registered with the MleClassRegistry.
 func (rcentry *MleRTRoleClassEntry) CreateRole(actor *MleActor) (*mle_util.Object, *MleError) {
 	var newRole mle_util.Object
-	var mlerr *MleError
-	var obj interface{}
-
-	// See if class exists in our registry.
-	var found = false
-	for key, data := range mle_util.GClassRegistry {
-		if key == rcentry.m_classname {
-			// Found registered class, make sure that it can create a new instance.
-			found = mle_util.MethodExists(data, "NewInstance")
-			if found {
-				// The method name exists and we can proceed.
-				obj = data
-				break
-			}
-		}
-	}
 
-	if !found {
-		// Return an error.
-		msg := "CreateRole: class " + rcentry.m_classname + " not found."
-		mlerr = NewMleError(msg, 0, nil)
+	obj, mlerr := GetMleClassRegistryInstance().NewInstance(rcentry.m_classname, MLE_CLASS_ROLE)
+	if mlerr != nil {
+		mlerr = NewMleError("CreateRole: "+mlerr.What, 0, mlerr.Err)
 	} else {
-		// Call method to create a Role. There are no input or output parameters.
-		var err error
-		newRole, err = mle_util.Invoke(obj, "NewInstance")
-		if err != nil {
-			// Calling method on Class object failed.
-			mlerr = NewMleError(err.Error(), 0, err)
-		} else {
-			// Set the Actor on the new Role.
-			//role := newRole.(MleRole)
-			//role.SetActor(actor)
-			newRole.(*MleRole).SetActor(actor)
-		}
+		// Set the Actor on the new Role.
+		obj.(IMleRole).SetActor(actor)
+		newRole = obj
 	}
 
 	return &newRole, mlerr
@@ -348,39 +339,36 @@ func (scentry *MleRTSetClassEntry) String() string {
 	return ""
 }
 
+/**
+ * Get the name of the Set class.
+ *
+ * @return The registered class name is returned.
+ */
+func (scentry *MleRTSetClassEntry) GetClassName() string {
+	return scentry.m_classname
+}
+
+/**
+ * Get the offset of the first Set property in the property table.
+ *
+ * @return The property table offset is returned.
+ */
+func (scentry *MleRTSetClassEntry) GetOffset() int {
+	return scentry.m_offset
+}
+
 // CreateSet creates an instance of a Set based on a SetClassEntry.
-// A Class object must have been registered with the ClassFactory.
+// The class must have been registered with the MleClassRegistry.
 func (scentry *MleRTSetClassEntry) CreateSet() (*mle_util.Object, *MleError) {
 	var newSet mle_util.Object
-	var mlerr *MleError
-	var obj interface{}
-
-	// See if class exists in our registry.
-	var found = false
-	for key, data := range mle_util.GClassRegistry {
-		if key == scentry.m_classname {
-			// Found registered class, make sure that it can create a new instance.
-			found = mle_util.MethodExists(data, "NewInstance")
-			if found {
-				// The method name exists and we can proceed.
-				obj = data
-				break
-			}
-		}
-	}
 
-	if !found {
-		// Return an error.
-		msg := "CreateSet: class " + scentry.m_classname + " not found."
-		mlerr = NewMleError(msg, 0, nil)
+	obj, mlerr := GetMleClassRegistryInstance().NewInstance(scentry.m_classname, MLE_CLASS_SET)
+	if mlerr != nil {
+		mlerr = NewMleError("CreateSet: "+mlerr.What, 0, mlerr.Err)
 	} else {
-		// Call method to create a Set. There are no input or output parameters.
-		var err error
-		newSet, err = mle_util.Invoke(obj, "NewInstance")
-		if err != nil {
-			// Calling method on Class object failed.
-			mlerr = NewMleError(err.Error(), 0, err)
-		}
+		// Bind the Set's properties to the new instance.
+		obj.(IMleSet).BindProperties(obj, scentry.m_classname)
+		newSet = obj
 	}
 
 	return &newSet, mlerr
@@ -412,39 +400,25 @@ func (gcentry *MleRTGroupClassEntry) String() string {
 	return ""
 }
 
+/**
+ * Get the name of the Group class.
+ *
+ * @return The registered class name is returned.
+ */
+func (gcentry *MleRTGroupClassEntry) GetClassName() string {
+	return gcentry.m_classname
+}
+
 // CreateGroup creates an instance of a Group based on a GroupClassEntry.
-// A Class object must have been registered with the ClassFactory.
+// The class must have been registered with the MleClassRegistry.
 func (gcentry *MleRTGroupClassEntry) CreateGroup() (*mle_util.Object, *MleError) {
 	var newGroup mle_util.Object
-	var mlerr *MleError
-	var obj interface{}
-
-	// See if class exists in our registry.
-	var found = false
-	for key, data := range mle_util.GClassRegistry {
-		if key == gcentry.m_classname {
-			// Found registered class, make sure that it can create a new instance.
-			found = mle_util.MethodExists(data, "NewInstance")
-			if found {
-				// The method name exists and we can proceed.
-				obj = data
-				break
-			}
-		}
-	}
 
-	if !found {
-		// Return an error.
-		msg := "CreateGroup: class " + gcentry.m_classname + " not found."
-		mlerr = NewMleError(msg, 0, nil)
+	obj, mlerr := GetMleClassRegistryInstance().NewInstance(gcentry.m_classname, MLE_CLASS_GROUP)
+	if mlerr != nil {
+		mlerr = NewMleError("CreateGroup: "+mlerr.What, 0, mlerr.Err)
 	} else {
-		// Call method to create a Group. There are no input or output parameters.
-		var err error
-		newGroup, err = mle_util.Invoke(obj, "NewInstance")
-		if err != nil {
-			// Calling method on Class object failed.
-			mlerr = NewMleError(err.Error(), 0, err)
-		}
+		newGroup = obj
 	}
 
 	return &newGroup, mlerr
@@ -476,39 +450,25 @@ func (mcentry *MleRTMediaRefClassEntry) String() string {
 	return ""
 }
 
+/**
+ * Get the name of the MediaRef class.
+ *
+ * @return The registered class name is returned.
+ */
+func (mcentry *MleRTMediaRefClassEntry) GetClassName() string {
+	return mcentry.m_classname
+}
+
 // CreateMediaRef creates an instance of a MediaRef based on a MediaRefClassEntry.
-// A Class object must have been registered with the ClassFactory.
+// The class must have been registered with the MleClassRegistry.
 func (mcentry *MleRTMediaRefClassEntry) CreateMediaRef() (*mle_util.Object, *MleError) {
 	var newMediaRef mle_util.Object
-	var mlerr *MleError
-	var obj interface{}
-
-	// See if class exists in our registry.
-	var found = false
-	for key, data := range mle_util.GClassRegistry {
-		if key == mcentry.m_classname {
-			// Found registered class, make sure that it can create a new instance.
-			found = mle_util.MethodExists(data, "NewInstance")
-			if found {
-				// The method name exists and we can proceed.
-				obj = data
-				break
-			}
-		}
-	}
 
-	if !found {
-		// Return an error.
-		msg := "CreateMediaRef: class " + mcentry.m_classname + " not found."
-		mlerr = NewMleError(msg, 0, nil)
+	obj, mlerr := GetMleClassRegistryInstance().NewInstance(mcentry.m_classname, MLE_CLASS_MEDIAREF)
+	if mlerr != nil {
+		mlerr = NewMleError("CreateMediaRef: "+mlerr.What, 0, mlerr.Err)
 	} else {
-		// Call method to create a MediaRef. There are no input or output parameters.
-		var err error
-		newMediaRef, err = mle_util.Invoke(obj, "NewInstance")
-		if err != nil {
-			// Calling method on Class object failed.
-			mlerr = NewMleError(err.Error(), 0, err)
-		}
+		newMediaRef = obj
 	}
 
 	return &newMediaRef, mlerr
@@ -540,39 +500,25 @@ func (scentry *MleRTSceneClassEntry) String() string {
 	return ""
 }
 
+/**
+ * Get the name of the Scene class.
+ *
+ * @return The registered class name is returned.
+ */
+func (scentry *MleRTSceneClassEntry) GetClassName() string {
+	return scentry.m_classname
+}
+
 // CreateScene creates an instance of a Scene based on a SceneClassEntry.
-// A Class object must have been registered with the ClassFactory.
+// The class must have been registered with the MleClassRegistry.
 func (scentry *MleRTSceneClassEntry) CreateScene() (*mle_util.Object, *MleError) {
 	var newScene mle_util.Object
-	var mlerr *MleError
-	var obj interface{}
-
-	// See if class exists in our registry.
-	var found = false
-	for key, data := range mle_util.GClassRegistry {
-		if key == scentry.m_classname {
-			// Found registered class, make sure that it can create a new instance.
-			found = mle_util.MethodExists(data, "NewInstance")
-			if found {
-				// The method name exists and we can proceed.
-				obj = data
-				break
-			}
-		}
-	}
 
-	if !found {
-		// Return an error.
-		msg := "CreateScene: class " + scentry.m_classname + " not found."
-		mlerr = NewMleError(msg, 0, nil)
+	obj, mlerr := GetMleClassRegistryInstance().NewInstance(scentry.m_classname, MLE_CLASS_SCENE)
+	if mlerr != nil {
+		mlerr = NewMleError("CreateScene: "+mlerr.What, 0, mlerr.Err)
 	} else {
-		// Call method to create a Scene. There are no input or output parameters.
-		var err error
-		newScene, err = mle_util.Invoke(obj, "NewInstance")
-		if err != nil {
-			// Calling method on Class object failed.
-			mlerr = NewMleError(err.Error(), 0, err)
-		}
+		newScene = obj
 	}
 
 	return &newScene, mlerr
@@ -598,6 +544,38 @@ func (sentry *MleRTSetEntry) String() string {
 	return ""
 }
 
+/**
+ * Get the name of the Set class.
+ *
+ * @return The registered class name is returned.
+ */
+func (sentry *MleRTSetEntry) GetClassName() string {
+	return sentry.m_classname
+}
+
+/**
+ * Get the Set instance for this entry.
+ *
+ * @return The Set is returned. <b>nil</b> will be returned if the
+ * Set has not yet been loaded.
+ */
+func (sentry *MleRTSetEntry) GetSet() *MleSet {
+	return sentry.m_theSet
+}
+
+/**
+ * Set the Set instance for this entry.
+ * <p>
+ * The loader calls this method once the Set has been created and
+ * initialized from its playprint chunk.
+ * </p>
+ *
+ * @param set The loaded Set.
+ */
+func (sentry *MleRTSetEntry) SetSet(set *MleSet) {
+	sentry.m_theSet = set
+}
+
 type MleRTMediaRefEntry struct {
 	/** The class name for invoking the default constructor. */
 	m_classname string
@@ -615,6 +593,15 @@ func (sentry *MleRTMediaRefEntry) String() string {
 	return ""
 }
 
+/**
+ * Get the name of the MediaRef class.
+ *
+ * @return The registered class name is returned.
+ */
+func (sentry *MleRTMediaRefEntry) GetClassName() string {
+	return sentry.m_classname
+}
+
 /**
  * Add an Actor Property entry.
  *
@@ -626,11 +613,11 @@ func (sentry *MleRTMediaRefEntry) String() string {
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTPropertyEntry</code>.
  */
-func (tables *MleTables) addActorProperty(property *MleRTPropertyEntry) (bool, *MleError) {
+func (tables *MleTables) AddActorProperty(property *MleRTPropertyEntry) (bool, *MleError) {
 	added := true
 
 	if !mle_util.InstanceOf(property, (*MleRTPropertyEntry)(nil)) {
-		msg := "addActorProperty: Not an Actor property."
+		msg := "AddActorProperty: Not an Actor property."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTActorProperties.AddElement(property)
@@ -653,11 +640,11 @@ func (tables *MleTables) addActorProperty(property *MleRTPropertyEntry) (bool, *
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTPropertyEntry</code>.
  */
-func (tables *MleTables) removeActorProperty(property *MleRTPropertyEntry) (bool, *MleError) {
+func (tables *MleTables) RemoveActorProperty(property *MleRTPropertyEntry) (bool, *MleError) {
 	retValue := false
 
 	if !mle_util.InstanceOf(property, (*MleRTPropertyEntry)(nil)) {
-		msg := "removeActorProperty: Not an Actor property."
+		msg := "RemoveActorProperty: Not an Actor property."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTActorProperties.RemoveElement(property)
@@ -680,11 +667,11 @@ func (tables *MleTables) removeActorProperty(property *MleRTPropertyEntry) (bool
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTPropertyEntry</code>.
  */
-func (tables *MleTables) addSetProperty(property *MleRTPropertyEntry) (bool, *MleError) {
+func (tables *MleTables) AddSetProperty(property *MleRTPropertyEntry) (bool, *MleError) {
 	added := true
 
 	if !mle_util.InstanceOf(property, (*MleRTPropertyEntry)(nil)) {
-		msg := "addSetProperty: Not a Set property."
+		msg := "AddSetProperty: Not a Set property."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTSetProperties.AddElement(property)
@@ -707,11 +694,11 @@ func (tables *MleTables) addSetProperty(property *MleRTPropertyEntry) (bool, *Ml
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTPropertyEntry</code>.
  */
-func (tables *MleTables) removeSetProperty(property *MleRTPropertyEntry) (bool, *MleError) {
+func (tables *MleTables) RemoveSetProperty(property *MleRTPropertyEntry) (bool, *MleError) {
 	retValue := false
 
 	if !mle_util.InstanceOf(property, (*MleRTPropertyEntry)(nil)) {
-		msg := "removeSetProperty: Not a Set property."
+		msg := "RemoveSetProperty: Not a Set property."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTSetProperties.RemoveElement(property)
@@ -734,11 +721,11 @@ func (tables *MleTables) removeSetProperty(property *MleRTPropertyEntry) (bool,
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTActorClassEntry</code>.
  */
-func (tables *MleTables) addActorClass(clazz *MleRTActorClassEntry) (bool, *MleError) {
+func (tables *MleTables) AddActorClass(clazz *MleRTActorClassEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(clazz, (*MleRTActorClassEntry)(nil)) {
-		msg := "addActorClass: Not a Actor class."
+		msg := "AddActorClass: Not a Actor class."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTActorClass.AddElement(clazz)
@@ -761,11 +748,11 @@ func (tables *MleTables) addActorClass(clazz *MleRTActorClassEntry) (bool, *MleE
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTActorClassEntry</code>.
  */
-func (tables *MleTables) removeActorClass(clazz *MleRTActorClassEntry) (bool, *MleError) {
+func (tables *MleTables) RemoveActorClass(clazz *MleRTActorClassEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(clazz, (*MleRTActorClassEntry)(nil)) {
-		msg := "removeActorClass: Not a Actor class."
+		msg := "RemoveActorClass: Not a Actor class."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTActorClass.RemoveElement(clazz)
@@ -788,11 +775,11 @@ func (tables *MleTables) removeActorClass(clazz *MleRTActorClassEntry) (bool, *M
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTRoleClassEntry</code>.
  */
-func (tables *MleTables) addRoleClass(clazz *MleRTRoleClassEntry) (bool, *MleError) {
+func (tables *MleTables) AddRoleClass(clazz *MleRTRoleClassEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(clazz, (*MleRTRoleClassEntry)(nil)) {
-		msg := "addRoleClass: Not a Role class."
+		msg := "AddRoleClass: Not a Role class."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTRoleClass.AddElement(clazz)
@@ -815,11 +802,11 @@ func (tables *MleTables) addRoleClass(clazz *MleRTRoleClassEntry) (bool, *MleErr
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTRoleClassEntry</code>.
  */
-func (tables *MleTables) removeRoleClass(clazz *MleRTRoleClassEntry) (bool, *MleError) {
+func (tables *MleTables) RemoveRoleClass(clazz *MleRTRoleClassEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(clazz, (*MleRTRoleClassEntry)(nil)) {
-		msg := "removeRoleClass: Not a Role class."
+		msg := "RemoveRoleClass: Not a Role class."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTRoleClass.RemoveElement(clazz)
@@ -842,11 +829,11 @@ func (tables *MleTables) removeRoleClass(clazz *MleRTRoleClassEntry) (bool, *Mle
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTSetClassEntry</code>.
  */
-func (tables *MleTables) addSetClass(clazz *MleRTSetClassEntry) (bool, *MleError) {
+func (tables *MleTables) AddSetClass(clazz *MleRTSetClassEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(clazz, (*MleRTSetClassEntry)(nil)) {
-		msg := "addSetClass: Not a Set class."
+		msg := "AddSetClass: Not a Set class."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTSetClass.AddElement(clazz)
@@ -869,11 +856,11 @@ func (tables *MleTables) addSetClass(clazz *MleRTSetClassEntry) (bool, *MleError
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTSetClassEntry</code>.
  */
-func (tables *MleTables) removeSetClass(clazz *MleRTSetClassEntry) (bool, *MleError) {
+func (tables *MleTables) RemoveSetClass(clazz *MleRTSetClassEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(clazz, (*MleRTSetClassEntry)(nil)) {
-		msg := "removeSetClass: Not a Set class."
+		msg := "RemoveSetClass: Not a Set class."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTSetClass.RemoveElement(clazz)
@@ -896,11 +883,11 @@ func (tables *MleTables) removeSetClass(clazz *MleRTSetClassEntry) (bool, *MleEr
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTGroupClassEntry</code>.
  */
-func (tables *MleTables) addGroupClass(clazz *MleRTGroupClassEntry) (bool, *MleError) {
+func (tables *MleTables) AddGroupClass(clazz *MleRTGroupClassEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(clazz, (*MleRTGroupClassEntry)(nil)) {
-		msg := "addGroupClass: Not a Group class."
+		msg := "AddGroupClass: Not a Group class."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTGroupClass.AddElement(clazz)
@@ -923,11 +910,11 @@ func (tables *MleTables) addGroupClass(clazz *MleRTGroupClassEntry) (bool, *MleE
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTGroupClassEntry</code>.
  */
-func (tables *MleTables) removeGroupClass(clazz *MleRTGroupClassEntry) (bool, *MleError) {
+func (tables *MleTables) RemoveGroupClass(clazz *MleRTGroupClassEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(clazz, (*MleRTGroupClassEntry)(nil)) {
-		msg := "removeGroupClass: Not a Group class."
+		msg := "RemoveGroupClass: Not a Group class."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTGroupClass.RemoveElement(clazz)
@@ -950,11 +937,11 @@ func (tables *MleTables) removeGroupClass(clazz *MleRTGroupClassEntry) (bool, *M
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTScemeClassEntry</code>.
  */
-func (tables *MleTables) addSceneClass(clazz *MleRTSceneClassEntry) (bool, *MleError) {
+func (tables *MleTables) AddSceneClass(clazz *MleRTSceneClassEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(clazz, (*MleRTSceneClassEntry)(nil)) {
-		msg := "addSceneClass: Not a Scene class."
+		msg := "AddSceneClass: Not a Scene class."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTSceneClass.AddElement(clazz)
@@ -977,11 +964,11 @@ func (tables *MleTables) addSceneClass(clazz *MleRTSceneClassEntry) (bool, *MleE
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTSceneClassEntry</code>.
  */
-func (tables *MleTables) removeSceneClass(clazz *MleRTSceneClassEntry) (bool, *MleError) {
+func (tables *MleTables) RemoveSceneClass(clazz *MleRTSceneClassEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(clazz, (*MleRTSceneClassEntry)(nil)) {
-		msg := "removeSceneClass: Not a Scene class."
+		msg := "RemoveSceneClass: Not a Scene class."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTSceneClass.RemoveElement(clazz)
@@ -1004,11 +991,11 @@ func (tables *MleTables) removeSceneClass(clazz *MleRTSceneClassEntry) (bool, *M
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTSetEntry</code>.
  */
-func (tables *MleTables) addSet(set *MleRTSetEntry) (bool, *MleError) {
+func (tables *MleTables) AddSet(set *MleRTSetEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(set, (*MleRTSetEntry)(nil)) {
-		msg := "addSet: Not a Set."
+		msg := "AddSet: Not a Set."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTSet.AddElement(set)
@@ -1031,11 +1018,11 @@ func (tables *MleTables) addSet(set *MleRTSetEntry) (bool, *MleError) {
  * @throws MleRuntimeException This exception is thrown if the emtry
  * is not an instance of <code>MleRTSetClassEntry</code>.
  */
-func (tables *MleTables) removeSet(set *MleRTSetEntry) (bool, *MleError) {
+func (tables *MleTables) RemoveSet(set *MleRTSetEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(set, (*MleRTSetEntry)(nil)) {
-		msg := "removeSet: Not a Set."
+		msg := "RemoveSet: Not a Set."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTSet.RemoveElement(set)
@@ -1058,11 +1045,11 @@ func (tables *MleTables) removeSet(set *MleRTSetEntry) (bool, *MleError) {
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTMediaRefEntry</code>.
  */
-func (tables *MleTables) addMediaRef(mediaref *MleRTMediaRefEntry) (bool, *MleError) {
+func (tables *MleTables) AddMediaRef(mediaref *MleRTMediaRefEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(mediaref, (*MleRTMediaRefEntry)(nil)) {
-		msg := "addMediaRef: Not a MediaRef."
+		msg := "AddMediaRef: Not a MediaRef."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTMediaRef.AddElement(mediaref)
@@ -1085,11 +1072,11 @@ func (tables *MleTables) addMediaRef(mediaref *MleRTMediaRefEntry) (bool, *MleEr
  * @throws MleRuntimeException This exception is thrown if the entry
  * is not an instance of <code>MleRTMediaRefEntry</code>.
  */
-func (tables *MleTables) removeMediaRef(mediaref *MleRTMediaRefEntry) (bool, *MleError) {
+func (tables *MleTables) RemoveMediaRef(mediaref *MleRTMediaRefEntry) (bool, *MleError) {
 	retValue := true
 
 	if !mle_util.InstanceOf(mediaref, (*MleRTMediaRefEntry)(nil)) {
-		msg := "removeMediaRef: Not a MediaRef."
+		msg := "RemoveMediaRef: Not a MediaRef."
 		return false, NewMleError(msg, 0, nil)
 	}
 	tables.g_mleRTMediaRef.RemoveElement(mediaref)
@@ -1101,6 +1088,455 @@ func (tables *MleTables) removeMediaRef(mediaref *MleRTMediaRefEntry) (bool, *Ml
 	return retValue, nil
 }
 
+/**
+ * Add a Media Reference Class entry.
+ *
+ * @param clazz The entry to add.
+ *
+ * @return If the class is successfully added, then <b>true</b>
+ * will be returned. Otherwise, <b>false</b> will be returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the entry
+ * is not an instance of <code>MleRTMediaRefClassEntry</code>.
+ */
+func (tables *MleTables) AddMediaRefClass(clazz *MleRTMediaRefClassEntry) (bool, *MleError) {
+	retValue := true
+
+	if !mle_util.InstanceOf(clazz, (*MleRTMediaRefClassEntry)(nil)) {
+		msg := "AddMediaRefClass: Not a MediaRef class."
+		return false, NewMleError(msg, 0, nil)
+	}
+	tables.g_mleRTMediaRefClass.AddElement(clazz)
+
+	// Notify observers of change.
+	tables.m_observable.SetChanged()
+	tables.m_observable.NotifyObserversWithObject(clazz)
+
+	return retValue, nil
+}
+
+/**
+ * Remove a Media Reference Class entry.
+ *
+ * @param clazz The entry to remove.
+ *
+ * @return If the class is successfully removed, then <b>true</b>
+ * will be returned. Otherwise, <b>false</b> will be returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the entry
+ * is not an instance of <code>MleRTMediaRefClassEntry</code>.
+ */
+func (tables *MleTables) RemoveMediaRefClass(clazz *MleRTMediaRefClassEntry) (bool, *MleError) {
+	retValue := true
+
+	if !mle_util.InstanceOf(clazz, (*MleRTMediaRefClassEntry)(nil)) {
+		msg := "RemoveMediaRefClass: Not a MediaRef class."
+		return false, NewMleError(msg, 0, nil)
+	}
+	tables.g_mleRTMediaRefClass.RemoveElement(clazz)
+
+	// Notify observers of change.
+	tables.m_observable.SetChanged()
+	tables.m_observable.NotifyObserversWithObject(clazz)
+
+	return retValue, nil
+}
+
+// Retrieve the entry at the specified index of a table.
+func getTableEntry(table *mle_util.Vector, index int, caller string) (interface{}, *MleError) {
+	if (index < 0) || (index >= len(*table)) {
+		msg := caller + ": index " + strconv.Itoa(index) + " out of range."
+		return nil, NewMleError(msg, 0, nil)
+	}
+	return table.ElementAt(index), nil
+}
+
+/**
+ * Get the Actor Property entry at the specified index.
+ *
+ * @param index The index into the Actor property table.
+ *
+ * @return The property entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the index
+ * is out of range.
+ */
+func (tables *MleTables) GetActorProperty(index int) (*MleRTPropertyEntry, *MleError) {
+	entry, err := getTableEntry(tables.g_mleRTActorProperties, index, "GetActorProperty")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTPropertyEntry), nil
+}
+
+/**
+ * Get the Set Property entry at the specified index.
+ *
+ * @param index The index into the Set property table.
+ *
+ * @return The property entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the index
+ * is out of range.
+ */
+func (tables *MleTables) GetSetProperty(index int) (*MleRTPropertyEntry, *MleError) {
+	entry, err := getTableEntry(tables.g_mleRTSetProperties, index, "GetSetProperty")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTPropertyEntry), nil
+}
+
+/**
+ * Get the Actor Class entry at the specified index.
+ *
+ * @param index The index into the Actor class table.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the index
+ * is out of range.
+ */
+func (tables *MleTables) GetActorClass(index int) (*MleRTActorClassEntry, *MleError) {
+	entry, err := getTableEntry(tables.g_mleRTActorClass, index, "GetActorClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTActorClassEntry), nil
+}
+
+/**
+ * Get the Role Class entry at the specified index.
+ *
+ * @param index The index into the Role class table.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the index
+ * is out of range.
+ */
+func (tables *MleTables) GetRoleClass(index int) (*MleRTRoleClassEntry, *MleError) {
+	entry, err := getTableEntry(tables.g_mleRTRoleClass, index, "GetRoleClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTRoleClassEntry), nil
+}
+
+/**
+ * Get the Set Class entry at the specified index.
+ *
+ * @param index The index into the Set class table.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the index
+ * is out of range.
+ */
+func (tables *MleTables) GetSetClass(index int) (*MleRTSetClassEntry, *MleError) {
+	entry, err := getTableEntry(tables.g_mleRTSetClass, index, "GetSetClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTSetClassEntry), nil
+}
+
+/**
+ * Get the Group Class entry at the specified index.
+ *
+ * @param index The index into the Group class table.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the index
+ * is out of range.
+ */
+func (tables *MleTables) GetGroupClass(index int) (*MleRTGroupClassEntry, *MleError) {
+	entry, err := getTableEntry(tables.g_mleRTGroupClass, index, "GetGroupClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTGroupClassEntry), nil
+}
+
+/**
+ * Get the Scene Class entry at the specified index.
+ *
+ * @param index The index into the Scene class table.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the index
+ * is out of range.
+ */
+func (tables *MleTables) GetSceneClass(index int) (*MleRTSceneClassEntry, *MleError) {
+	entry, err := getTableEntry(tables.g_mleRTSceneClass, index, "GetSceneClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTSceneClassEntry), nil
+}
+
+/**
+ * Get the Media Reference Class entry at the specified index.
+ *
+ * @param index The index into the MediaRef class table.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the index
+ * is out of range.
+ */
+func (tables *MleTables) GetMediaRefClass(index int) (*MleRTMediaRefClassEntry, *MleError) {
+	entry, err := getTableEntry(tables.g_mleRTMediaRefClass, index, "GetMediaRefClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTMediaRefClassEntry), nil
+}
+
+// Retrieve the class entry with the specified class name from a table.
+func findClassEntry(table *mle_util.Vector, name string, caller string) (interface{}, *MleError) {
+	for _, entry := range *table {
+		if entry.(interface{ GetClassName() string }).GetClassName() == name {
+			return entry, nil
+		}
+	}
+	return nil, NewMleError(caller+": class "+name+" is not in the table.", 0, nil)
+}
+
+/**
+ * Find the Actor Class entry with the specified class name.
+ *
+ * @param name The class name.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the class
+ * is not in the Actor class table.
+ */
+func (tables *MleTables) FindActorClass(name string) (*MleRTActorClassEntry, *MleError) {
+	entry, err := findClassEntry(tables.g_mleRTActorClass, name, "FindActorClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTActorClassEntry), nil
+}
+
+/**
+ * Find the Role Class entry with the specified class name.
+ *
+ * @param name The class name.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the class
+ * is not in the Role class table.
+ */
+func (tables *MleTables) FindRoleClass(name string) (*MleRTRoleClassEntry, *MleError) {
+	entry, err := findClassEntry(tables.g_mleRTRoleClass, name, "FindRoleClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTRoleClassEntry), nil
+}
+
+/**
+ * Find the Set Class entry with the specified class name.
+ *
+ * @param name The class name.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the class
+ * is not in the Set class table.
+ */
+func (tables *MleTables) FindSetClass(name string) (*MleRTSetClassEntry, *MleError) {
+	entry, err := findClassEntry(tables.g_mleRTSetClass, name, "FindSetClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTSetClassEntry), nil
+}
+
+/**
+ * Find the Group Class entry with the specified class name.
+ *
+ * @param name The class name.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the class
+ * is not in the Group class table.
+ */
+func (tables *MleTables) FindGroupClass(name string) (*MleRTGroupClassEntry, *MleError) {
+	entry, err := findClassEntry(tables.g_mleRTGroupClass, name, "FindGroupClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTGroupClassEntry), nil
+}
+
+/**
+ * Find the Scene Class entry with the specified class name.
+ *
+ * @param name The class name.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the class
+ * is not in the Scene class table.
+ */
+func (tables *MleTables) FindSceneClass(name string) (*MleRTSceneClassEntry, *MleError) {
+	entry, err := findClassEntry(tables.g_mleRTSceneClass, name, "FindSceneClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTSceneClassEntry), nil
+}
+
+/**
+ * Find the Media Reference Class entry with the specified class name.
+ *
+ * @param name The class name.
+ *
+ * @return The class entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the class
+ * is not in the MediaRef class table.
+ */
+func (tables *MleTables) FindMediaRefClass(name string) (*MleRTMediaRefClassEntry, *MleError) {
+	entry, err := findClassEntry(tables.g_mleRTMediaRefClass, name, "FindMediaRefClass")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTMediaRefClassEntry), nil
+}
+
+/**
+ * Get the Set entry at the specified index.
+ *
+ * @param index The index into the Set table.
+ *
+ * @return The Set entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the index
+ * is out of range.
+ */
+func (tables *MleTables) GetSet(index int) (*MleRTSetEntry, *MleError) {
+	entry, err := getTableEntry(tables.g_mleRTSet, index, "GetSet")
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*MleRTSetEntry), nil
+}
+
+/**
+ * Get the number of entries in the Set table.
+ *
+ * @return The number of Sets is returned.
+ */
+func (tables *MleTables) GetNumberOfSets() int {
+	return len(*tables.g_mleRTSet)
+}
+
+// Retrieve the chunk number at the specified index of a chunk table.
+func getChunk(chunks []int, index int, caller string) (int, *MleError) {
+	if (index < 0) || (index >= len(chunks)) {
+		msg := caller + ": index " + strconv.Itoa(index) + " out of range."
+		return -1, NewMleError(msg, 0, nil)
+	}
+	return chunks[index], nil
+}
+
+/**
+ * Set the playprint chunk table for the Sets.
+ *
+ * @param chunks The chunk numbers, indexed by Set.
+ */
+func (tables *MleTables) SetSetChunks(chunks []int) {
+	g_mleRTSetChunk = chunks
+}
+
+/**
+ * Get the playprint chunk number for the specified Set.
+ *
+ * @param index The index of the Set.
+ *
+ * @return The chunk number is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if no chunk
+ * exists for the specified Set.
+ */
+func (tables *MleTables) GetSetChunk(index int) (int, *MleError) {
+	return getChunk(g_mleRTSetChunk, index, "GetSetChunk")
+}
+
+/**
+ * Set the playprint chunk table for the Groups.
+ *
+ * @param chunks The chunk numbers, indexed by Group.
+ */
+func (tables *MleTables) SetGroupChunks(chunks []int) {
+	g_mleRTGroupChunk = chunks
+}
+
+/**
+ * Get the playprint chunk number for the specified Group.
+ *
+ * @param index The index of the Group.
+ *
+ * @return The chunk number is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if no chunk
+ * exists for the specified Group.
+ */
+func (tables *MleTables) GetGroupChunk(index int) (int, *MleError) {
+	return getChunk(g_mleRTGroupChunk, index, "GetGroupChunk")
+}
+
+/**
+ * Set the playprint chunk table for the Scenes.
+ *
+ * @param chunks The chunk numbers, indexed by Scene.
+ */
+func (tables *MleTables) SetSceneChunks(chunks []int) {
+	g_mleRTSceneChunk = chunks
+}
+
+/**
+ * Get the playprint chunk number for the specified Scene.
+ *
+ * @param index The index of the Scene.
+ *
+ * @return The chunk number is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if no chunk
+ * exists for the specified Scene.
+ */
+func (tables *MleTables) GetSceneChunk(index int) (int, *MleError) {
+	return getChunk(g_mleRTSceneChunk, index, "GetSceneChunk")
+}
+
+/**
+ * Set the boot Scene.
+ *
+ * @param scene The index of the Scene to load when the title is booted.
+ */
+func (tables *MleTables) SetBootScene(scene int) {
+	g_mleBootScene = scene
+}
+
+/**
+ * Get the boot Scene.
+ *
+ * @return The index of the boot Scene is returned. <b>-1</b> will be
+ * returned if no boot Scene has been specified.
+ */
+func (tables *MleTables) GetBootScene() int {
+	return g_mleBootScene
+}
+
 /**
  * Register the specified Magic Lantern Object.
  * <p>
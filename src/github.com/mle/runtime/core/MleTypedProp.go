@@ -0,0 +1,369 @@
+/**
+ * @file MleTypedProp.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package core
+
+// Import go packages.
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"strconv"
+)
+
+/** A 2 element floating-point vector. */
+type MleVector2 [2]float32
+
+/** A 3 element floating-point vector. */
+type MleVector3 [3]float32
+
+/** A 4 element floating-point vector. */
+type MleVector4 [4]float32
+
+/**
+ * A reference to a MediaRef, by its index in the title's MediaRef table.
+ * Actor and Set fields of this type are bound to PROP_TYPE_MEDIAREF
+ * properties.
+ */
+type MleMediaRefIndex int32
+
+/**
+ * This class implements a property with a known data type.
+ * MleTypedProp implements the IMleProp interface.
+ * <p>
+ * The property data is encoded in big-endian byte order, the same
+ * encoding used by the Digital Playprint. Vectors and arrays are
+ * encoded as consecutive elements.
+ * </p>
+ *
+ * @author Mark S. Millard
+ */
+type MleTypedProp struct {
+	/** The property type. */
+	m_type int
+	/** The property value. */
+	m_value interface{}
+}
+
+// Construct a property of the specified type.
+func newMleTypedProp(propType int, value interface{}) *MleTypedProp {
+	p := new(MleTypedProp)
+	p.m_type = propType
+	p.m_value = value
+	return p
+}
+
+/**
+ * Create a PROP_TYPE_INT property.
+ *
+ * @param value The property value.
+ */
+func NewMleIntProp(value int32) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_INT, value)
+}
+
+/**
+ * Create a PROP_TYPE_FLOAT property.
+ *
+ * @param value The property value.
+ */
+func NewMleFloatProp(value float32) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_FLOAT, value)
+}
+
+/**
+ * Create a PROP_TYPE_DOUBLE property.
+ *
+ * @param value The property value.
+ */
+func NewMleDoubleProp(value float64) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_DOUBLE, value)
+}
+
+/**
+ * Create a PROP_TYPE_BOOL property.
+ *
+ * @param value The property value.
+ */
+func NewMleBoolProp(value bool) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_BOOL, value)
+}
+
+/**
+ * Create a PROP_TYPE_STRING property.
+ *
+ * @param value The property value.
+ */
+func NewMleStringProp(value string) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_STRING, value)
+}
+
+/**
+ * Create a PROP_TYPE_VECTOR2 property.
+ *
+ * @param value The property value.
+ */
+func NewMleVector2Prop(value MleVector2) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_VECTOR2, value)
+}
+
+/**
+ * Create a PROP_TYPE_VECTOR3 property.
+ *
+ * @param value The property value.
+ */
+func NewMleVector3Prop(value MleVector3) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_VECTOR3, value)
+}
+
+/**
+ * Create a PROP_TYPE_VECTOR4 property.
+ *
+ * @param value The property value.
+ */
+func NewMleVector4Prop(value MleVector4) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_VECTOR4, value)
+}
+
+/**
+ * Create a PROP_TYPE_MEDIAREF property.
+ *
+ * @param value The index of the MediaRef.
+ */
+func NewMleMediaRefProp(value MleMediaRefIndex) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_MEDIAREF, value)
+}
+
+/**
+ * Create a PROP_TYPE_INT_ARRAY property.
+ *
+ * @param value The property value.
+ */
+func NewMleIntArrayProp(value []int32) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_INT_ARRAY, value)
+}
+
+/**
+ * Create a PROP_TYPE_FLOAT_ARRAY property.
+ *
+ * @param value The property value.
+ */
+func NewMleFloatArrayProp(value []float32) *MleTypedProp {
+	return newMleTypedProp(PROP_TYPE_FLOAT_ARRAY, value)
+}
+
+// String implements IObject interface.
+func (prop *MleTypedProp) String() string {
+	return strconv.Itoa(prop.m_type)
+}
+
+/**
+ * Get the property type.
+ *
+ * @return The property's type is returned.
+ *
+ * @see IMleProp.GetType()
+ */
+func (prop *MleTypedProp) GetType() int {
+	return prop.m_type
+}
+
+/**
+ * Get the property value.
+ * <p>
+ * The dynamic type of the value depends on the property type; e.g. a
+ * PROP_TYPE_INT property has an <code>int32</code> value and a
+ * PROP_TYPE_VECTOR3 property has a <code>MleVector3</code> value.
+ * </p>
+ *
+ * @return The property value is returned.
+ */
+func (prop *MleTypedProp) GetValue() interface{} {
+	return prop.m_value
+}
+
+// Encode the property value.
+func (prop *MleTypedProp) encode() []byte {
+	var buf bytes.Buffer
+	switch value := prop.m_value.(type) {
+	case string:
+		buf.WriteString(value)
+	case bool:
+		if value {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+	default:
+		// Fixed size values, vectors and arrays of fixed size values.
+		binary.Write(&buf, binary.BigEndian, value)
+	}
+	return buf.Bytes()
+}
+
+/**
+ * Get the length of the property data.
+ *
+ * @return The size of the encoded property data is returned.
+ *
+ * @see IMleProp.GetLength()
+ */
+func (prop *MleTypedProp) GetLength() int {
+	return len(prop.encode())
+}
+
+/**
+ * Get the property data as an input stream.
+ * <p>
+ * A new stream is returned on each call.
+ * </p>
+ *
+ * @return An input stream is returned.
+ *
+ * @see IMleProp.GetStream()
+ */
+func (prop *MleTypedProp) GetStream() io.ByteReader {
+	return bytes.NewReader(prop.encode())
+}
+
+// The size, in bytes, of each element of the fixed size property types.
+var g_propElementSize = map[int]int{
+	PROP_TYPE_INT:         4,
+	PROP_TYPE_FLOAT:       4,
+	PROP_TYPE_DOUBLE:      8,
+	PROP_TYPE_BOOL:        1,
+	PROP_TYPE_VECTOR2:     8,
+	PROP_TYPE_VECTOR3:     12,
+	PROP_TYPE_VECTOR4:     16,
+	PROP_TYPE_MEDIAREF:    4,
+	PROP_TYPE_INT_ARRAY:   4,
+	PROP_TYPE_FLOAT_ARRAY: 4,
+}
+
+/**
+ * Decode a property from an input stream.
+ * <p>
+ * <b>length</b> * <b>nElements</b> bytes are read from the stream. For
+ * scalar and vector types the data must be exactly the size of the type;
+ * for array types it must be a multiple of the element size.
+ * </p>
+ *
+ * @param propType The type of the property (e.g. PROP_TYPE_VECTOR3).
+ * @param length The length of each element in the stream, in bytes.
+ * @param nElements The number of elements in the stream.
+ * @param stream The property data.
+ *
+ * @return The decoded property is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the data can
+ * not be read or does not match the property type.
+ */
+func DecodeMleProp(propType int, length int, nElements int, stream io.ByteReader) (*MleTypedProp, *MleError) {
+	if (length < 0) || (nElements < 0) {
+		return nil, NewMleError("DecodeMleProp: invalid property size.", 0, nil)
+	}
+	size := length * nElements
+	data := make([]byte, size)
+	for i := 0; i < size; i++ {
+		b, err := stream.ReadByte()
+		if err != nil {
+			return nil, NewMleError("DecodeMleProp: property data is truncated.", 0, err)
+		}
+		data[i] = b
+	}
+
+	if propType == PROP_TYPE_STRING {
+		return NewMleStringProp(string(data)), nil
+	}
+
+	elementSize, found := g_propElementSize[propType]
+	if !found {
+		return nil, NewMleError("DecodeMleProp: unknown property type "+strconv.Itoa(propType)+".", 0, nil)
+	}
+	isArray := (propType == PROP_TYPE_INT_ARRAY) || (propType == PROP_TYPE_FLOAT_ARRAY)
+	if (isArray && (size%elementSize != 0)) || (!isArray && (size != elementSize)) {
+		msg := "DecodeMleProp: " + strconv.Itoa(size) + " bytes does not match property type " + strconv.Itoa(propType) + "."
+		return nil, NewMleError(msg, 0, nil)
+	}
+
+	reader := bytes.NewReader(data)
+	var value interface{}
+	switch propType {
+	case PROP_TYPE_INT:
+		var v int32
+		binary.Read(reader, binary.BigEndian, &v)
+		value = v
+	case PROP_TYPE_FLOAT:
+		var v uint32
+		binary.Read(reader, binary.BigEndian, &v)
+		value = math.Float32frombits(v)
+	case PROP_TYPE_DOUBLE:
+		var v uint64
+		binary.Read(reader, binary.BigEndian, &v)
+		value = math.Float64frombits(v)
+	case PROP_TYPE_BOOL:
+		value = data[0] != 0
+	case PROP_TYPE_VECTOR2:
+		var v MleVector2
+		binary.Read(reader, binary.BigEndian, &v)
+		value = v
+	case PROP_TYPE_VECTOR3:
+		var v MleVector3
+		binary.Read(reader, binary.BigEndian, &v)
+		value = v
+	case PROP_TYPE_VECTOR4:
+		var v MleVector4
+		binary.Read(reader, binary.BigEndian, &v)
+		value = v
+	case PROP_TYPE_MEDIAREF:
+		var v MleMediaRefIndex
+		binary.Read(reader, binary.BigEndian, &v)
+		value = v
+	case PROP_TYPE_INT_ARRAY:
+		v := make([]int32, size/elementSize)
+		binary.Read(reader, binary.BigEndian, v)
+		value = v
+	case PROP_TYPE_FLOAT_ARRAY:
+		v := make([]float32, size/elementSize)
+		binary.Read(reader, binary.BigEndian, v)
+		value = v
+	}
+	return newMleTypedProp(propType, value), nil
+}
@@ -0,0 +1,257 @@
+/**
+ * @file MleDppInput.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dpp
+
+// Import go packages.
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"strconv"
+
+	mle_core "github.com/mle/runtime/core"
+)
+
+/**
+ * An entry in the playprint chunk table.
+ */
+type MleDppChunk struct {
+	/** The type of chunk. */
+	m_type int32
+	/** The offset of the chunk data from the beginning of the playprint. */
+	m_offset int
+	/** The length of the chunk data, in bytes. */
+	m_length int
+}
+
+/**
+ * Get the type of the chunk.
+ *
+ * @return The chunk type is returned. Valid types include:
+ * <ul>
+ *   <li>MLE_DPP_CHUNK_SET</li>
+ *   <li>MLE_DPP_CHUNK_GROUP</li>
+ *   <li>MLE_DPP_CHUNK_SCENE</li>
+ *   <li>MLE_DPP_CHUNK_MEDIAREF</li>
+ * </ul>
+ */
+func (chunk *MleDppChunk) GetType() int32 {
+	return chunk.m_type
+}
+
+/**
+ * Get the length of the chunk data.
+ *
+ * @return The length of the chunk, in bytes, is returned.
+ */
+func (chunk *MleDppChunk) GetLength() int {
+	return chunk.m_length
+}
+
+/**
+ * <code>MleDppInput</code> provides access to the contents of a
+ * Digital Playprint.
+ * <p>
+ * A playprint begins with a header, followed by a table of chunks.
+ * All values are stored in big-endian (network) byte order.
+ * </p><p>
+ * <pre>
+ *   header:      magic (MLE_DPP_MAGIC), version, boot scene, number of chunks
+ *   chunk table: type, offset, length (one entry per chunk)
+ *   chunk data
+ * </pre>
+ * </p>
+ *
+ * @see MleDppLoader
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDppInput struct {
+	/** The playprint contents. */
+	m_data []byte
+	/** The playprint format version. */
+	m_version int32
+	/** The index of the boot Scene. */
+	m_bootScene int
+	/** The chunk table. */
+	m_chunks []*MleDppChunk
+}
+
+/**
+ * Create a <code>MleDppInput</code> for the specified playprint file.
+ *
+ * @param filename The name of the playprint file.
+ *
+ * @throws MleRuntimeException This exception is thrown if the file
+ * can not be read or is not a valid playprint.
+ */
+func NewMleDppInput(filename string) (*MleDppInput, *mle_core.MleError) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		msg := "MleDppInput: unable to read playprint " + filename + "."
+		return nil, mle_core.NewMleError(msg, 0, err)
+	}
+	return NewMleDppInputWithBytes(data)
+}
+
+/**
+ * Create a <code>MleDppInput</code> for a playprint that is already
+ * in memory.
+ *
+ * @param data The playprint contents.
+ *
+ * @throws MleRuntimeException This exception is thrown if the data
+ * is not a valid playprint.
+ */
+func NewMleDppInputWithBytes(data []byte) (*MleDppInput, *mle_core.MleError) {
+	p := new(MleDppInput)
+	p.m_data = data
+	err := p.readHeader()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Read a big-endian 32-bit integer at the specified offset.
+func (input *MleDppInput) readInt(offset int) int32 {
+	return int32(binary.BigEndian.Uint32(input.m_data[offset : offset+4]))
+}
+
+// Parse the header and chunk table.
+func (input *MleDppInput) readHeader() *mle_core.MleError {
+	if len(input.m_data) < MLE_DPP_HEADER_SIZE {
+		return mle_core.NewMleError("MleDppInput: playprint is truncated.", 0, nil)
+	}
+	if uint32(input.readInt(0)) != MLE_DPP_MAGIC {
+		return mle_core.NewMleError("MleDppInput: not a Digital Playprint.", 0, nil)
+	}
+	input.m_version = input.readInt(4)
+	if input.m_version != MLE_DPP_VERSION {
+		msg := "MleDppInput: unsupported playprint version " + strconv.Itoa(int(input.m_version)) + "."
+		return mle_core.NewMleError(msg, 0, nil)
+	}
+	input.m_bootScene = int(input.readInt(8))
+
+	numChunks := int(input.readInt(12))
+	if (numChunks < 0) || (len(input.m_data) < MLE_DPP_HEADER_SIZE+(numChunks*MLE_DPP_CHUNK_ENTRY_SIZE)) {
+		return mle_core.NewMleError("MleDppInput: chunk table is truncated.", 0, nil)
+	}
+
+	input.m_chunks = make([]*MleDppChunk, numChunks)
+	for i := 0; i < numChunks; i++ {
+		offset := MLE_DPP_HEADER_SIZE + (i * MLE_DPP_CHUNK_ENTRY_SIZE)
+		chunk := new(MleDppChunk)
+		chunk.m_type = input.readInt(offset)
+		chunk.m_offset = int(input.readInt(offset + 4))
+		chunk.m_length = int(input.readInt(offset + 8))
+		if (chunk.m_offset < 0) || (chunk.m_length < 0) || (chunk.m_offset+chunk.m_length > len(input.m_data)) {
+			msg := "MleDppInput: chunk " + strconv.Itoa(i) + " lies outside of the playprint."
+			return mle_core.NewMleError(msg, 0, nil)
+		}
+		input.m_chunks[i] = chunk
+	}
+
+	return nil
+}
+
+/**
+ * Get the playprint format version.
+ *
+ * @return The version is returned.
+ */
+func (input *MleDppInput) GetVersion() int32 {
+	return input.m_version
+}
+
+/**
+ * Get the boot Scene.
+ *
+ * @return The index of the Scene to load when the title is booted
+ * is returned. <b>-1</b> is returned if there is no boot Scene.
+ */
+func (input *MleDppInput) GetBootScene() int {
+	return input.m_bootScene
+}
+
+/**
+ * Get the number of chunks in the playprint.
+ *
+ * @return The number of chunks is returned.
+ */
+func (input *MleDppInput) GetNumberOfChunks() int {
+	return len(input.m_chunks)
+}
+
+/**
+ * Get the chunk table entry for the specified chunk.
+ *
+ * @param n The chunk number.
+ *
+ * @return The chunk table entry is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the chunk
+ * does not exist.
+ */
+func (input *MleDppInput) GetChunk(n int) (*MleDppChunk, *mle_core.MleError) {
+	if (n < 0) || (n >= len(input.m_chunks)) {
+		msg := "MleDppInput: chunk " + strconv.Itoa(n) + " does not exist."
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+	return input.m_chunks[n], nil
+}
+
+/**
+ * Get the data for the specified chunk.
+ *
+ * @param n The chunk number.
+ *
+ * @return The chunk data is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the chunk
+ * does not exist.
+ */
+func (input *MleDppInput) GetChunkData(n int) ([]byte, *mle_core.MleError) {
+	chunk, err := input.GetChunk(n)
+	if err != nil {
+		return nil, err
+	}
+	return input.m_data[chunk.m_offset : chunk.m_offset+chunk.m_length], nil
+}
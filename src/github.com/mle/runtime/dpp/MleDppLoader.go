@@ -0,0 +1,637 @@
+/**
+ * @file MleDppLoader.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dpp
+
+// Import go packages.
+import (
+	"bytes"
+	"math"
+	"strconv"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_util "github.com/mle/runtime/util"
+)
+
+/**
+ * <code>MleDppLoader</code> instantiates Sets, Groups, Scenes and MediaRefs
+ * from a Digital Playprint.
+ * <p>
+ * The loader registers the playprint's chunk tables with <code>MleTables</code>
+ * so that the k-th chunk of each type corresponds to the k-th Set, Group or
+ * Scene. Classes are resolved through the class registry using the class
+ * tables in <code>MleTables</code>.
+ * </p><p>
+ * A Set chunk contains the index of the Set class followed by a stream of
+ * property operations terminated by MLE_DPP_OP_END. A Group chunk contains
+ * the index of the Group class followed by a stream of Actor operations
+ * terminated by MLE_DPP_OP_END. A Scene chunk contains the index of the
+ * Scene class, the number of Groups and the index of each Group. A MediaRef
+ * chunk contains the index of the MediaRef class, the number of references
+ * and, for each reference, its flags, size and data.
+ * </p>
+ *
+ * @see MleDppInput
+ * @see MleTables
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDppLoader struct {
+	/** The playprint to load from. */
+	m_input *MleDppInput
+	/** The playprint chunk number for each MediaRef. */
+	m_mediaRefChunks []int
+	/** The Sets that have been loaded, indexed by Set. */
+	m_sets map[int]mle_util.Object
+}
+
+/**
+ * Create a loader for the specified playprint.
+ * <p>
+ * The Set, Group and Scene chunk tables and the boot Scene are
+ * registered with <code>MleTables</code>.
+ * </p>
+ *
+ * @param input The playprint to load from.
+ */
+func NewMleDppLoader(input *MleDppInput) *MleDppLoader {
+	p := new(MleDppLoader)
+	p.m_input = input
+	p.m_sets = make(map[int]mle_util.Object)
+
+	var sets, groups, scenes []int
+	for i, chunk := range input.m_chunks {
+		switch chunk.m_type {
+		case MLE_DPP_CHUNK_SET:
+			sets = append(sets, i)
+		case MLE_DPP_CHUNK_GROUP:
+			groups = append(groups, i)
+		case MLE_DPP_CHUNK_SCENE:
+			scenes = append(scenes, i)
+		case MLE_DPP_CHUNK_MEDIAREF:
+			p.m_mediaRefChunks = append(p.m_mediaRefChunks, i)
+		}
+	}
+
+	tables := mle_core.GetMleTablesInstance()
+	tables.SetSetChunks(sets)
+	tables.SetGroupChunks(groups)
+	tables.SetSceneChunks(scenes)
+	tables.SetBootScene(input.GetBootScene())
+
+	return p
+}
+
+// Get a reader for the specified chunk, verifying its type.
+func (loader *MleDppLoader) getChunkReader(chunk int, chunkType int32) (*_DppChunkReader, *mle_core.MleError) {
+	entry, err := loader.m_input.GetChunk(chunk)
+	if err != nil {
+		return nil, err
+	}
+	reader := _NewDppChunkReader(loader.m_input.m_data[entry.m_offset:entry.m_offset+entry.m_length], chunk)
+	if entry.m_type != chunkType {
+		return nil, reader.newError("unexpected chunk type "+strconv.Itoa(int(entry.m_type))+".", nil)
+	}
+	return reader, nil
+}
+
+// Read a property operation and apply it to the specified object.
+//
+// Parameters
+//   reader - The chunk reader, positioned after the MLE_DPP_OP_PROPERTY code.
+//   obj    - The object whose property is being set.
+//   offset - The offset of the object's class into the property table.
+//   getProperty - The property table lookup (Actor or Set).
+func readProperty(reader *_DppChunkReader, obj mle_util.Object, offset int,
+	getProperty func(index int) (*mle_core.MleRTPropertyEntry, *mle_core.MleError)) *mle_core.MleError {
+	index, err := reader.readInt()
+	if err != nil {
+		return err
+	}
+	length, err := reader.readInt()
+	if err != nil {
+		return err
+	}
+	nElements, err := reader.readInt()
+	if err != nil {
+		return err
+	}
+	if (length < 0) || (nElements < 0) || ((nElements > 0) && (length > math.MaxInt / nElements)) {
+		return reader.newError("invalid property size.", nil)
+	}
+	data, err := reader.readBytes(length * nElements)
+	if err != nil {
+		return err
+	}
+
+	property, err := getProperty(offset + index)
+	if err != nil {
+		return err
+	}
+	target, ok := obj.(mle_core.IMleObject)
+	if !ok {
+		return reader.newError("object does not support property "+property.GetProperty()+".", nil)
+	}
+	err = target.SetPropertyArray(property.GetProperty(), length, nElements, bytes.NewReader(data))
+	if err != nil {
+		return reader.newError("unable to set property "+property.GetProperty()+".", err)
+	}
+	return nil
+}
+
+/**
+ * Load the specified Set.
+ * <p>
+ * The Set is created, its properties are loaded and it is initialized.
+ * The Set is recorded in the <code>MleTables</code> Set table. A Set is
+ * only loaded once; subsequent calls return the previously loaded Set.
+ * </p>
+ *
+ * @param index The index of the Set in the <code>MleTables</code> Set table.
+ *
+ * @return The Set object, as created from the class registry, is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the Set
+ * can not be loaded.
+ */
+func (loader *MleDppLoader) LoadSet(index int) (mle_util.Object, *mle_core.MleError) {
+	if set, ok := loader.m_sets[index]; ok {
+		return set, nil
+	}
+
+	tables := mle_core.GetMleTablesInstance()
+	entry, err := tables.GetSet(index)
+	if err != nil {
+		return nil, err
+	}
+	if entry.GetSet() != nil {
+		// The Set was created outside of this loader.
+		return entry.GetSet(), nil
+	}
+
+	chunk, err := tables.GetSetChunk(index)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := loader.getChunkReader(chunk, MLE_DPP_CHUNK_SET)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the Set.
+	classIndex, err := reader.readInt()
+	if err != nil {
+		return nil, err
+	}
+	clazz, err := tables.GetSetClass(classIndex)
+	if err != nil {
+		return nil, err
+	}
+	newSet, err := clazz.CreateSet()
+	if err != nil {
+		return nil, err
+	}
+	set := *newSet
+	base := mle_core.ToMleSet(set)
+	if base == nil {
+		return nil, reader.newError("class "+clazz.GetClassName()+" is not a Set.", nil)
+	}
+
+	// Load the Set properties.
+	for {
+		op, err := reader.readOp()
+		if err != nil {
+			return nil, err
+		}
+		if op == MLE_DPP_OP_END {
+			break
+		}
+		if op != MLE_DPP_OP_PROPERTY {
+			return nil, reader.newError("invalid Set operation "+strconv.Itoa(int(op))+".", nil)
+		}
+		err = readProperty(reader, set, clazz.GetOffset(), tables.GetSetProperty)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Initialize the Set.
+	if s, ok := set.(interface{ Init() }); ok {
+		s.Init()
+	}
+
+	entry.SetSet(base)
+	loader.m_sets[index] = set
+	return set, nil
+}
+
+// Attach a Role to its parent using the current Set.
+func (loader *MleDppLoader) attachRole(reader *_DppChunkReader, parent *mle_core.MleRole, child *mle_core.MleRole) *mle_core.MleError {
+	var current *mle_core.MleSet
+	current = current.GetCurrentSet()
+	if current == nil {
+		return reader.newError("no current Set for Role.", nil)
+	}
+
+	// Use the loaded Set so that an overridden AttachRoles is called.
+	var set mle_util.Object = current
+	for _, s := range loader.m_sets {
+		if mle_core.ToMleSet(s) == current {
+			set = s
+			break
+		}
+	}
+	set.(interface {
+		AttachRoles(parent *mle_core.MleRole, child *mle_core.MleRole)
+	}).AttachRoles(parent, child)
+	return nil
+}
+
+/**
+ * Load the specified Group.
+ * <p>
+ * For each Actor in the Group, the Actor is created and its properties
+ * are loaded. If the Actor has a Role, the Set the Role belongs to is
+ * made current (loading it if necessary), the Role is created and bound
+ * to the Actor, and the Role is attached to its parent through the Set.
+ * The Actor is then initialized and added to the Group. Once all the
+ * Actors are loaded, the Group is initialized.
+ * </p>
+ *
+ * @param index The index of the Group.
+ *
+ * @return The Group object, as created from the class registry, is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the Group
+ * can not be loaded.
+ */
+func (loader *MleDppLoader) LoadGroup(index int) (mle_util.Object, *mle_core.MleError) {
+	tables := mle_core.GetMleTablesInstance()
+	chunk, err := tables.GetGroupChunk(index)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := loader.getChunkReader(chunk, MLE_DPP_CHUNK_GROUP)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the Group.
+	classIndex, err := reader.readInt()
+	if err != nil {
+		return nil, err
+	}
+	clazz, err := tables.GetGroupClass(classIndex)
+	if err != nil {
+		return nil, err
+	}
+	newGroup, err := clazz.CreateGroup()
+	if err != nil {
+		return nil, err
+	}
+	group := *newGroup
+	base := mle_core.ToMleGroup(group)
+	if base == nil {
+		return nil, reader.newError("class "+clazz.GetClassName()+" is not a Group.", nil)
+	}
+
+	// The Actors loaded so far, used to resolve parent Roles.
+	var actors []*mle_core.MleActor
+
+	// The state of the Actor currently being loaded.
+	var actor mle_util.Object
+	var actorClass *mle_core.MleRTActorClassEntry
+	var role *mle_core.MleRole
+	var parent *mle_core.MleRole
+
+	for {
+		op, err := reader.readOp()
+		if err != nil {
+			return nil, err
+		}
+		if op == MLE_DPP_OP_END {
+			break
+		}
+
+		if (op != MLE_DPP_OP_CREATE_ACTOR) && (op != MLE_DPP_OP_SET_SET) && (actor == nil) {
+			return nil, reader.newError("operation "+strconv.Itoa(int(op))+" outside of an Actor.", nil)
+		}
+
+		switch op {
+		case MLE_DPP_OP_CREATE_ACTOR:
+			if actor != nil {
+				return nil, reader.newError("Actor is missing MLE_DPP_OP_END_ACTOR.", nil)
+			}
+			classIndex, err := reader.readInt()
+			if err != nil {
+				return nil, err
+			}
+			actorClass, err = tables.GetActorClass(classIndex)
+			if err != nil {
+				return nil, err
+			}
+			newActor, err := actorClass.CreateActor()
+			if err != nil {
+				return nil, err
+			}
+			actor = *newActor
+			if mle_core.ToMleActor(actor) == nil {
+				return nil, reader.newError("class "+actorClass.GetClassName()+" is not an Actor.", nil)
+			}
+			role = nil
+			parent = nil
+
+		case MLE_DPP_OP_PROPERTY:
+			err = readProperty(reader, actor, actorClass.GetOffset(), tables.GetActorProperty)
+			if err != nil {
+				return nil, err
+			}
+
+		case MLE_DPP_OP_SET_SET:
+			setIndex, err := reader.readInt()
+			if err != nil {
+				return nil, err
+			}
+			set, err := loader.LoadSet(setIndex)
+			if err != nil {
+				return nil, err
+			}
+			mle_core.ToMleSet(set).SetCurrentSet()
+
+		case MLE_DPP_OP_CREATE_ROLE:
+			roleIndex, err := reader.readInt()
+			if err != nil {
+				return nil, err
+			}
+			roleClass, err := tables.GetRoleClass(roleIndex)
+			if err != nil {
+				return nil, err
+			}
+			newRole, err := roleClass.CreateRole(mle_core.ToMleActor(actor))
+			if err != nil {
+				return nil, err
+			}
+			role = mle_core.ToMleRole(*newRole)
+			if role == nil {
+				return nil, reader.newError("class "+roleClass.GetClassName()+" is not a Role.", nil)
+			}
+
+		case MLE_DPP_OP_PARENT_ROLE:
+			parentIndex, err := reader.readInt()
+			if err != nil {
+				return nil, err
+			}
+			if (parentIndex < 0) || (parentIndex >= len(actors)) {
+				return nil, reader.newError("invalid parent Actor "+strconv.Itoa(parentIndex)+".", nil)
+			}
+			parent = actors[parentIndex].GetRole()
+			if parent == nil {
+				return nil, reader.newError("parent Actor "+strconv.Itoa(parentIndex)+" has no Role.", nil)
+			}
+
+		case MLE_DPP_OP_END_ACTOR:
+			if role != nil {
+				err = loader.attachRole(reader, parent, role)
+				if err != nil {
+					return nil, err
+				}
+			} else if parent != nil {
+				return nil, reader.newError("Actor has a parent but no Role.", nil)
+			}
+
+			// Initialize the Actor now that its properties and Role are loaded.
+			if a, ok := actor.(interface{ Init() }); ok {
+				a.Init()
+			}
+			base.Add(mle_core.ToMleActor(actor))
+			actors = append(actors, mle_core.ToMleActor(actor))
+			actor = nil
+
+		default:
+			return nil, reader.newError("invalid Group operation "+strconv.Itoa(int(op))+".", nil)
+		}
+	}
+
+	if actor != nil {
+		return nil, reader.newError("Actor is missing MLE_DPP_OP_END_ACTOR.", nil)
+	}
+
+	// Initialize the Group.
+	if g, ok := group.(interface{ Init() *mle_core.MleError }); ok {
+		err = g.Init()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return group, nil
+}
+
+/**
+ * Load the specified Scene.
+ * <p>
+ * Each Group in the Scene is loaded and added to the Scene. Once all the
+ * Groups are loaded, the Scene is initialized. The Scene is not made
+ * current; use <code>mle_core.ChangeCurrentScene()</code> or
+ * <code>LoadBootScene()</code>.
+ * </p>
+ *
+ * @param index The index of the Scene.
+ *
+ * @return The Scene object, as created from the class registry, is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the Scene
+ * can not be loaded.
+ */
+func (loader *MleDppLoader) LoadScene(index int) (mle_util.Object, *mle_core.MleError) {
+	tables := mle_core.GetMleTablesInstance()
+	chunk, err := tables.GetSceneChunk(index)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := loader.getChunkReader(chunk, MLE_DPP_CHUNK_SCENE)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the Scene.
+	classIndex, err := reader.readInt()
+	if err != nil {
+		return nil, err
+	}
+	clazz, err := tables.GetSceneClass(classIndex)
+	if err != nil {
+		return nil, err
+	}
+	newScene, err := clazz.CreateScene()
+	if err != nil {
+		return nil, err
+	}
+	scene := *newScene
+	base := mle_core.ToMleScene(scene)
+	if base == nil {
+		return nil, reader.newError("class "+clazz.GetClassName()+" is not a Scene.", nil)
+	}
+
+	// Load the Groups.
+	numGroups, err := reader.readInt()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < numGroups; i++ {
+		groupIndex, err := reader.readInt()
+		if err != nil {
+			return nil, err
+		}
+		group, err := loader.LoadGroup(groupIndex)
+		if err != nil {
+			return nil, err
+		}
+		base.Add(mle_core.ToMleGroup(group))
+	}
+
+	// Initialize the Scene.
+	if s, ok := scene.(interface{ Init() }); ok {
+		s.Init()
+	}
+
+	return scene, nil
+}
+
+/**
+ * Load the boot Scene and make it the current Scene.
+ *
+ * @return The Scene object, as created from the class registry, is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the playprint
+ * has no boot Scene or the Scene can not be loaded.
+ */
+func (loader *MleDppLoader) LoadBootScene() (mle_util.Object, *mle_core.MleError) {
+	index := mle_core.GetMleTablesInstance().GetBootScene()
+	if index < 0 {
+		return nil, mle_core.NewMleError("MleDppLoader: playprint has no boot Scene.", 0, nil)
+	}
+	scene, err := loader.LoadScene(index)
+	if err != nil {
+		return nil, err
+	}
+	mle_core.ChangeCurrentScene(mle_core.ToMleScene(scene))
+	return scene, nil
+}
+
+/**
+ * Get the number of MediaRefs in the playprint.
+ *
+ * @return The number of MediaRef chunks is returned.
+ */
+func (loader *MleDppLoader) GetNumberOfMediaRefs() int {
+	return len(loader.m_mediaRefChunks)
+}
+
+/**
+ * Load the specified MediaRef.
+ * <p>
+ * The MediaRef is created and each of its media references is registered.
+ * </p>
+ *
+ * @param index The index of the MediaRef.
+ *
+ * @return The MediaRef object, as created from the class registry, is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the MediaRef
+ * can not be loaded.
+ */
+func (loader *MleDppLoader) LoadMediaRef(index int) (mle_util.Object, *mle_core.MleError) {
+	if (index < 0) || (index >= len(loader.m_mediaRefChunks)) {
+		msg := "MleDppLoader: MediaRef " + strconv.Itoa(index) + " does not exist."
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+	reader, err := loader.getChunkReader(loader.m_mediaRefChunks[index], MLE_DPP_CHUNK_MEDIAREF)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the MediaRef.
+	classIndex, err := reader.readInt()
+	if err != nil {
+		return nil, err
+	}
+	clazz, err := mle_core.GetMleTablesInstance().GetMediaRefClass(classIndex)
+	if err != nil {
+		return nil, err
+	}
+	newMediaRef, err := clazz.CreateMediaRef()
+	if err != nil {
+		return nil, err
+	}
+	mediaref := *newMediaRef
+	base := mle_core.ToMleMediaRef(mediaref)
+	if base == nil {
+		return nil, reader.newError("class "+clazz.GetClassName()+" is not a MediaRef.", nil)
+	}
+
+	// Register the media references.
+	numRefs, err := reader.readInt()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < numRefs; i++ {
+		flags, err := reader.readInt()
+		if err != nil {
+			return nil, err
+		}
+		size, err := reader.readInt()
+		if err != nil {
+			return nil, err
+		}
+		media, err := reader.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+		base.RegisterMedia(int32(flags), size, media)
+	}
+
+	// Initialize the MediaRef.
+	if m, ok := mediaref.(interface{ Init() }); ok {
+		m.Init()
+	}
+
+	return mediaref, nil
+}
@@ -0,0 +1,154 @@
+/**
+ * @file MleDppUtil.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dpp
+
+// Import go packages.
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strconv"
+
+	mle_core "github.com/mle/runtime/core"
+)
+
+/** The magic number identifying a Digital Playprint ("MLDP"). */
+const MLE_DPP_MAGIC uint32 = 0x4d4c4450
+
+/** The version of the Digital Playprint format supported by this loader. */
+const MLE_DPP_VERSION int32 = 1
+
+/** The size of the playprint header, in bytes. */
+const MLE_DPP_HEADER_SIZE int = 16
+
+/** The size of a chunk table entry, in bytes. */
+const MLE_DPP_CHUNK_ENTRY_SIZE int = 12
+
+/** The chunk contains a Set. */
+const MLE_DPP_CHUNK_SET int32 = 1
+/** The chunk contains a Group of Actors. */
+const MLE_DPP_CHUNK_GROUP int32 = 2
+/** The chunk contains a Scene. */
+const MLE_DPP_CHUNK_SCENE int32 = 3
+/** The chunk contains a Media Reference. */
+const MLE_DPP_CHUNK_MEDIAREF int32 = 4
+
+//  Group and Set chunks are a stream of operations. Each operation is
+//  a single byte followed by its big-endian 32-bit operands. A Group
+//  chunk describes each Actor with the sequence
+//
+//      CREATE_ACTOR (PROPERTY)* [SET_SET] [CREATE_ROLE [PARENT_ROLE]] END_ACTOR
+//
+//  which maps directly onto the actor/role initialization sequence
+//  documented by MleRole.
+
+/** End of the chunk. */
+const MLE_DPP_OP_END byte = 0
+/** Create an Actor; operand is the Actor class index. */
+const MLE_DPP_OP_CREATE_ACTOR byte = 1
+/**
+ * Insert a property value; operands are the property index (relative
+ * to the class offset), the element length, the number of elements
+ * and then the property data.
+ */
+const MLE_DPP_OP_PROPERTY byte = 2
+/** Create a Role for the current Actor; operand is the Role class index. */
+const MLE_DPP_OP_CREATE_ROLE byte = 3
+/** Make a Set current, loading it if necessary; operand is the Set index. */
+const MLE_DPP_OP_SET_SET byte = 4
+/** Attach the Role to the Role of a previous Actor in the Group; operand is the Actor index. */
+const MLE_DPP_OP_PARENT_ROLE byte = 5
+/** Finish the current Actor; its Role is attached and the Actor is initialized. */
+const MLE_DPP_OP_END_ACTOR byte = 6
+
+// A helper class for reading the contents of a chunk.
+type _DppChunkReader struct {
+	// The chunk data.
+	m_reader *bytes.Reader
+	// The chunk number, used for reporting errors.
+	m_chunk int
+}
+
+// Construct a reader for the specified chunk data.
+func _NewDppChunkReader(data []byte, chunk int) *_DppChunkReader {
+	p := new(_DppChunkReader)
+	p.m_reader = bytes.NewReader(data)
+	p.m_chunk = chunk
+	return p
+}
+
+// Create an error describing a malformed chunk.
+func (r *_DppChunkReader) newError(msg string, err error) *mle_core.MleError {
+	str := "MleDppLoader: chunk " + strconv.Itoa(r.m_chunk) + ": " + msg
+	return mle_core.NewMleError(str, 0, err)
+}
+
+// Read the next operation code.
+func (r *_DppChunkReader) readOp() (byte, *mle_core.MleError) {
+	op, err := r.m_reader.ReadByte()
+	if err != nil {
+		return MLE_DPP_OP_END, r.newError("unexpected end of chunk.", err)
+	}
+	return op, nil
+}
+
+// Read a big-endian 32-bit integer.
+func (r *_DppChunkReader) readInt() (int, *mle_core.MleError) {
+	var value int32
+	err := binary.Read(r.m_reader, binary.BigEndian, &value)
+	if err != nil {
+		return 0, r.newError("unable to read integer.", err)
+	}
+	return int(value), nil
+}
+
+// Read the specified number of bytes.
+func (r *_DppChunkReader) readBytes(n int) ([]byte, *mle_core.MleError) {
+	if (n < 0) || (n > r.m_reader.Len()) {
+		return nil, r.newError("data length "+strconv.Itoa(n)+" exceeds chunk.", nil)
+	}
+	// Read fully, a plain Read returns io.EOF for no data at the end of the chunk.
+	data := make([]byte, n)
+	_, err := io.ReadFull(r.m_reader, data)
+	if err != nil {
+		return nil, r.newError("unable to read data.", err)
+	}
+	return data, nil
+}
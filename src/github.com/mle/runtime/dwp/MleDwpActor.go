@@ -0,0 +1,171 @@
+/**
+ * @file MleDwpActor.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dwp
+
+/**
+ * MleDwpActor defines an Actor in a Digital Workprint.
+ * <p>
+ * <pre>
+ *   (Actor name class (Property ...) ... [(RoleBinding ...)])
+ * </pre>
+ * </p>
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpActor struct {
+	MleDwpItem
+	/** The registered class of the Actor. */
+	m_class string
+}
+
+/**
+ * A constructor that initializes the name and class of the Actor.
+ *
+ * @param name The name of the Actor.
+ * @param class The registered class of the Actor.
+ */
+func NewMleDwpActor(name string, class string) *MleDwpActor {
+	p := new(MleDwpActor)
+	p.init(MLE_DWP_ACTOR, name, 0)
+	p.m_class = class
+	return p
+}
+
+/**
+ * Get the class of the Actor.
+ *
+ * @return The registered class name is returned.
+ */
+func (actor *MleDwpActor) GetClassName() string {
+	return actor.m_class
+}
+
+/**
+ * Get the property values of the Actor.
+ *
+ * @return The properties are returned, in the order they were defined.
+ */
+func (actor *MleDwpActor) GetProperties() []*MleDwpProperty {
+	return actor.getProperties()
+}
+
+/**
+ * Get the Role binding of the Actor.
+ *
+ * @return The Role binding is returned, or <b>nil</b> if the Actor
+ * has no Role.
+ */
+func (actor *MleDwpActor) GetRoleBinding() *MleDwpRoleBinding {
+	bindings := actor.GetChildrenOfType(MLE_DWP_ROLEBINDING)
+	if len(bindings) == 0 {
+		return nil
+	}
+	return bindings[0].(*MleDwpRoleBinding)
+}
+
+/**
+ * MleDwpRoleBinding binds a Role to an Actor.
+ * <p>
+ * The Role belongs to the named Set. If a parent Actor is specified,
+ * the Role is attached to the parent Actor's Role; the parent Actor
+ * must be defined earlier in the same Group.
+ * <pre>
+ *   (RoleBinding class set [parent])
+ * </pre>
+ * </p>
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpRoleBinding struct {
+	MleDwpItem
+	/** The registered class of the Role. */
+	m_class string
+	/** The name of the Set the Role belongs to. */
+	m_set string
+	/** The name of the parent Actor. */
+	m_parentActor string
+}
+
+/**
+ * A constructor that initializes the Role binding.
+ *
+ * @param class The registered class of the Role.
+ * @param set The name of the Set the Role belongs to.
+ * @param parentActor The name of the parent Actor, or an empty string
+ * if the Role has no parent.
+ */
+func NewMleDwpRoleBinding(class string, set string, parentActor string) *MleDwpRoleBinding {
+	p := new(MleDwpRoleBinding)
+	p.init(MLE_DWP_ROLEBINDING, "", 0)
+	p.m_class = class
+	p.m_set = set
+	p.m_parentActor = parentActor
+	return p
+}
+
+/**
+ * Get the class of the Role.
+ *
+ * @return The registered class name is returned.
+ */
+func (binding *MleDwpRoleBinding) GetClassName() string {
+	return binding.m_class
+}
+
+/**
+ * Get the Set the Role belongs to.
+ *
+ * @return The name of the Set is returned.
+ */
+func (binding *MleDwpRoleBinding) GetSetName() string {
+	return binding.m_set
+}
+
+/**
+ * Get the parent Actor.
+ *
+ * @return The name of the parent Actor is returned. An empty string
+ * is returned if the Role has no parent.
+ */
+func (binding *MleDwpRoleBinding) GetParentActor() string {
+	return binding.m_parentActor
+}
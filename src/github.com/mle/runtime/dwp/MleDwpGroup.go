@@ -0,0 +1,120 @@
+/**
+ * @file MleDwpGroup.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dwp
+
+/**
+ * MleDwpGroup defines a Group of Actors in a Digital Workprint.
+ * <p>
+ * A Group may be defined at the top level of the workprint or inline
+ * within a Scene.
+ * <pre>
+ *   (Group name class (Actor ...) ...)
+ * </pre>
+ * </p>
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpGroup struct {
+	MleDwpItem
+	/** The registered class of the Group. */
+	m_class string
+}
+
+/**
+ * A constructor that initializes the name and class of the Group.
+ *
+ * @param name The name of the Group.
+ * @param class The registered class of the Group.
+ */
+func NewMleDwpGroup(name string, class string) *MleDwpGroup {
+	p := new(MleDwpGroup)
+	p.init(MLE_DWP_GROUP, name, 0)
+	p.m_class = class
+	return p
+}
+
+/**
+ * Get the class of the Group.
+ *
+ * @return The registered class name is returned.
+ */
+func (group *MleDwpGroup) GetClassName() string {
+	return group.m_class
+}
+
+/**
+ * Get the Actors in the Group.
+ *
+ * @return The Actors are returned, in the order they were defined.
+ */
+func (group *MleDwpGroup) GetActors() []*MleDwpActor {
+	var actors []*MleDwpActor
+	for _, child := range group.GetChildrenOfType(MLE_DWP_ACTOR) {
+		actors = append(actors, child.(*MleDwpActor))
+	}
+	return actors
+}
+
+/**
+ * MleDwpGroupRef refers to a Group from a Scene.
+ * <p>
+ * <pre>
+ *   (GroupRef name)
+ * </pre>
+ * </p>
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpGroupRef struct {
+	MleDwpItem
+}
+
+/**
+ * A constructor that initializes the name of the referenced Group.
+ *
+ * @param name The name of the Group.
+ */
+func NewMleDwpGroupRef(name string) *MleDwpGroupRef {
+	p := new(MleDwpGroupRef)
+	p.init(MLE_DWP_GROUPREF, name, 0)
+	return p
+}
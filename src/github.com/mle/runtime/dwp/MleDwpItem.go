@@ -0,0 +1,295 @@
+/**
+ * @file MleDwpItem.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dwp
+
+// Import go packages.
+import (
+	mle_util "github.com/mle/runtime/util"
+)
+
+/** The type of the root of a Digital Workprint. */
+const MLE_DWP_WORKPRINT string = "Workprint"
+
+/** The type of a Set definition. */
+const MLE_DWP_SET string = "Set"
+
+/** The type of a Group definition. */
+const MLE_DWP_GROUP string = "Group"
+
+/** The type of a reference to a Group from a Scene. */
+const MLE_DWP_GROUPREF string = "GroupRef"
+
+/** The type of an Actor definition. */
+const MLE_DWP_ACTOR string = "Actor"
+
+/** The type of a Role binding. */
+const MLE_DWP_ROLEBINDING string = "RoleBinding"
+
+/** The type of a property value. */
+const MLE_DWP_PROPERTY string = "Property"
+
+/** The type of a Scene definition. */
+const MLE_DWP_SCENE string = "Scene"
+
+/** The type of a MediaRef definition. */
+const MLE_DWP_MEDIAREF string = "MediaRef"
+
+/** The type of a media reference within a MediaRef. */
+const MLE_DWP_MEDIA string = "Media"
+
+/** The type of the boot Scene declaration. */
+const MLE_DWP_BOOTSCENE string = "BootScene"
+
+/**
+ * IMleDwpItem is the interface shared by all items in a Digital Workprint.
+ * <p>
+ * A workprint is a tree of items. Each item has a type (e.g. MLE_DWP_ACTOR),
+ * an optional name and an ordered collection of children.
+ * </p>
+ */
+type IMleDwpItem interface {
+	// Inherit root Object interface.
+	mle_util.IObject
+
+	/**
+	 * Get the item type.
+	 *
+	 * @return The item type is returned (e.g. MLE_DWP_ACTOR).
+	 */
+	GetType() string
+
+	/**
+	 * Get the item name.
+	 *
+	 * @return The item name is returned. An empty string is returned
+	 * if the item is not named.
+	 */
+	GetName() string
+
+	/**
+	 * Get the line of the workprint the item was defined on.
+	 *
+	 * @return The line number is returned, starting at 1.
+	 */
+	GetLine() int
+
+	/**
+	 * Get the parent of the item.
+	 *
+	 * @return The parent item is returned. <b>nil</b> is returned for
+	 * the root of the workprint.
+	 */
+	GetParent() IMleDwpItem
+
+	/**
+	 * Get the children of the item.
+	 *
+	 * @return The children are returned, in the order they were defined.
+	 */
+	GetChildren() []IMleDwpItem
+
+	// Add a child to the item.
+	addChild(child IMleDwpItem)
+
+	// Set the parent of the item.
+	setParent(parent IMleDwpItem)
+
+	// Set the line the item was defined on.
+	setLine(line int)
+}
+
+/**
+ * MleDwpItem implements the state shared by all workprint items.
+ * Concrete items embed <code>MleDwpItem</code>.
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpItem struct {
+	/** The item type. */
+	m_type string
+	/** The item name. */
+	m_name string
+	/** The line the item was defined on. */
+	m_line int
+	/** The parent item. */
+	m_parent IMleDwpItem
+	/** The child items. */
+	m_children []IMleDwpItem
+}
+
+// Initialize the shared item state.
+func (item *MleDwpItem) init(itemType string, name string, line int) {
+	item.m_type = itemType
+	item.m_name = name
+	item.m_line = line
+}
+
+// String implements IObject interface.
+func (item *MleDwpItem) String() string {
+	if item.m_name == "" {
+		return item.m_type
+	}
+	return item.m_type + " " + item.m_name
+}
+
+func (item *MleDwpItem) GetType() string {
+	return item.m_type
+}
+
+func (item *MleDwpItem) GetName() string {
+	return item.m_name
+}
+
+func (item *MleDwpItem) GetLine() int {
+	return item.m_line
+}
+
+func (item *MleDwpItem) GetParent() IMleDwpItem {
+	return item.m_parent
+}
+
+func (item *MleDwpItem) GetChildren() []IMleDwpItem {
+	return item.m_children
+}
+
+func (item *MleDwpItem) addChild(child IMleDwpItem) {
+	item.m_children = append(item.m_children, child)
+}
+
+func (item *MleDwpItem) setParent(parent IMleDwpItem) {
+	item.m_parent = parent
+}
+
+func (item *MleDwpItem) setLine(line int) {
+	item.m_line = line
+}
+
+/**
+ * Get the children of the specified type.
+ *
+ * @param itemType The type of children to get (e.g. MLE_DWP_PROPERTY).
+ *
+ * @return The matching children are returned, in the order they were defined.
+ */
+func (item *MleDwpItem) GetChildrenOfType(itemType string) []IMleDwpItem {
+	var children []IMleDwpItem
+	for _, child := range item.m_children {
+		if child.GetType() == itemType {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Get the Property children of an item.
+func (item *MleDwpItem) getProperties() []*MleDwpProperty {
+	var props []*MleDwpProperty
+	for _, child := range item.GetChildrenOfType(MLE_DWP_PROPERTY) {
+		props = append(props, child.(*MleDwpProperty))
+	}
+	return props
+}
+
+/**
+ * MleDwpWorkprint is the root of a Digital Workprint.
+ * <p>
+ * Its children are the Set, Group, Scene and MediaRef definitions
+ * of the title.
+ * </p>
+ *
+ * @see MleDwpParser
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpWorkprint struct {
+	MleDwpItem
+	/** The name of the boot Scene. */
+	m_bootScene string
+}
+
+/**
+ * The default constructor.
+ */
+func NewMleDwpWorkprint() *MleDwpWorkprint {
+	p := new(MleDwpWorkprint)
+	p.init(MLE_DWP_WORKPRINT, "", 0)
+	return p
+}
+
+/**
+ * Get the name of the boot Scene.
+ *
+ * @return The name of the Scene to load when the title is booted is
+ * returned. An empty string is returned if there is no boot Scene.
+ */
+func (wp *MleDwpWorkprint) GetBootScene() string {
+	return wp.m_bootScene
+}
+
+/**
+ * Find a named item.
+ * <p>
+ * The entire workprint is searched, so Groups defined inline within
+ * a Scene may be found.
+ * </p>
+ *
+ * @param itemType The type of item to find (e.g. MLE_DWP_GROUP).
+ * @param name The name of the item.
+ *
+ * @return The item is returned, or <b>nil</b> if no such item exists.
+ */
+func (wp *MleDwpWorkprint) FindItem(itemType string, name string) IMleDwpItem {
+	return findItem(wp, itemType, name)
+}
+
+// Search the tree rooted at item for a named item.
+func findItem(item IMleDwpItem, itemType string, name string) IMleDwpItem {
+	for _, child := range item.GetChildren() {
+		if (child.GetType() == itemType) && (child.GetName() == name) {
+			return child
+		}
+		if found := findItem(child, itemType, name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,419 @@
+/**
+ * @file MleDwpLoader.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dwp
+
+// Import go packages.
+import (
+	"bytes"
+	"strconv"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_util "github.com/mle/runtime/util"
+)
+
+/**
+ * <code>MleDwpLoader</code> instantiates Sets, Groups, Scenes and MediaRefs
+ * from a Digital Workprint.
+ * <p>
+ * This allows a title to be run directly from its workprint during
+ * rehearsal, without first mastering a Digital Playprint. Classes are
+ * resolved by name through the <code>MleTables</code> class tables, as
+ * <code>MleDppLoader</code> resolves them by index, so a class must be in
+ * the tables to be loaded. Objects are loaded in the same order as
+ * <code>MleDppLoader</code>.
+ * </p>
+ *
+ * @see MleDwpParser
+ * @see MleDppLoader
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpLoader struct {
+	/** The workprint to load from. */
+	m_workprint *MleDwpWorkprint
+	/** The Sets that have been loaded, indexed by name. */
+	m_sets map[string]mle_util.Object
+}
+
+/**
+ * Create a loader for the specified workprint.
+ *
+ * @param workprint The workprint to load from.
+ */
+func NewMleDwpLoader(workprint *MleDwpWorkprint) *MleDwpLoader {
+	p := new(MleDwpLoader)
+	p.m_workprint = workprint
+	p.m_sets = make(map[string]mle_util.Object)
+	return p
+}
+
+// Create an error describing an item that can not be loaded.
+func (loader *MleDwpLoader) newError(item IMleDwpItem, msg string) *mle_core.MleError {
+	str := "MleDwpLoader: line " + strconv.Itoa(item.GetLine()) + ": " + msg
+	return mle_core.NewMleError(str, 0, nil)
+}
+
+// Apply workprint property values to an object.
+func (loader *MleDwpLoader) setProperties(item IMleDwpItem, obj mle_util.Object, props []*MleDwpProperty) *mle_core.MleError {
+	if len(props) == 0 {
+		return nil
+	}
+	target, ok := obj.(mle_core.IMleObject)
+	if !ok {
+		return loader.newError(item, item.String()+" does not support properties.")
+	}
+	for _, prop := range props {
+		data, length, nElements, err := prop.GetData()
+		if err != nil {
+			return err
+		}
+		err = target.SetPropertyArray(prop.GetName(), length, nElements, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Load the named Set.
+ * <p>
+ * The Set is created, its properties are loaded and it is initialized.
+ * The Set is added to the <code>MleTables</code> Set table. A Set is only
+ * loaded once; subsequent calls return the previously loaded Set.
+ * </p>
+ *
+ * @param name The name of the Set.
+ *
+ * @return The Set object, as created from the class registry, is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the Set
+ * can not be loaded.
+ */
+func (loader *MleDwpLoader) LoadSet(name string) (mle_util.Object, *mle_core.MleError) {
+	if set, ok := loader.m_sets[name]; ok {
+		return set, nil
+	}
+	item, _ := loader.m_workprint.FindItem(MLE_DWP_SET, name).(*MleDwpSet)
+	if item == nil {
+		return nil, mle_core.NewMleError("MleDwpLoader: Set "+name+" is not defined.", 0, nil)
+	}
+
+	tables := mle_core.GetMleTablesInstance()
+	clazz, err := tables.FindSetClass(item.GetClassName())
+	if err != nil {
+		return nil, loader.newError(item, err.What)
+	}
+	newSet, err := clazz.CreateSet()
+	if err != nil {
+		return nil, err
+	}
+	set := *newSet
+	base := mle_core.ToMleSet(set)
+	if base == nil {
+		return nil, loader.newError(item, "class "+item.GetClassName()+" is not a Set.")
+	}
+	err = loader.setProperties(item, set, item.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the Set.
+	if s, ok := set.(interface{ Init() }); ok {
+		s.Init()
+	}
+
+	tables.AddSet(mle_core.NewMleRTSetEntryWithClassAndSet(item.GetClassName(), base))
+	loader.m_sets[name] = set
+	return set, nil
+}
+
+// Load an Actor and, if it has one, its Role.
+func (loader *MleDwpLoader) loadActor(item *MleDwpActor, actors map[string]*mle_core.MleActor) (mle_util.Object, *mle_core.MleError) {
+	tables := mle_core.GetMleTablesInstance()
+	clazz, err := tables.FindActorClass(item.GetClassName())
+	if err != nil {
+		return nil, loader.newError(item, err.What)
+	}
+	newActor, err := clazz.CreateActor()
+	if err != nil {
+		return nil, err
+	}
+	actor := *newActor
+	base := mle_core.ToMleActor(actor)
+	if base == nil {
+		return nil, loader.newError(item, "class "+item.GetClassName()+" is not an Actor.")
+	}
+	err = loader.setProperties(item, actor, item.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+
+	binding := item.GetRoleBinding()
+	if binding != nil {
+		// Make the Role's Set current before creating the Role.
+		set, err := loader.LoadSet(binding.GetSetName())
+		if err != nil {
+			return nil, err
+		}
+		mle_core.ToMleSet(set).SetCurrentSet()
+
+		roleClass, err := tables.FindRoleClass(binding.GetClassName())
+		if err != nil {
+			return nil, loader.newError(binding, err.What)
+		}
+		newRole, err := roleClass.CreateRole(base)
+		if err != nil {
+			return nil, err
+		}
+		role := mle_core.ToMleRole(*newRole)
+		if role == nil {
+			return nil, loader.newError(binding, "class "+binding.GetClassName()+" is not a Role.")
+		}
+
+		var parent *mle_core.MleRole
+		if binding.GetParentActor() != "" {
+			parentActor, ok := actors[binding.GetParentActor()]
+			if !ok {
+				return nil, loader.newError(binding, "parent Actor "+binding.GetParentActor()+" is not defined earlier in the Group.")
+			}
+			parent = parentActor.GetRole()
+			if parent == nil {
+				return nil, loader.newError(binding, "parent Actor "+binding.GetParentActor()+" has no Role.")
+			}
+		}
+		set.(interface {
+			AttachRoles(parent *mle_core.MleRole, child *mle_core.MleRole)
+		}).AttachRoles(parent, role)
+	}
+
+	// Initialize the Actor now that its properties and Role are loaded.
+	if a, ok := actor.(interface{ Init() }); ok {
+		a.Init()
+	}
+	return actor, nil
+}
+
+// Load a Group and its Actors.
+func (loader *MleDwpLoader) loadGroup(item *MleDwpGroup) (mle_util.Object, *mle_core.MleError) {
+	clazz, err := mle_core.GetMleTablesInstance().FindGroupClass(item.GetClassName())
+	if err != nil {
+		return nil, loader.newError(item, err.What)
+	}
+	newGroup, err := clazz.CreateGroup()
+	if err != nil {
+		return nil, err
+	}
+	group := *newGroup
+	base := mle_core.ToMleGroup(group)
+	if base == nil {
+		return nil, loader.newError(item, "class "+item.GetClassName()+" is not a Group.")
+	}
+
+	actors := make(map[string]*mle_core.MleActor)
+	for _, actorItem := range item.GetActors() {
+		actor, err := loader.loadActor(actorItem, actors)
+		if err != nil {
+			return nil, err
+		}
+		base.Add(mle_core.ToMleActor(actor))
+		actors[actorItem.GetName()] = mle_core.ToMleActor(actor)
+	}
+
+	// Initialize the Group.
+	if g, ok := group.(interface{ Init() *mle_core.MleError }); ok {
+		err = g.Init()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return group, nil
+}
+
+/**
+ * Load the named Group.
+ * <p>
+ * For each Actor in the Group, the Actor is created and its properties
+ * are loaded. If the Actor has a Role binding, the Role's Set is made
+ * current (loading it if necessary), the Role is created and bound to
+ * the Actor, and the Role is attached to its parent through the Set.
+ * The Actor is then initialized and added to the Group. Once all the
+ * Actors are loaded, the Group is initialized.
+ * </p>
+ *
+ * @param name The name of the Group.
+ *
+ * @return The Group object, as created from the class registry, is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the Group
+ * can not be loaded.
+ */
+func (loader *MleDwpLoader) LoadGroup(name string) (mle_util.Object, *mle_core.MleError) {
+	item, _ := loader.m_workprint.FindItem(MLE_DWP_GROUP, name).(*MleDwpGroup)
+	if item == nil {
+		return nil, mle_core.NewMleError("MleDwpLoader: Group "+name+" is not defined.", 0, nil)
+	}
+	return loader.loadGroup(item)
+}
+
+/**
+ * Load the named Scene.
+ * <p>
+ * Each Group in the Scene is loaded and added to the Scene. Once all the
+ * Groups are loaded, the Scene is initialized. The Scene is not made
+ * current; use <code>mle_core.ChangeCurrentScene()</code> or
+ * <code>LoadBootScene()</code>.
+ * </p>
+ *
+ * @param name The name of the Scene.
+ *
+ * @return The Scene object, as created from the class registry, is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the Scene
+ * can not be loaded.
+ */
+func (loader *MleDwpLoader) LoadScene(name string) (mle_util.Object, *mle_core.MleError) {
+	item, _ := loader.m_workprint.FindItem(MLE_DWP_SCENE, name).(*MleDwpScene)
+	if item == nil {
+		return nil, mle_core.NewMleError("MleDwpLoader: Scene "+name+" is not defined.", 0, nil)
+	}
+
+	clazz, err := mle_core.GetMleTablesInstance().FindSceneClass(item.GetClassName())
+	if err != nil {
+		return nil, loader.newError(item, err.What)
+	}
+	newScene, err := clazz.CreateScene()
+	if err != nil {
+		return nil, err
+	}
+	scene := *newScene
+	base := mle_core.ToMleScene(scene)
+	if base == nil {
+		return nil, loader.newError(item, "class "+item.GetClassName()+" is not a Scene.")
+	}
+
+	for _, groupItem := range item.GetGroups() {
+		var group mle_util.Object
+		if groupItem.GetType() == MLE_DWP_GROUPREF {
+			group, err = loader.LoadGroup(groupItem.GetName())
+		} else {
+			group, err = loader.loadGroup(groupItem.(*MleDwpGroup))
+		}
+		if err != nil {
+			return nil, err
+		}
+		base.Add(mle_core.ToMleGroup(group))
+	}
+
+	// Initialize the Scene.
+	if s, ok := scene.(interface{ Init() }); ok {
+		s.Init()
+	}
+	return scene, nil
+}
+
+/**
+ * Load the boot Scene and make it the current Scene.
+ *
+ * @return The Scene object, as created from the class registry, is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the workprint
+ * has no boot Scene or the Scene can not be loaded.
+ */
+func (loader *MleDwpLoader) LoadBootScene() (mle_util.Object, *mle_core.MleError) {
+	name := loader.m_workprint.GetBootScene()
+	if name == "" {
+		return nil, mle_core.NewMleError("MleDwpLoader: workprint has no boot Scene.", 0, nil)
+	}
+	scene, err := loader.LoadScene(name)
+	if err != nil {
+		return nil, err
+	}
+	mle_core.ChangeCurrentScene(mle_core.ToMleScene(scene))
+	return scene, nil
+}
+
+/**
+ * Load the named MediaRef.
+ * <p>
+ * The MediaRef is created and each of its media references is registered.
+ * The value of each reference (typically a file name) is registered as
+ * the media data.
+ * </p>
+ *
+ * @param name The name of the MediaRef.
+ *
+ * @return The MediaRef object, as created from the class registry, is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the MediaRef
+ * can not be loaded.
+ */
+func (loader *MleDwpLoader) LoadMediaRef(name string) (mle_util.Object, *mle_core.MleError) {
+	item, _ := loader.m_workprint.FindItem(MLE_DWP_MEDIAREF, name).(*MleDwpMediaRef)
+	if item == nil {
+		return nil, mle_core.NewMleError("MleDwpLoader: MediaRef "+name+" is not defined.", 0, nil)
+	}
+
+	clazz, err := mle_core.GetMleTablesInstance().FindMediaRefClass(item.GetClassName())
+	if err != nil {
+		return nil, loader.newError(item, err.What)
+	}
+	newMediaRef, err := clazz.CreateMediaRef()
+	if err != nil {
+		return nil, err
+	}
+	mediaref := *newMediaRef
+	base := mle_core.ToMleMediaRef(mediaref)
+	if base == nil {
+		return nil, loader.newError(item, "class "+item.GetClassName()+" is not a MediaRef.")
+	}
+
+	for _, media := range item.GetMedia() {
+		value := []byte(media.GetValue())
+		base.RegisterMedia(media.GetFlags(), len(value), value)
+	}
+
+	// Initialize the MediaRef.
+	if m, ok := mediaref.(interface{ Init() }); ok {
+		m.Init()
+	}
+	return mediaref, nil
+}
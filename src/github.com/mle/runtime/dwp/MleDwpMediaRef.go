@@ -0,0 +1,144 @@
+/**
+ * @file MleDwpMediaRef.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dwp
+
+/**
+ * MleDwpMediaRef defines a MediaRef in a Digital Workprint.
+ * <p>
+ * <pre>
+ *   (MediaRef name class (Media flags value) ...)
+ * </pre>
+ * </p>
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpMediaRef struct {
+	MleDwpItem
+	/** The registered class of the MediaRef. */
+	m_class string
+}
+
+/**
+ * A constructor that initializes the name and class of the MediaRef.
+ *
+ * @param name The name of the MediaRef.
+ * @param class The registered class of the MediaRef.
+ */
+func NewMleDwpMediaRef(name string, class string) *MleDwpMediaRef {
+	p := new(MleDwpMediaRef)
+	p.init(MLE_DWP_MEDIAREF, name, 0)
+	p.m_class = class
+	return p
+}
+
+/**
+ * Get the class of the MediaRef.
+ *
+ * @return The registered class name is returned.
+ */
+func (mediaref *MleDwpMediaRef) GetClassName() string {
+	return mediaref.m_class
+}
+
+/**
+ * Get the media references.
+ *
+ * @return The media references are returned, in the order they were defined.
+ */
+func (mediaref *MleDwpMediaRef) GetMedia() []*MleDwpMedia {
+	var media []*MleDwpMedia
+	for _, child := range mediaref.GetChildrenOfType(MLE_DWP_MEDIA) {
+		media = append(media, child.(*MleDwpMedia))
+	}
+	return media
+}
+
+/**
+ * MleDwpMedia is a single media reference within a MediaRef.
+ * <p>
+ * The value is typically the name of the file containing the media.
+ * <pre>
+ *   (Media flags value)
+ * </pre>
+ * </p>
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpMedia struct {
+	MleDwpItem
+	/** The media reference flags. */
+	m_flags int32
+	/** The media reference value. */
+	m_value string
+}
+
+/**
+ * A constructor that initializes the media reference.
+ *
+ * @param flags The media reference flags.
+ * @param value The media reference value.
+ */
+func NewMleDwpMedia(flags int32, value string) *MleDwpMedia {
+	p := new(MleDwpMedia)
+	p.init(MLE_DWP_MEDIA, "", 0)
+	p.m_flags = flags
+	p.m_value = value
+	return p
+}
+
+/**
+ * Get the media reference flags.
+ *
+ * @return The flags are returned.
+ */
+func (media *MleDwpMedia) GetFlags() int32 {
+	return media.m_flags
+}
+
+/**
+ * Get the media reference value.
+ *
+ * @return The value is returned.
+ */
+func (media *MleDwpMedia) GetValue() string {
+	return media.m_value
+}
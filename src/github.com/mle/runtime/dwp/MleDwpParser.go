@@ -0,0 +1,442 @@
+/**
+ * @file MleDwpParser.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dwp
+
+// Import go packages.
+import (
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	mle_core "github.com/mle/runtime/core"
+)
+
+// The kinds of workprint tokens.
+const (
+	_DWP_TOKEN_OPEN = iota
+	_DWP_TOKEN_CLOSE
+	_DWP_TOKEN_ATOM
+	_DWP_TOKEN_EOF
+)
+
+// A workprint token.
+type _DwpToken struct {
+	// The kind of token.
+	m_kind int
+	// The text of an atom.
+	m_text string
+	// The line the token is on.
+	m_line int
+}
+
+// A parsed list, before it is converted to an item.
+type _DwpList struct {
+	// The leading atoms of the list; the first is the item type.
+	m_atoms []string
+	// The nested lists.
+	m_lists []*_DwpList
+	// The line the list begins on.
+	m_line int
+}
+
+/**
+ * <code>MleDwpParser</code> reads a Digital Workprint.
+ * <p>
+ * A workprint is a human-readable description of a title. It is a
+ * sequence of parenthesized items; each item begins with its type,
+ * followed by its arguments and then any nested items. Arguments
+ * containing white space or parentheses may be enclosed in double quotes.
+ * A '#' or ';' begins a comment which extends to the end of the line.
+ * <pre>
+ *   (Set set0 Mle2dSet)
+ *   (Group group0 MleGroup
+ *     (Actor actor0 MyActor
+ *       (Property position float 1.0 2.0)
+ *       (RoleBinding MyRole set0)
+ *     )
+ *     (Actor actor1 MyActor
+ *       (Property label string "child actor")
+ *       (RoleBinding MyRole set0 actor0)
+ *     )
+ *   )
+ *   (Scene scene0 MleScene (GroupRef group0))
+ *   (MediaRef image0 MyMediaRef (Media 0 "image.png"))
+ *   (BootScene scene0)
+ * </pre>
+ * </p>
+ *
+ * @see MleDwpLoader
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpParser struct {
+	/** The workprint text. */
+	m_text []rune
+	/** The current position in the text. */
+	m_pos int
+	/** The current line. */
+	m_line int
+}
+
+/**
+ * Create a parser for the workprint text.
+ *
+ * @param text The workprint text.
+ */
+func NewMleDwpParser(text string) *MleDwpParser {
+	p := new(MleDwpParser)
+	p.m_text = []rune(text)
+	p.m_pos = 0
+	p.m_line = 1
+	return p
+}
+
+/**
+ * Read a workprint.
+ *
+ * @param reader The source of the workprint text.
+ *
+ * @return The root of the workprint is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the workprint
+ * can not be read or is malformed.
+ */
+func ReadWorkprint(reader io.Reader) (*MleDwpWorkprint, *mle_core.MleError) {
+	text, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, mle_core.NewMleError("MleDwpParser: unable to read workprint.", 0, err)
+	}
+	return NewMleDwpParser(string(text)).Parse()
+}
+
+/**
+ * Read a workprint file.
+ *
+ * @param filename The name of the workprint file.
+ *
+ * @return The root of the workprint is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the file
+ * can not be read or the workprint is malformed.
+ */
+func ReadWorkprintFile(filename string) (*MleDwpWorkprint, *mle_core.MleError) {
+	text, err := ioutil.ReadFile(filename)
+	if err != nil {
+		msg := "MleDwpParser: unable to read workprint " + filename + "."
+		return nil, mle_core.NewMleError(msg, 0, err)
+	}
+	return NewMleDwpParser(string(text)).Parse()
+}
+
+// Create an error describing malformed workprint text.
+func (parser *MleDwpParser) newError(line int, msg string) *mle_core.MleError {
+	str := "MleDwpParser: line " + strconv.Itoa(line) + ": " + msg
+	return mle_core.NewMleError(str, 0, nil)
+}
+
+// Read the next token.
+func (parser *MleDwpParser) nextToken() (*_DwpToken, *mle_core.MleError) {
+	// Skip white space and comments.
+	for parser.m_pos < len(parser.m_text) {
+		c := parser.m_text[parser.m_pos]
+		if c == '\n' {
+			parser.m_line++
+			parser.m_pos++
+		} else if (c == ' ') || (c == '\t') || (c == '\r') {
+			parser.m_pos++
+		} else if (c == '#') || (c == ';') {
+			for (parser.m_pos < len(parser.m_text)) && (parser.m_text[parser.m_pos] != '\n') {
+				parser.m_pos++
+			}
+		} else {
+			break
+		}
+	}
+
+	token := &_DwpToken{m_line: parser.m_line}
+	if parser.m_pos >= len(parser.m_text) {
+		token.m_kind = _DWP_TOKEN_EOF
+		return token, nil
+	}
+
+	c := parser.m_text[parser.m_pos]
+	switch c {
+	case '(':
+		token.m_kind = _DWP_TOKEN_OPEN
+		parser.m_pos++
+	case ')':
+		token.m_kind = _DWP_TOKEN_CLOSE
+		parser.m_pos++
+	case '"':
+		// A quoted atom; '\' escapes the next character.
+		var atom strings.Builder
+		parser.m_pos++
+		for {
+			if parser.m_pos >= len(parser.m_text) {
+				return nil, parser.newError(token.m_line, "unterminated string.")
+			}
+			c = parser.m_text[parser.m_pos]
+			parser.m_pos++
+			if c == '"' {
+				break
+			}
+			if (c == '\\') && (parser.m_pos < len(parser.m_text)) {
+				c = parser.m_text[parser.m_pos]
+				parser.m_pos++
+			}
+			if c == '\n' {
+				parser.m_line++
+			}
+			atom.WriteRune(c)
+		}
+		token.m_kind = _DWP_TOKEN_ATOM
+		token.m_text = atom.String()
+	default:
+		start := parser.m_pos
+		for parser.m_pos < len(parser.m_text) {
+			c = parser.m_text[parser.m_pos]
+			if strings.ContainsRune(" \t\r\n()\"#;", c) {
+				break
+			}
+			parser.m_pos++
+		}
+		token.m_kind = _DWP_TOKEN_ATOM
+		token.m_text = string(parser.m_text[start:parser.m_pos])
+	}
+	return token, nil
+}
+
+// Read a list; the opening parenthesis has already been read.
+func (parser *MleDwpParser) readList(line int) (*_DwpList, *mle_core.MleError) {
+	list := &_DwpList{m_line: line}
+	for {
+		token, err := parser.nextToken()
+		if err != nil {
+			return nil, err
+		}
+		switch token.m_kind {
+		case _DWP_TOKEN_EOF:
+			return nil, parser.newError(line, "missing ')'.")
+		case _DWP_TOKEN_CLOSE:
+			if len(list.m_atoms) == 0 {
+				return nil, parser.newError(line, "missing item type.")
+			}
+			return list, nil
+		case _DWP_TOKEN_OPEN:
+			if len(list.m_atoms) == 0 {
+				return nil, parser.newError(token.m_line, "missing item type.")
+			}
+			child, err := parser.readList(token.m_line)
+			if err != nil {
+				return nil, err
+			}
+			list.m_lists = append(list.m_lists, child)
+		case _DWP_TOKEN_ATOM:
+			if len(list.m_lists) > 0 {
+				return nil, parser.newError(token.m_line, "argument "+token.m_text+" follows a nested item.")
+			}
+			list.m_atoms = append(list.m_atoms, token.m_text)
+		}
+	}
+}
+
+// The item types which may be nested within each item type.
+var _DwpChildTypes = map[string][]string{
+	MLE_DWP_WORKPRINT:   {MLE_DWP_SET, MLE_DWP_GROUP, MLE_DWP_SCENE, MLE_DWP_MEDIAREF, MLE_DWP_BOOTSCENE},
+	MLE_DWP_SET:         {MLE_DWP_PROPERTY},
+	MLE_DWP_GROUP:       {MLE_DWP_ACTOR},
+	MLE_DWP_ACTOR:       {MLE_DWP_PROPERTY, MLE_DWP_ROLEBINDING},
+	MLE_DWP_SCENE:       {MLE_DWP_GROUP, MLE_DWP_GROUPREF},
+	MLE_DWP_MEDIAREF:    {MLE_DWP_MEDIA},
+	MLE_DWP_GROUPREF:    {},
+	MLE_DWP_ROLEBINDING: {},
+	MLE_DWP_PROPERTY:    {},
+	MLE_DWP_MEDIA:       {},
+	MLE_DWP_BOOTSCENE:   {},
+}
+
+// Check the number of arguments of a list.
+func (parser *MleDwpParser) checkArgs(list *_DwpList, min int, max int) *mle_core.MleError {
+	n := len(list.m_atoms) - 1
+	if (n < min) || ((max >= 0) && (n > max)) {
+		return parser.newError(list.m_line, "wrong number of arguments for "+list.m_atoms[0]+".")
+	}
+	return nil
+}
+
+// Convert a list to an item and add it to its parent.
+func (parser *MleDwpParser) buildItem(wp *MleDwpWorkprint, parent IMleDwpItem, list *_DwpList) *mle_core.MleError {
+	itemType := list.m_atoms[0]
+	args := list.m_atoms[1:]
+
+	allowed := false
+	for _, childType := range _DwpChildTypes[parent.GetType()] {
+		if childType == itemType {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		if _, known := _DwpChildTypes[itemType]; !known {
+			return parser.newError(list.m_line, "unknown item "+itemType+".")
+		}
+		return parser.newError(list.m_line, itemType+" is not allowed in "+parent.GetType()+".")
+	}
+
+	var item IMleDwpItem
+	var err *mle_core.MleError
+	switch itemType {
+	case MLE_DWP_SET:
+		if err = parser.checkArgs(list, 2, 2); err == nil {
+			item = NewMleDwpSet(args[0], args[1])
+		}
+	case MLE_DWP_GROUP:
+		if err = parser.checkArgs(list, 2, 2); err == nil {
+			item = NewMleDwpGroup(args[0], args[1])
+		}
+	case MLE_DWP_GROUPREF:
+		if err = parser.checkArgs(list, 1, 1); err == nil {
+			item = NewMleDwpGroupRef(args[0])
+		}
+	case MLE_DWP_ACTOR:
+		if err = parser.checkArgs(list, 2, 2); err == nil {
+			item = NewMleDwpActor(args[0], args[1])
+		}
+	case MLE_DWP_ROLEBINDING:
+		if err = parser.checkArgs(list, 2, 3); err == nil {
+			for _, sibling := range parent.GetChildren() {
+				if sibling.GetType() == MLE_DWP_ROLEBINDING {
+					return parser.newError(list.m_line, "Actor "+parent.GetName()+" has more than one RoleBinding.")
+				}
+			}
+			parentActor := ""
+			if len(args) == 3 {
+				parentActor = args[2]
+			}
+			item = NewMleDwpRoleBinding(args[0], args[1], parentActor)
+		}
+	case MLE_DWP_PROPERTY:
+		if err = parser.checkArgs(list, 3, -1); err == nil {
+			item = NewMleDwpProperty(args[0], args[1], args[2:])
+		}
+	case MLE_DWP_SCENE:
+		if err = parser.checkArgs(list, 2, 2); err == nil {
+			item = NewMleDwpScene(args[0], args[1])
+		}
+	case MLE_DWP_MEDIAREF:
+		if err = parser.checkArgs(list, 2, 2); err == nil {
+			item = NewMleDwpMediaRef(args[0], args[1])
+		}
+	case MLE_DWP_MEDIA:
+		if err = parser.checkArgs(list, 2, 2); err == nil {
+			flags, perr := strconv.ParseInt(args[0], 0, 32)
+			if perr != nil {
+				return parser.newError(list.m_line, "invalid Media flags "+args[0]+".")
+			}
+			item = NewMleDwpMedia(int32(flags), args[1])
+		}
+	case MLE_DWP_BOOTSCENE:
+		if err = parser.checkArgs(list, 1, 1); err == nil {
+			if wp.m_bootScene != "" {
+				return parser.newError(list.m_line, "more than one BootScene.")
+			}
+			wp.m_bootScene = args[0]
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// Named definitions must be unique.
+	switch itemType {
+	case MLE_DWP_SET, MLE_DWP_GROUP, MLE_DWP_SCENE, MLE_DWP_MEDIAREF:
+		if wp.FindItem(itemType, item.GetName()) != nil {
+			return parser.newError(list.m_line, "duplicate "+itemType+" "+item.GetName()+".")
+		}
+	}
+
+	item.setLine(list.m_line)
+	item.setParent(parent)
+	parent.addChild(item)
+
+	for _, child := range list.m_lists {
+		err = parser.buildItem(wp, item, child)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Parse the workprint.
+ *
+ * @return The root of the workprint is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the workprint
+ * is malformed.
+ */
+func (parser *MleDwpParser) Parse() (*MleDwpWorkprint, *mle_core.MleError) {
+	wp := NewMleDwpWorkprint()
+	for {
+		token, err := parser.nextToken()
+		if err != nil {
+			return nil, err
+		}
+		if token.m_kind == _DWP_TOKEN_EOF {
+			break
+		}
+		if token.m_kind != _DWP_TOKEN_OPEN {
+			return nil, parser.newError(token.m_line, "expected '('.")
+		}
+		list, err := parser.readList(token.m_line)
+		if err != nil {
+			return nil, err
+		}
+		err = parser.buildItem(wp, wp, list)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return wp, nil
+}
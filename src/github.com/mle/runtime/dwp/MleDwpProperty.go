@@ -0,0 +1,197 @@
+/**
+ * @file MleDwpProperty.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dwp
+
+// Import go packages.
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strconv"
+
+	mle_core "github.com/mle/runtime/core"
+)
+
+/** A 32-bit integer property. */
+const MLE_DWP_TYPE_INT string = "int"
+
+/** A 32-bit floating-point property. */
+const MLE_DWP_TYPE_FLOAT string = "float"
+
+/** A 64-bit floating-point property. */
+const MLE_DWP_TYPE_DOUBLE string = "double"
+
+/** A boolean property, stored as a single byte. */
+const MLE_DWP_TYPE_BOOL string = "bool"
+
+/** A string property. */
+const MLE_DWP_TYPE_STRING string = "string"
+
+/**
+ * MleDwpProperty is a property value in a Digital Workprint.
+ * <p>
+ * A property has a name, a type and one or more values. Numeric and
+ * boolean properties with more than one value are arrays.
+ * <pre>
+ *   (Property name type value ...)
+ * </pre>
+ * </p>
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpProperty struct {
+	MleDwpItem
+	/** The property type. */
+	m_propType string
+	/** The property values, as written in the workprint. */
+	m_values []string
+}
+
+/**
+ * A constructor that initializes the property.
+ *
+ * @param name The name of the property.
+ * @param propType The type of the property (e.g. MLE_DWP_TYPE_INT).
+ * @param values The property values.
+ */
+func NewMleDwpProperty(name string, propType string, values []string) *MleDwpProperty {
+	p := new(MleDwpProperty)
+	p.init(MLE_DWP_PROPERTY, name, 0)
+	p.m_propType = propType
+	p.m_values = values
+	return p
+}
+
+/**
+ * Get the type of the property.
+ *
+ * @return The property type is returned (e.g. MLE_DWP_TYPE_INT).
+ */
+func (prop *MleDwpProperty) GetPropertyType() string {
+	return prop.m_propType
+}
+
+/**
+ * Get the values of the property.
+ *
+ * @return The values are returned, as written in the workprint.
+ */
+func (prop *MleDwpProperty) GetValues() []string {
+	return prop.m_values
+}
+
+// Create an error describing an invalid property.
+func (prop *MleDwpProperty) newError(msg string, err error) *mle_core.MleError {
+	str := "MleDwpProperty: line " + strconv.Itoa(prop.m_line) + ": property " + prop.m_name + ": " + msg
+	return mle_core.NewMleError(str, 0, err)
+}
+
+/**
+ * Get the property data.
+ * <p>
+ * The values are encoded in big-endian byte order, the same encoding used
+ * by the Digital Playprint, so the data may be passed directly to
+ * <code>IMleObject.SetPropertyArray()</code>.
+ * </p>
+ *
+ * @return The encoded data, the length of each element, in bytes, and the
+ * number of elements are returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the type is
+ * unknown or a value can not be converted to the type.
+ */
+func (prop *MleDwpProperty) GetData() ([]byte, int, int, *mle_core.MleError) {
+	var buf bytes.Buffer
+	var length int
+
+	switch prop.m_propType {
+	case MLE_DWP_TYPE_STRING:
+		if len(prop.m_values) != 1 {
+			return nil, 0, 0, prop.newError("a string property must have exactly one value.", nil)
+		}
+		return []byte(prop.m_values[0]), len(prop.m_values[0]), 1, nil
+	case MLE_DWP_TYPE_INT:
+		length = 4
+	case MLE_DWP_TYPE_FLOAT:
+		length = 4
+	case MLE_DWP_TYPE_DOUBLE:
+		length = 8
+	case MLE_DWP_TYPE_BOOL:
+		length = 1
+	default:
+		return nil, 0, 0, prop.newError("unknown type "+prop.m_propType+".", nil)
+	}
+
+	for _, value := range prop.m_values {
+		switch prop.m_propType {
+		case MLE_DWP_TYPE_INT:
+			v, err := strconv.ParseInt(value, 0, 32)
+			if err != nil {
+				return nil, 0, 0, prop.newError("invalid int "+value+".", err)
+			}
+			binary.Write(&buf, binary.BigEndian, int32(v))
+		case MLE_DWP_TYPE_FLOAT:
+			v, err := strconv.ParseFloat(value, 32)
+			if err != nil {
+				return nil, 0, 0, prop.newError("invalid float "+value+".", err)
+			}
+			binary.Write(&buf, binary.BigEndian, math.Float32bits(float32(v)))
+		case MLE_DWP_TYPE_DOUBLE:
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, 0, 0, prop.newError("invalid double "+value+".", err)
+			}
+			binary.Write(&buf, binary.BigEndian, math.Float64bits(v))
+		case MLE_DWP_TYPE_BOOL:
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, 0, 0, prop.newError("invalid bool "+value+".", err)
+			}
+			if v {
+				buf.WriteByte(1)
+			} else {
+				buf.WriteByte(0)
+			}
+		}
+	}
+
+	return buf.Bytes(), length, len(prop.m_values), nil
+}
@@ -0,0 +1,96 @@
+/**
+ * @file MleDwpScene.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dwp
+
+/**
+ * MleDwpScene defines a Scene in a Digital Workprint.
+ * <p>
+ * A Scene contains Groups, either by reference or defined inline.
+ * <pre>
+ *   (Scene name class (GroupRef name) (Group ...) ...)
+ * </pre>
+ * </p>
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpScene struct {
+	MleDwpItem
+	/** The registered class of the Scene. */
+	m_class string
+}
+
+/**
+ * A constructor that initializes the name and class of the Scene.
+ *
+ * @param name The name of the Scene.
+ * @param class The registered class of the Scene.
+ */
+func NewMleDwpScene(name string, class string) *MleDwpScene {
+	p := new(MleDwpScene)
+	p.init(MLE_DWP_SCENE, name, 0)
+	p.m_class = class
+	return p
+}
+
+/**
+ * Get the class of the Scene.
+ *
+ * @return The registered class name is returned.
+ */
+func (scene *MleDwpScene) GetClassName() string {
+	return scene.m_class
+}
+
+/**
+ * Get the Groups in the Scene.
+ *
+ * @return The <code>MleDwpGroup</code> and <code>MleDwpGroupRef</code>
+ * items are returned, in the order they were defined.
+ */
+func (scene *MleDwpScene) GetGroups() []IMleDwpItem {
+	var groups []IMleDwpItem
+	for _, child := range scene.m_children {
+		if (child.GetType() == MLE_DWP_GROUP) || (child.GetType() == MLE_DWP_GROUPREF) {
+			groups = append(groups, child)
+		}
+	}
+	return groups
+}
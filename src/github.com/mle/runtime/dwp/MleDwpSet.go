@@ -0,0 +1,88 @@
+/**
+ * @file MleDwpSet.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package dwp
+
+/**
+ * MleDwpSet defines a Set in a Digital Workprint.
+ * <p>
+ * <pre>
+ *   (Set name class (Property ...) ...)
+ * </pre>
+ * </p>
+ *
+ * @author Mark S. Millard
+ * @version 1.0
+ */
+type MleDwpSet struct {
+	MleDwpItem
+	/** The registered class of the Set. */
+	m_class string
+}
+
+/**
+ * A constructor that initializes the name and class of the Set.
+ *
+ * @param name The name of the Set.
+ * @param class The registered class of the Set.
+ */
+func NewMleDwpSet(name string, class string) *MleDwpSet {
+	p := new(MleDwpSet)
+	p.init(MLE_DWP_SET, name, 0)
+	p.m_class = class
+	return p
+}
+
+/**
+ * Get the class of the Set.
+ *
+ * @return The registered class name is returned.
+ */
+func (set *MleDwpSet) GetClassName() string {
+	return set.m_class
+}
+
+/**
+ * Get the property values of the Set.
+ *
+ * @return The properties are returned, in the order they were defined.
+ */
+func (set *MleDwpSet) GetProperties() []*MleDwpProperty {
+	return set.getProperties()
+}
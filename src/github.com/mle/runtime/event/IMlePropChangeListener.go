@@ -49,16 +49,8 @@ import (
  * You can register a <code>IMlePropChangeListener</code> with a source <code>Actor</code>
  * so as to be notified of any bound property updates.
  *
+ * @see mle_core.IMlePropChangeListener
+ *
  * @author Mark S. Millard
  */
-type IMlePropChangeListener interface {
-	mle_core.IMleListener
-
-	/**
-	 * This method gets called when a bound property is changed.
-	 *
-	 * @param event A <code>MlePropChangeEvent</code> object describing the event
-	 * source and the property that has changed.
-	 */
-	PropChangedEvent(event MlePropChangeEvent)
-}
+type IMlePropChangeListener = mle_core.IMlePropChangeListener
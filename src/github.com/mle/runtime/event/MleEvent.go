@@ -45,8 +45,35 @@ import (
 	mle_util "github.com/mle/runtime/util"
 )
 
-/** Constant defining invalid id. */
-const MLE_EVENT_INVALID_ID int = 0xffffffff
+/**
+ * Constant defining invalid id. It is negative, so it fits in an
+ * <code>int</code> on every platform and is never a valid composite
+ * identifier (see IsValidEventId).
+ */
+const MLE_EVENT_INVALID_ID int = -1
+
+/** The event is not being propagated. */
+const MLE_EVENT_PHASE_NONE int = 0
+/** The event is propagating down from the root towards its target. */
+const MLE_EVENT_PHASE_CAPTURING int = 1
+/** The event is being dispatched at its target. */
+const MLE_EVENT_PHASE_AT_TARGET int = 2
+/** The event is propagating up from its target towards the root. */
+const MLE_EVENT_PHASE_BUBBLING int = 3
+
+// The propagation state of an event, shared by copies of the event.
+type _EventPropagation struct {
+	// The node the event is targeted at.
+	m_target interface{}
+	// The node whose callbacks are being dispatched.
+	m_currentTarget interface{}
+	// The propagation phase.
+	m_phase int
+	// Flag indicating that propagation has been stopped.
+	m_stopped bool
+	// Flag indicating that the default action has been prevented.
+	m_defaultPrevented bool
+}
 
 /**
  * <code>MleEvent</code> encapsulates a Magic Lantern event.
@@ -63,6 +90,8 @@ type MleEvent struct {
 	m_type int16
 	/** Call data assoicated with the event. */
 	m_calldata mle_util.IObject
+	/** The propagation state, nil unless the event is propagated. */
+	m_propagation *_EventPropagation
 }
 
 /**
@@ -75,10 +104,10 @@ type MleEvent struct {
  * @return The composite event identifier is returned.
  */
 func MakeId(group int16, id int16) int {
-	//var newId int = (int)((group << 16) | id)
+	// The event id is not sign extended into the group.
 	var a  = int(group)
 	a = a<<16
-	var b = a | int(id)
+	var b = a | int(uint16(id))
 	return b
 }
 
@@ -99,10 +128,11 @@ func GetGroupId(cid int) int16 {
  *
  * @param cid The composite event identifier.
  *
- * @return The event id is returned.
+ * @return The event id is returned. Ids above 0x7FFF are negative; use
+ * <code>ToEventID(cid).GetEvent()</code> for the unsigned id.
  */
 func GetEventId(cid int) int16 {
-	var eventId int16 = int16(cid & 0x00FF)
+	var eventId int16 = int16(cid & 0xFFFF)
 	return eventId
 }
 
@@ -263,6 +293,85 @@ func (event *MleEvent) GetSource() mle_util.Object {
     return event.m_event.GetSource()
 }
 
+/**
+ * Stop the propagation of the event. The remaining callbacks of the current
+ * node are still dispatched, but no further nodes are visited. This has no
+ * effect on an event that is not propagated (see
+ * <code>MleEventPropagator</code>).
+ */
+func (event *MleEvent) StopPropagation() {
+	if event.m_propagation != nil {
+		event.m_propagation.m_stopped = true
+	}
+}
+
+/**
+ * Determine whether the propagation of the event has been stopped.
+ */
+func (event *MleEvent) IsPropagationStopped() bool {
+	return (event.m_propagation != nil) && event.m_propagation.m_stopped
+}
+
+/**
+ * Prevent the default action of the event. Propagation continues; the
+ * sender of the event decides whether to perform its default action. This
+ * has no effect on an event that is not propagated.
+ */
+func (event *MleEvent) PreventDefault() {
+	if event.m_propagation != nil {
+		event.m_propagation.m_defaultPrevented = true
+	}
+}
+
+/**
+ * Determine whether the default action of the event has been prevented.
+ */
+func (event *MleEvent) IsDefaultPrevented() bool {
+	return (event.m_propagation != nil) && event.m_propagation.m_defaultPrevented
+}
+
+/**
+ * Get the propagation phase of the event.
+ *
+ * @return One of <code>MLE_EVENT_PHASE_NONE</code>,
+ * <code>MLE_EVENT_PHASE_CAPTURING</code>,
+ * <code>MLE_EVENT_PHASE_AT_TARGET</code> or
+ * <code>MLE_EVENT_PHASE_BUBBLING</code> is returned.
+ */
+func (event *MleEvent) GetPhase() int {
+	if event.m_propagation == nil {
+		return MLE_EVENT_PHASE_NONE
+	}
+	return event.m_propagation.m_phase
+}
+
+/**
+ * Get the node the event is targeted at, such as an <code>MleActor</code>.
+ *
+ * @return The target is returned, or <b>nil</b> if the event is not
+ * propagated.
+ */
+func (event *MleEvent) GetTarget() interface{} {
+	if event.m_propagation == nil {
+		return nil
+	}
+	return event.m_propagation.m_target
+}
+
+/**
+ * Get the node whose callbacks are being dispatched, such as the
+ * <code>MleScene</code> during capture.
+ *
+ * @return The current node is returned, or <b>nil</b> if the event is not
+ * propagated.
+ */
+func (event *MleEvent) GetCurrentTarget() interface{} {
+	if event.m_propagation == nil {
+		return nil
+	}
+	return event.m_propagation.m_currentTarget
+}
+
 /**
  * Create a String representation of this MleEvent Object.
  *
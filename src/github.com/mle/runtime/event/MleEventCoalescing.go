@@ -0,0 +1,250 @@
+/**
+ * @file MleEventCoalescing.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import go packages.
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	mle_util "github.com/mle/runtime/util"
+	mle_core "github.com/mle/runtime/core"
+)
+
+/** Every delayed event is dispatched; this is the default. */
+const MLE_COALESCE_NONE int = 0
+/** A pending event takes the call data of the latest event pushed. */
+const MLE_COALESCE_KEEP_LAST int = 1
+/** A pending event keeps its call data; later events are dropped. */
+const MLE_COALESCE_KEEP_FIRST int = 2
+/** A pending event takes the call data merged by a user function. */
+const MLE_COALESCE_MERGE int = 3
+/**
+ * A pending event counts the events pushed; its call data is a
+ * <code>MleEventCountCallData</code>.
+ */
+const MLE_COALESCE_COUNT int = 4
+
+/**
+ * <code>MleEventMergeFunc</code> merges the call data of an event pushed
+ * while an event with the same id is pending, for the
+ * <code>MLE_COALESCE_MERGE</code> policy.
+ *
+ * @param pending The call data of the pending event.
+ * @param calldata The call data of the event pushed.
+ *
+ * @return The call data of the pending event is returned.
+ */
+type MleEventMergeFunc func(pending mle_util.IObject, calldata mle_util.IObject) mle_util.IObject
+
+/**
+ * <code>MleEventCountCallData</code> is the call data of an event coalesced
+ * with the <code>MLE_COALESCE_COUNT</code> policy.
+ */
+type MleEventCountCallData struct {
+	// The number of events coalesced.
+	m_count int
+	// The call data of the latest event.
+	m_calldata mle_util.IObject
+}
+
+/**
+ * Get the number of events coalesced into the dispatched event.
+ *
+ * @return The count is returned; it is at least 1.
+ */
+func (cd *MleEventCountCallData) GetCount() int {
+	return cd.m_count
+}
+
+/**
+ * Get the call data of the latest event coalesced.
+ *
+ * @return The call data is returned; it may be <b>nil</b>.
+ */
+func (cd *MleEventCountCallData) GetCallData() mle_util.IObject {
+	return cd.m_calldata
+}
+
+// String implements the IObject interface.
+func (cd *MleEventCountCallData) String() string {
+	return strconv.Itoa(cd.m_count)
+}
+
+// The coalescing policy of an event id.
+type _CoalescePolicy struct {
+	// The policy.
+	m_policy int
+	// The merge function of the MLE_COALESCE_MERGE policy.
+	m_merge MleEventMergeFunc
+}
+
+// The coalescing policies of a dispatcher, and its pending coalesced events.
+type _EventCoalescing struct {
+	// The policies by event id.
+	m_policies map[int]*_CoalescePolicy
+	// The queued events which have not been dispatched, by event id.
+	m_pending map[int]*_EventQueueElement
+	// Internal lock used for protecting sensitve code.
+	lock sync.Mutex
+}
+
+// Coalesce an event pushed for delayed dispatch into the pending event
+// with the same id. If there is none, the event becomes the pending event
+// to be queued, and false is returned.
+func (coalescing *_EventCoalescing) coalesce(element *_EventQueueElement) bool {
+	coalescing.lock.Lock()
+	defer coalescing.lock.Unlock()
+
+	event := element.m_event
+	policy := coalescing.m_policies[event.GetId()]
+	if policy == nil {
+		return false
+	}
+
+	pending := coalescing.m_pending[event.GetId()]
+	if pending == nil {
+		if policy.m_policy == MLE_COALESCE_COUNT {
+			event.SetCallData(&MleEventCountCallData{1, event.GetCallData()})
+		}
+		coalescing.m_pending[event.GetId()] = element
+		return false
+	}
+
+	// The pending event keeps its queue position and priority.
+	switch policy.m_policy {
+	case MLE_COALESCE_KEEP_LAST:
+		pending.m_event.SetCallData(event.GetCallData())
+	case MLE_COALESCE_MERGE:
+		pending.m_event.SetCallData(policy.m_merge(pending.m_event.GetCallData(), event.GetCallData()))
+	case MLE_COALESCE_COUNT:
+		count := pending.m_event.GetCallData().(*MleEventCountCallData)
+		count.m_count++
+		count.m_calldata = event.GetCallData()
+	}
+	return true
+}
+
+// Release an event removed from the queue, so later events are no longer
+// coalesced into it.
+func (coalescing *_EventCoalescing) release(element *_EventQueueElement) {
+	coalescing.lock.Lock()
+	defer coalescing.lock.Unlock()
+	id := element.m_event.GetId()
+	if coalescing.m_pending[id] == element {
+		delete(coalescing.m_pending, id)
+	}
+}
+
+// Release every pending event.
+func (coalescing *_EventCoalescing) clear() {
+	coalescing.lock.Lock()
+	defer coalescing.lock.Unlock()
+	if len(coalescing.m_pending) > 0 {
+		coalescing.m_pending = make(map[int]*_EventQueueElement)
+	}
+}
+
+/**
+ * Set how delayed events with the specified id are coalesced.
+ * <p>
+ * While a delayed event is pending, events with the same id pushed
+ * after it are coalesced into it rather than queued, so a burst of
+ * events is dispatched once per <code>DispatchEvents</code> pass. The
+ * pending event keeps its queue position and priority. Immediate events
+ * are never coalesced.
+ * </p>
+ *
+ * @param event The composite event identifier.
+ * @param policy The policy: <code>MLE_COALESCE_NONE</code>,
+ * <code>MLE_COALESCE_KEEP_LAST</code>, <code>MLE_COALESCE_KEEP_FIRST</code>,
+ * <code>MLE_COALESCE_MERGE</code> or <code>MLE_COALESCE_COUNT</code>.
+ * @param merge The merge function of the <code>MLE_COALESCE_MERGE</code>
+ * policy; it is ignored by the others.
+ *
+ * @return An error is returned if the policy is invalid, or if no merge
+ * function is specified for <code>MLE_COALESCE_MERGE</code>.
+ */
+func (dispatcher *MleEventDispatcher) SetCoalescePolicy(event int, policy int, merge MleEventMergeFunc) *mle_core.MleError {
+	if (policy < MLE_COALESCE_NONE) || (policy > MLE_COALESCE_COUNT) {
+		msg := fmt.Sprintf("MleEventDispatcher: invalid coalescing policy %d.", policy)
+		return mle_core.NewMleError(msg, 0, nil)
+	}
+	if (policy == MLE_COALESCE_MERGE) && (merge == nil) {
+		msg := "MleEventDispatcher: MLE_COALESCE_MERGE requires a merge function."
+		return mle_core.NewMleError(msg, 0, nil)
+	}
+
+	coalescing := &dispatcher.m_coalescing
+	coalescing.lock.Lock()
+	defer coalescing.lock.Unlock()
+	if coalescing.m_policies == nil {
+		coalescing.m_policies = make(map[int]*_CoalescePolicy)
+		coalescing.m_pending = make(map[int]*_EventQueueElement)
+	}
+	// Events already pending are released, as they may have been
+	// coalesced with another policy.
+	delete(coalescing.m_pending, event)
+	if policy == MLE_COALESCE_NONE {
+		delete(coalescing.m_policies, event)
+	} else {
+		coalescing.m_policies[event] = &_CoalescePolicy{policy, merge}
+	}
+	return nil
+}
+
+/**
+ * Get how delayed events with the specified id are coalesced.
+ *
+ * @param event The composite event identifier.
+ *
+ * @return The policy is returned; <code>MLE_COALESCE_NONE</code> if none
+ * was set.
+ */
+func (dispatcher *MleEventDispatcher) GetCoalescePolicy(event int) int {
+	coalescing := &dispatcher.m_coalescing
+	coalescing.lock.Lock()
+	defer coalescing.lock.Unlock()
+	if policy := coalescing.m_policies[event]; policy != nil {
+		return policy.m_policy
+	}
+	return MLE_COALESCE_NONE
+}
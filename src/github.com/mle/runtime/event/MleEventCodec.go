@@ -0,0 +1,231 @@
+/**
+ * @file MleEventCodec.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import go packages.
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	mle_util "github.com/mle/runtime/util"
+)
+
+/**
+ * <code>IMleEventCodec</code> serializes event call data for recordings
+ * (see <code>MleEventRecorder</code>).
+ */
+type IMleEventCodec interface {
+	/**
+	 * Get the codec name, which is stored in recordings and checked when
+	 * they are loaded.
+	 */
+	GetName() string
+
+	/**
+	 * Encode the call data of an event.
+	 *
+	 * @param id The composite event identifier.
+	 * @param calldata The call data, which is never <b>nil</b>.
+	 */
+	Encode(id int, calldata mle_util.IObject) ([]byte, error)
+
+	/**
+	 * Decode the call data of an event.
+	 *
+	 * @param id The composite event identifier.
+	 * @param data The encoded call data.
+	 */
+	Decode(id int, data []byte) (mle_util.IObject, error)
+}
+
+/**
+ * <code>MleStringCallData</code> is call data holding a string, as decoded
+ * by the string codec.
+ */
+type MleStringCallData struct {
+	// The string value.
+	m_value string
+}
+
+/**
+ * Construct call data holding the specified string.
+ *
+ * @param value The string value.
+ */
+func NewMleStringCallData(value string) *MleStringCallData {
+	p := new(MleStringCallData)
+	p.m_value = value
+	return p
+}
+
+// String implements the IObject interface.
+func (cd *MleStringCallData) String() string {
+	return cd.m_value
+}
+
+// The codec recording the String value of call data.
+type _StringEventCodec struct{}
+
+/**
+ * Get a codec that records the <code>String</code> value of call data and
+ * decodes it as <code>MleStringCallData</code>. It is lossy for call data
+ * that is not a string, but accepts anything.
+ */
+func NewMleStringEventCodec() IMleEventCodec {
+	return _StringEventCodec{}
+}
+
+// GetName implements the IMleEventCodec interface.
+func (codec _StringEventCodec) GetName() string {
+	return "string"
+}
+
+// Encode implements the IMleEventCodec interface.
+func (codec _StringEventCodec) Encode(id int, calldata mle_util.IObject) ([]byte, error) {
+	return []byte(calldata.String()), nil
+}
+
+// Decode implements the IMleEventCodec interface.
+func (codec _StringEventCodec) Decode(id int, data []byte) (mle_util.IObject, error) {
+	return NewMleStringCallData(string(data)), nil
+}
+
+// The codec recording TypedCallData payloads as JSON.
+type _TypedEventCodec[T any] struct{}
+
+/**
+ * Get a codec that records the payload of <code>TypedCallData</code> of
+ * type <b>T</b> as JSON, as emitted for an <code>EventType[T]</code>.
+ */
+func NewMleTypedEventCodec[T any]() IMleEventCodec {
+	return _TypedEventCodec[T]{}
+}
+
+// GetName implements the IMleEventCodec interface.
+func (codec _TypedEventCodec[T]) GetName() string {
+	return "json:" + reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// Encode implements the IMleEventCodec interface.
+func (codec _TypedEventCodec[T]) Encode(id int, calldata mle_util.IObject) ([]byte, error) {
+	typed, ok := calldata.(*TypedCallData[T])
+	if ! ok {
+		return nil, fmt.Errorf("call data %T is not a %s payload", calldata, codec.GetName())
+	}
+	return json.Marshal(typed.GetPayload())
+}
+
+// Decode implements the IMleEventCodec interface.
+func (codec _TypedEventCodec[T]) Decode(id int, data []byte) (mle_util.IObject, error) {
+	var payload T
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	return NewTypedCallData(payload), nil
+}
+
+/**
+ * <code>MleEventCodecMux</code> chooses a codec by event identifier, so a
+ * recording may hold events with different kinds of call data.
+ */
+type MleEventCodecMux struct {
+	// The codecs by event identifier.
+	m_codecs map[int]IMleEventCodec
+	// The codec for other events, may be nil.
+	m_default IMleEventCodec
+}
+
+/**
+ * Construct a codec multiplexer.
+ *
+ * @param fallback The codec for events without a codec of their own, or
+ * <b>nil</b> for none.
+ */
+func NewMleEventCodecMux(fallback IMleEventCodec) *MleEventCodecMux {
+	p := new(MleEventCodecMux)
+	p.m_codecs = make(map[int]IMleEventCodec)
+	p.m_default = fallback
+	return p
+}
+
+/**
+ * Set the codec for the specified event.
+ *
+ * @param id The composite event identifier.
+ * @param codec The codec for the event's call data.
+ */
+func (mux *MleEventCodecMux) SetCodec(id int, codec IMleEventCodec) {
+	mux.m_codecs[id] = codec
+}
+
+// Get the codec for the specified event.
+func (mux *MleEventCodecMux) getCodec(id int) (IMleEventCodec, error) {
+	if codec, ok := mux.m_codecs[id]; ok {
+		return codec, nil
+	}
+	if mux.m_default != nil {
+		return mux.m_default, nil
+	}
+	return nil, fmt.Errorf("no codec for event 0x%08x", id)
+}
+
+// GetName implements the IMleEventCodec interface.
+func (mux *MleEventCodecMux) GetName() string {
+	return "mux"
+}
+
+// Encode implements the IMleEventCodec interface.
+func (mux *MleEventCodecMux) Encode(id int, calldata mle_util.IObject) ([]byte, error) {
+	codec, err := mux.getCodec(id)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Encode(id, calldata)
+}
+
+// Decode implements the IMleEventCodec interface.
+func (mux *MleEventCodecMux) Decode(id int, data []byte) (mle_util.IObject, error) {
+	codec, err := mux.getCodec(id)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Decode(id, data)
+}
@@ -41,6 +41,10 @@ package event
 
 // Import go packages.
 import (
+	"container/heap"
+	"sync"
+	"sync/atomic"
+
 	hash_tbl   "github.com/timtadh/data-structures/hashtable"
 	hash_types "github.com/timtadh/data-structures/types"
 	mle_util   "github.com/mle/runtime/util"
@@ -54,7 +58,7 @@ type _EventCBNode struct {
 	/** The associated client data. */
 	m_clientData mle_util.IObject
 	/** Flag indicating whether event is enabled. */
-	m_isEnabled bool
+	m_isEnabled atomic.Bool
 }
 
 /**
@@ -64,7 +68,7 @@ func _NewEventCBNode() *_EventCBNode {
 	p := new(_EventCBNode)
 	p.m_callback = nil
 	p.m_clientData = nil
-	p.m_isEnabled = false
+	p.m_isEnabled.Store(false)
 	return p
 }
 
@@ -75,7 +79,7 @@ func _NewEventCBNode() *_EventCBNode {
  * <b>false</b> will be returned.
  */
 func (cbnode *_EventCBNode) IsEnabled() bool {
-    return cbnode.m_isEnabled
+    return cbnode.m_isEnabled.Load()
 }
  
  /**
@@ -118,6 +122,8 @@ type _EventGroupNode struct {
 	m_head *_EventNode
 	// The last event node in a group.
 	m_tail *_EventNode
+	// A priority queue of callbacks for every event in the group.
+	m_callbacks *mle_util.MlePQ
 }
 
 // Construct a new Event Group Node.
@@ -125,6 +131,7 @@ func _NewEventGroupNode() *_EventGroupNode {
 	p := new(_EventGroupNode)
 	p.m_head = nil
 	p.m_tail = nil
+	p.m_callbacks = mle_util.NewMlePQWithSize(mle_util.MLE_INC_QSIZE)
 	return p
 }
 
@@ -230,6 +237,10 @@ func (egn *_EventGroupNode) findPrevEventNode(firstNode *_EventNode, node *_Even
 type _EventQueueElement struct {
     /** The event. */
     m_event *MleEvent
+    // The event priority.
+    m_priority int
+    // The order the event was queued in, breaking ties between priorities.
+    m_sequence uint64
 }
 
 /**
@@ -252,9 +263,65 @@ func (eqe *_EventQueueElement) _GetEvent() *MleEvent {
 	return eqe.m_event
 }
 
+// The delayed events in dispatch order: the highest priority first and,
+// for equal priorities, the first queued first.
+type _EventQueue []*_EventQueueElement
+
+func (q _EventQueue) Len() int {
+	return len(q)
+}
+
+func (q _EventQueue) Less(i, j int) bool {
+	if q[i].m_priority != q[j].m_priority {
+		return q[i].m_priority > q[j].m_priority
+	}
+	return q[i].m_sequence < q[j].m_sequence
+}
+
+func (q _EventQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *_EventQueue) Push(x interface{}) {
+	*q = append(*q, x.(*_EventQueueElement))
+}
+
+func (q *_EventQueue) Pop() interface{} {
+	old := *q
+	element := old[len(old) - 1]
+	old[len(old) - 1] = nil
+	*q = old[:len(old) - 1]
+	return element
+}
+
+/** The number of shards used to buffer delayed events from producers. */
+const MLE_EVENT_QUEUE_SHARDS int = 8
+
+// A buffer of delayed events pushed by producers, waiting to be merged
+// into the dispatcher's priority queue.
+type _EventQueueShard struct {
+    // The pushed events, in push order.
+    m_pending []*_EventQueueElement
+    // Internal lock used for protecting the pending events.
+    lock sync.Mutex
+}
+
 /**
  * <code>MleEventDispatcher</code> is used to synchronize the dispatching of
  * Magic Lantern runtime events.
+ * <p>
+ * The dispatcher is safe for concurrent use by any number of producers
+ * (ProcessEvent, PushEvent and the callback and listener registration
+ * methods) and a single consumer calling DispatchEvents. Delayed events are
+ * pushed onto one of several sharded buffers, so producers rarely contend,
+ * and are merged into the priority queue when the consumer next reads it.
+ * Delayed events are dispatched highest priority first; events of equal
+ * priority are dispatched in the order they were queued.
+ * Callbacks and listeners are invoked without any dispatcher lock held, so
+ * they may push events or change registrations; such changes take effect
+ * from the next event dispatched, except that disabling a callback takes
+ * effect immediately.
+ * </p>
  * 
  * @author Mark S. Millard
  */
@@ -262,9 +329,27 @@ type MleEventDispatcher struct {
     // The event callback nodes organized by groups.
     m_eventGroups *hash_tbl.Hash
     // The queued events.
-    m_eventQueue *mle_util.MlePQ
+    m_eventQueue _EventQueue
     // Registry of event listeners.
     m_eventListeners *mle_util.Vector
+    // Buffers of delayed events not yet merged into the queue.
+    m_shards []_EventQueueShard
+    // The shard the next delayed event is pushed onto.
+    m_nextShard atomic.Uint32
+    // The sequence number of the last queued event.
+    m_sequence atomic.Uint64
+    // The recorder of processed events, may be nil.
+    m_recorder atomic.Pointer[MleEventRecorder]
+    // The timed events.
+    m_timers _EventTimers
+    // The coalescing policies and pending coalesced events.
+    m_coalescing _EventCoalescing
+    // The middleware chain, replaced rather than modified.
+    m_middleware []*MleEventMiddlewareId
+    // Internal lock used for protecting the queued events.
+    m_queueLock sync.Mutex
+    // Internal lock used for protecting the event groups and listeners.
+    lock sync.Mutex
 }
 
 /**
@@ -276,8 +361,8 @@ type MleEventDispatcher struct {
 func NewMleEventDispatcher() *MleEventDispatcher {
 	p := new(MleEventDispatcher)
 	p.m_eventGroups = hash_tbl.NewHashTable(10)
-	p.m_eventQueue = mle_util.NewMlePQWithSize(mle_util.MLE_INC_QSIZE)
 	p.m_eventListeners = mle_util.NewVector()
+	p.m_shards = make([]_EventQueueShard, MLE_EVENT_QUEUE_SHARDS)
 	return p
 }
 
@@ -290,27 +375,32 @@ func NewMleEventDispatcher() *MleEventDispatcher {
  func NewMleEventDispatcherWithCapacity(capacity int) *MleEventDispatcher {
 	p := new(MleEventDispatcher)
 	p.m_eventGroups = hash_tbl.NewHashTable(capacity)
-	p.m_eventQueue = mle_util.NewMlePQWithSize(mle_util.MLE_INC_QSIZE)
 	p.m_eventListeners = mle_util.NewVector()
+	p.m_shards = make([]_EventQueueShard, MLE_EVENT_QUEUE_SHARDS)
 	return p
 }
 
+// Find the event group node based on the group id.
+func (dispatcher *MleEventDispatcher) findEventGroup(groupId int16) *_EventGroupNode {
+	var key hash_types.Int16 = hash_types.Int16(groupId)
+
+	value, err := dispatcher.m_eventGroups.Get(key)
+	if err != nil {
+		return nil
+	}
+	// nil may be returned for the value.
+	return value.(*_EventGroupNode)
+}
+
 // Find the event node based on the composite event id.
 func (dispatcher *MleEventDispatcher) findEventNode(id int) *_EventNode {
 	var node *_EventNode = nil
-	var groupId int16 = GetGroupId(id)
-	var key hash_types.Int16 = hash_types.Int16(groupId)
-	 
-	var value interface{}
-	var err error
-	value, err =  dispatcher.m_eventGroups.Get(key)
-	if err == nil {
-	    var group *_EventGroupNode = value.(*_EventGroupNode)
-	    if group != nil {
-		    node = group.findEventNode(id)
-	    }
-    }
-	
+
+	group := dispatcher.findEventGroup(GetGroupId(id))
+	if group != nil {
+		node = group.findEventNode(id)
+	}
+
 	// nil may be returned for the value.
 	return node
 }
@@ -358,10 +448,15 @@ func (dispatcher *MleEventDispatcher) removeEventNode(node *_EventNode) {
 
 // Find the event callback node.
 func (dispatcher *MleEventDispatcher) findEventCBNode(node *_EventNode, id *_EventCBNode) int {
+	return dispatcher.findCBNode(node.m_callbacks, id)
+}
+
+// Find the callback node in a priority queue of callbacks.
+func (dispatcher *MleEventDispatcher) findCBNode(callbacks *mle_util.MlePQ, id *_EventCBNode) int {
 	var result int = -1
 
-	for i := 0; i < node.m_callbacks.GetNumElements(); i++ {
-		item := node.m_callbacks.Peek(i)
+	for i := 0; i < callbacks.GetNumElements(); i++ {
+		item := callbacks.Peek(i)
 		if item.Data == id {
 			result = i
 			break
@@ -386,6 +481,29 @@ func (dispatcher *MleEventDispatcher) findEventCBNode(node *_EventNode, id *_Eve
  * callback can not be installed successfully.
  */
 func (dispatcher *MleEventDispatcher) InstallEventCB(event int, callback IMleEventCallback, clientData mle_util.IObject) (mle_core.IMleCallbackId, *mle_core.MleError) {
+	return dispatcher.InstallEventCBWithPriority(event, callback, clientData, 0)
+}
+
+/**
+ * Install a callback for the specified event with a dispatch priority.
+ * Callbacks with a higher priority are dispatched first.
+ * 
+ * @param event The composite event identifier.
+ * @param callback The callback to install.
+ * @param clientData Client data associated with the dispatch
+ * of the callback.
+ * @param key The callback priority.
+ * 
+ * @return A callback identifier is returned. This may be used to
+ * uniquely identify a specific callback for a particular event.
+ * 
+ * @throws MleRuntimeException This exception is thrown if the
+ * callback can not be installed successfully.
+ */
+func (dispatcher *MleEventDispatcher) InstallEventCBWithPriority(event int, callback IMleEventCallback, clientData mle_util.IObject, key int) (mle_core.IMleCallbackId, *mle_core.MleError) {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
     var node *_EventNode
  // Check if event node already exists.
 	node = dispatcher.findEventNode(event)
@@ -411,10 +529,10 @@ func (dispatcher *MleEventDispatcher) InstallEventCB(event int, callback IMleEve
     if cbNode != nil {
 	    cbNode.m_callback = &callback
 	    cbNode.m_clientData = clientData
-	    cbNode.m_isEnabled = true
+	    cbNode.m_isEnabled.Store(true)
 
 	    // Add callback node to priority queue.
-	    item := mle_util.NewMlePQElementWithKey(0, cbNode)
+	    item := mle_util.NewMlePQElementWithKey(key, cbNode)
 	    node.m_callbacks.Insert(item)
     } else  {
 	    var msg string = "MleEventDispatcher: Unable to install event callback."
@@ -436,6 +554,9 @@ func (dispatcher *MleEventDispatcher) InstallEventCB(event int, callback IMleEve
  * Otherwise, <b>false</b> will be returned.
  */
 func (dispatcher *MleEventDispatcher) UninstallEventCB(event int, id mle_core.IMleCallbackId) bool {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
 	var node *_EventNode
  
 	// Find event node.
@@ -458,6 +579,66 @@ func (dispatcher *MleEventDispatcher) UninstallEventCB(event int, id mle_core.IM
 	return true
 }
 
+/**
+ * Install a callback for every event in the specified group, including
+ * events that have no callbacks of their own. Group callbacks are
+ * dispatched with the event's callbacks, in priority order, unless the
+ * event has been disabled.
+ * 
+ * @param group The group identifier (see <code>GetGroupId</code>).
+ * @param callback The callback to install.
+ * @param clientData Client data associated with the dispatch
+ * of the callback.
+ * @param key The callback priority.
+ * 
+ * @return A callback identifier is returned. It may be used to uninstall
+ * the callback with <code>UninstallGroupCB</code>.
+ */
+func (dispatcher *MleEventDispatcher) InstallGroupCB(group int16, callback IMleEventCallback, clientData mle_util.IObject, key int) (mle_core.IMleCallbackId, *mle_core.MleError) {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
+	groupNode := dispatcher.findEventGroup(group)
+	if groupNode == nil {
+		groupNode = _NewEventGroupNode()
+		dispatcher.m_eventGroups.Put(hash_types.Int16(group), groupNode)
+	}
+
+	cbNode := _NewEventCBNode()
+	cbNode.m_callback = &callback
+	cbNode.m_clientData = clientData
+	cbNode.m_isEnabled.Store(true)
+	groupNode.m_callbacks.Insert(mle_util.NewMlePQElementWithKey(key, cbNode))
+
+	return cbNode, nil
+}
+
+/**
+ * Uninstall the specified callback associated with the given
+ * <b>group</b>.
+ * 
+ * @param group The group identifier.
+ * @param id The identifier for the callback to uninstall.
+ * 
+ * @return <b>true</b> is returned if the callback is successfully uninstalled.
+ * Otherwise, <b>false</b> will be returned.
+ */
+func (dispatcher *MleEventDispatcher) UninstallGroupCB(group int16, id mle_core.IMleCallbackId) bool {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
+	groupNode := dispatcher.findEventGroup(group)
+	if groupNode == nil {
+		return false
+	}
+	index := dispatcher.findCBNode(groupNode.m_callbacks, id.(*_EventCBNode))
+	if index == -1 {
+		return false
+	}
+	groupNode.m_callbacks.DestroyItem(index)
+	return true
+}
+
 /**
  * Enable the specified callback associated with the given
  * <b>event</b>.
@@ -469,6 +650,9 @@ func (dispatcher *MleEventDispatcher) UninstallEventCB(event int, id mle_core.IM
  * Otherwise, <b>false</b> will be returned.
  */
 func (dispatcher *MleEventDispatcher) EnableEventCB(event int, id mle_core.IMleCallbackId) bool {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
 	var node *_EventNode
 	var cbNode *_EventCBNode
  
@@ -482,7 +666,7 @@ func (dispatcher *MleEventDispatcher) EnableEventCB(event int, id mle_core.IMleC
 		if cbNode == nil {
 			return false
 		} else {
-			cbNode.m_isEnabled = true
+			cbNode.m_isEnabled.Store(true)
 		}
 	}
  
@@ -500,6 +684,9 @@ func (dispatcher *MleEventDispatcher) EnableEventCB(event int, id mle_core.IMleC
      * Otherwise, <b>false</b> will be returned.
      */
 func (dispatcher *MleEventDispatcher) DisableEventCB(event int, id mle_core.IMleCallbackId) bool {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
 	var node *_EventNode
 	var cbNode *_EventCBNode
  
@@ -513,7 +700,7 @@ func (dispatcher *MleEventDispatcher) DisableEventCB(event int, id mle_core.IMle
 		if cbNode == nil {
 			return false
 		} else {
-			cbNode.m_isEnabled = false
+			cbNode.m_isEnabled.Store(false)
 		}
 	}
  
@@ -533,6 +720,9 @@ func (dispatcher *MleEventDispatcher) DisableEventCB(event int, id mle_core.IMle
  * can not be removed from the priority queue.
  */
  func (dispatcher *MleEventDispatcher) UninstallEvent(event int) (bool, *mle_core.MleError) {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
     var node *_EventNode
 
 	// Check if event already exists.
@@ -566,6 +756,9 @@ func (dispatcher *MleEventDispatcher) DisableEventCB(event int, id mle_core.IMle
  * Otherwise, <b>false</b> will be returned.
  */
 func (dispatcher *MleEventDispatcher) EnableEvent(event int) bool {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
 	var node *_EventNode
  
 	// Check if event already exists.
@@ -588,6 +781,9 @@ func (dispatcher *MleEventDispatcher) EnableEvent(event int) bool {
  * Otherwise, <b>false</b> will be returned.
  */
 func (dispatcher *MleEventDispatcher) DisableEvent(event int) bool {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
 	var node *_EventNode
  
 	// Check if event already exists.
@@ -613,6 +809,9 @@ func (dispatcher *MleEventDispatcher) DisableEvent(event int) bool {
  * returned.
  */
 func (dispatcher *MleEventDispatcher) ChangeCBPriority(event int, id mle_core.IMleCallbackId, key int) bool {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
 	var node *_EventNode
 	var result bool
  
@@ -647,11 +846,16 @@ func (dispatcher *MleEventDispatcher) ChangeCBPriority(event int, id mle_core.IM
  */
 func (dispatcher *MleEventDispatcher) ChangeEventPriority(event int, key int) bool {
 	var result = false
-		 
-	for i := 0; i < dispatcher.m_eventQueue.GetNumElements(); i++ {
-		element := dispatcher.m_eventQueue.GetElementAt(i)
-		if event == element.Data.(MleEvent).m_id {
-			result = dispatcher.m_eventQueue.ChangeItem(event, key)
+
+	dispatcher.m_queueLock.Lock()
+	defer dispatcher.m_queueLock.Unlock()
+	dispatcher.mergePendingEvents()
+
+	for i, queueElement := range dispatcher.m_eventQueue {
+		if event == queueElement.m_event.GetId() {
+			queueElement.m_priority = key
+			heap.Fix(&dispatcher.m_eventQueue, i)
+			result = true
 			break;
 		}
 	}
@@ -659,12 +863,50 @@ func (dispatcher *MleEventDispatcher) ChangeEventPriority(event int, key int) bo
 	return result
 }
 
+// Snapshot the event and group callbacks and the listeners for the
+// specified event. false is returned if the event is disabled, or is not
+// installed and has no group callbacks.
+func (dispatcher *MleEventDispatcher) prepareDispatch(id int) (*mle_util.MlePQ, []IMleEventListener, bool) {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+
+	var node *_EventNode
+	var elements []mle_util.MlePQElement
+	group := dispatcher.findEventGroup(GetGroupId(id))
+	if group != nil {
+		node = group.findEventNode(id)
+		elements = group.m_callbacks.CopyQueue()
+	}
+	if node != nil {
+		if ! node.m_isEnabled {
+			return nil, nil, false
+		}
+		elements = append(elements, node.m_callbacks.CopyQueue()...)
+	} else if len(elements) == 0 {
+		return nil, nil, false
+	}
+
+	// Copy the queues into one we can process.
+	processQ := mle_util.NewMlePQWithElements(elements)
+	listeners := make([]IMleEventListener, len(*dispatcher.m_eventListeners))
+	for i := 0; i < len(listeners); i++ {
+		listeners[i] = dispatcher.m_eventListeners.ElementAt(i).(IMleEventListener)
+	}
+	return processQ, listeners, true
+}
+
 /**
- * Dispatch all events that have been placed in the delayed queue.
+ * Dispatch all events that have been placed in the delayed queue,
+ * including the events of timers that have fallen due.
  */
  func (dispatcher *MleEventDispatcher) DispatchEvents() {
-    // Retrieve the size of the queue.
-    size := dispatcher.m_eventQueue.GetNumElements();
+    // Queue the events of due timers, then retrieve the size of the queue.
+    // Events pushed while dispatching are left for the next call.
+    dispatcher.fireTimers()
+    dispatcher.m_queueLock.Lock()
+    dispatcher.mergePendingEvents()
+    size := len(dispatcher.m_eventQueue)
+    dispatcher.m_queueLock.Unlock()
         
     // Dispatch all events currently on the queue.
     for i := 0; i < size; i++ {
@@ -672,19 +914,26 @@ func (dispatcher *MleEventDispatcher) ChangeEventPriority(event int, key int) bo
 		element = dispatcher.PopEvent()
         if element != nil {
             event := element._GetEvent()
+
+            // Pass the event down the middleware chain; delayed events
+            // are left for the next call.
+            action := dispatcher.applyMiddleware(event)
+            if action == MLE_MIDDLEWARE_DELAY {
+                dispatcher.enqueueEvent(element)
+                continue
+            } else if action == MLE_MIDDLEWARE_DROP {
+                continue
+            }
                 
             // Find the event node is our registry.
-            node := dispatcher.findEventNode(event.GetId())
-            if (node != nil) && (node.m_isEnabled) {
+            processQ, listeners, ok := dispatcher.prepareDispatch(event.GetId())
+            if ok {
                 // Execute each callback that has been installed for this event
                 // a priori.
-                    
-                // Copy the queue into one we can process.
-                processQ := mle_util.NewMlePQWithElements(node.m_callbacks.CopyQueue())
                 for ! processQ.IsEmpty() {
                     item := processQ.Remove()
                     cbNode := item.Data.(*_EventCBNode)
-                    if (cbNode != nil) && (cbNode.m_isEnabled) {
+                    if (cbNode != nil) && (cbNode.IsEnabled()) {
 						// Invoke callback.
 						cb := cbNode.m_callback
                         (*cb).Dispatch(*event, cbNode.m_clientData)
@@ -692,8 +941,7 @@ func (dispatcher *MleEventDispatcher) ChangeEventPriority(event int, key int) bo
                 }
     
                 // Notify listeners.
-                for j := 0; j < len(*dispatcher.m_eventListeners); j++ {
-					listener := dispatcher.m_eventListeners.ElementAt(j).(IMleEventListener)
+                for _, listener := range listeners {
 					listener.EventDispatched(event)
 				}
             }
@@ -701,6 +949,32 @@ func (dispatcher *MleEventDispatcher) ChangeEventPriority(event int, key int) bo
     }
 }
 
+// Dispatch an event to its callbacks and notify the listeners that it was
+// processed. The result of the last callback is returned.
+func (dispatcher *MleEventDispatcher) dispatchImmediate(event *MleEvent) bool {
+	var status = false
+
+	processQ, listeners, ok := dispatcher.prepareDispatch(event.GetId())
+	if ok {
+		// Execute each callback that has been installed for this event
+		// a priori.
+		for ! processQ.IsEmpty() {
+			var item *mle_util.MlePQElement = processQ.Remove()
+			var cbNode = item.Data.(*_EventCBNode)
+			if (cbNode != nil) && (cbNode.IsEnabled()) {
+				cb := cbNode.m_callback
+				status = (*cb).Dispatch(*event, cbNode.m_clientData)
+			}
+		}
+
+		// Notify listeners.
+		for _, listener := range listeners {
+			listener.EventProcessed(event)
+		}
+	}
+	return status
+}
+
 /**
  * Process the event specified by the event id.
  * <p>
@@ -711,7 +985,10 @@ func (dispatcher *MleEventDispatcher) ChangeEventPriority(event int, key int) bo
  * called).
  * </p><p>
  * The event will be dispatched with the specified priority if the event
- * type is MLE_EVENT_DELAYED.
+ * type is MLE_EVENT_DELAYED, or if the middleware delays it.
+ * </p><p>
+ * Immediate events pass down the middleware chain here (see
+ * AddMiddleware); delayed events pass down it when they are dispatched.
  * </p>
  *
  * @param id The composite event identifier.
@@ -726,6 +1003,10 @@ func (dispatcher *MleEventDispatcher) ChangeEventPriority(event int, key int) bo
  */
 func (dispatcher *MleEventDispatcher) ProcessEventWithPriority(id int, calldata mle_util.IObject, evType int16, priority int) bool {
 	var status = false
+	if recorder := dispatcher.m_recorder.Load(); recorder != nil {
+		recorder.record(id, evType, priority, calldata)
+	}
+
 	//Todo: Figure out how to make the dispatcher the source of the event.
 	var source mle_util.Object = dispatcher
 	//var source *mle_util.Object
@@ -733,28 +1014,14 @@ func (dispatcher *MleEventDispatcher) ProcessEventWithPriority(id int, calldata
         
     if event != nil {
         if (evType == MLE_EVENT_IMMEDIATE) {
-            // Dispatch event immediately.
-            node := dispatcher.findEventNode(id)
-            if (node != nil) && (node.m_isEnabled) {
-                // Execute each callback that has been installed for this event
-                // a priori.
-
-                // Copy the queue into one we can process.
-                processQ := mle_util.NewMlePQWithElements(node.m_callbacks.CopyQueue())
-                for ! processQ.IsEmpty() {
-                    var item *mle_util.MlePQElement = processQ.Remove()
-                    var cbNode = item.Data.(*_EventCBNode)
-                    if (cbNode != nil) && (cbNode.IsEnabled()) {
-                        cb := cbNode.m_callback
-                        status = (*cb).Dispatch(*event, cbNode.m_clientData)
-                    }
-                }
-                    
-                // Notify listeners.
-                for i := 0; i < len(*dispatcher.m_eventListeners); i++ {
-					listener := dispatcher.m_eventListeners.ElementAt(i).(IMleEventListener)
-					listener.EventProcessed(event)
-				}
+            // Dispatch event immediately, unless the middleware drops or
+            // delays it.
+            switch dispatcher.applyMiddleware(event) {
+            case MLE_MIDDLEWARE_CONTINUE:
+                status = dispatcher.dispatchImmediate(event)
+            case MLE_MIDDLEWARE_DELAY:
+                event.SetType(MLE_EVENT_DELAYED)
+                status = dispatcher.PushEvent(event, calldata, priority)
             }
         } else if (evType == MLE_EVENT_DELAYED) {
             /* Push event onto delayed queue. */
@@ -792,7 +1059,9 @@ func (dispatcher *MleEventDispatcher) ProcessEventWithPriority(id int, calldata
 	 }
  
 /*
- * Push the event onto the queue for delayed dispatching.
+ * Push the event onto the queue for delayed dispatching. An event with a
+ * coalescing policy is coalesced into the pending event with the same id,
+ * if there is one (see SetCoalescePolicy).
  *
  * @param event The event to be pushed onto the queue.
  * @param calldata The call data to be associated with the event.
@@ -804,10 +1073,43 @@ func (dispatcher *MleEventDispatcher) ProcessEventWithPriority(id int, calldata
  */
 func (dispatcher *MleEventDispatcher) PushEvent(event *MleEvent, calldata mle_util.Object, priority int) bool {
 	var queueElement = _NewEventQueueElement(event)
-	var element = mle_util.NewMlePQElementWithKey(priority, queueElement)
-	dispatcher.m_eventQueue.Insert(element)
+	queueElement.m_priority = priority
+	if dispatcher.m_coalescing.coalesce(queueElement) {
+		return true
+	}
+	dispatcher.enqueueEvent(queueElement)
 	return true
 }
+
+// Append a queue element to one of the shards of delayed events. The
+// element is stamped with the order it was queued in, so events of equal
+// priority are dispatched in that order whichever shards they are on.
+func (dispatcher *MleEventDispatcher) enqueueEvent(queueElement *_EventQueueElement) {
+	queueElement.m_sequence = dispatcher.m_sequence.Add(1)
+
+	// Spread producers across the shards so they rarely share a lock.
+	n := dispatcher.m_nextShard.Add(1)
+	shard := &dispatcher.m_shards[int(n % uint32(len(dispatcher.m_shards)))]
+	shard.lock.Lock()
+	shard.m_pending = append(shard.m_pending, queueElement)
+	shard.lock.Unlock()
+}
+
+// Move the pushed events into the priority queue. The queue lock must be
+// held.
+func (dispatcher *MleEventDispatcher) mergePendingEvents() {
+	for i := range dispatcher.m_shards {
+		shard := &dispatcher.m_shards[i]
+		shard.lock.Lock()
+		pending := shard.m_pending
+		shard.m_pending = nil
+		shard.lock.Unlock()
+
+		for _, element := range pending {
+			heap.Push(&dispatcher.m_eventQueue, element)
+		}
+	}
+}
 	 
 /*
  * Pop the event from the queue.
@@ -818,12 +1120,16 @@ func (dispatcher *MleEventDispatcher) PushEvent(event *MleEvent, calldata mle_ut
  */
 func (dispatcher *MleEventDispatcher) PopEvent() *_EventQueueElement {
 	var element *_EventQueueElement = nil
+
+	dispatcher.m_queueLock.Lock()
+	defer dispatcher.m_queueLock.Unlock()
+	dispatcher.mergePendingEvents()
 		 
 	// Check if the queue is empty.
-	if ! dispatcher.m_eventQueue.IsEmpty() {
+	if len(dispatcher.m_eventQueue) > 0 {
 		/* There is at least one element in the queue. */
-		queueElement := dispatcher.m_eventQueue.Remove()
-		element = queueElement.Data.(*_EventQueueElement)
+		element = heap.Pop(&dispatcher.m_eventQueue).(*_EventQueueElement)
+		dispatcher.m_coalescing.release(element)
 	}
 		 
 	return element
@@ -834,7 +1140,30 @@ func (dispatcher *MleEventDispatcher) PopEvent() *_EventQueueElement {
  * ignored.
  */
 func (dispatcher *MleEventDispatcher) Flush() {
-	dispatcher.m_eventQueue.Clear()
+	dispatcher.m_queueLock.Lock()
+	defer dispatcher.m_queueLock.Unlock()
+	dispatcher.mergePendingEvents()
+	dispatcher.m_eventQueue = nil
+	dispatcher.m_coalescing.clear()
+}
+
+/**
+ * Set the recorder of the events passed to
+ * <code>ProcessEventWithPriority</code>.
+ * 
+ * @param recorder The event recorder, or <b>nil</b> to stop recording.
+ */
+func (dispatcher *MleEventDispatcher) SetRecorder(recorder *MleEventRecorder) {
+	dispatcher.m_recorder.Store(recorder)
+}
+
+/**
+ * Get the recorder of processed events.
+ * 
+ * @return The event recorder is returned, or <b>nil</b> if there is none.
+ */
+func (dispatcher *MleEventDispatcher) GetRecorder() *MleEventRecorder {
+	return dispatcher.m_recorder.Load()
 }
 
 /**
@@ -848,6 +1177,8 @@ func (dispatcher *MleEventDispatcher) AddListener(listener IMleEventListener) {
 	if listener == nil {
 		return
 	}
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
 	dispatcher.m_eventListeners.AddElement(listener)
 }
 	 
@@ -860,7 +1191,14 @@ func (dispatcher *MleEventDispatcher) RemoveListener(listener IMleEventListener)
 	if listener == nil {
 		return
 	}
-	dispatcher.m_eventListeners.RemoveElement(listener);
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+	for i := 0; i < len(*dispatcher.m_eventListeners); i++ {
+		if dispatcher.m_eventListeners.ElementAt(i) == listener {
+			dispatcher.m_eventListeners.Delete(i)
+			break
+		}
+	}
 }
 
 // ToString implements IObject interface.
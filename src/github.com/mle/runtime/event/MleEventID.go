@@ -0,0 +1,164 @@
+/**
+ * @file MleEventID.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import go packages.
+import (
+	"fmt"
+)
+
+/** The largest group of a valid event identifier. */
+const MLE_MAX_GROUP int16 = 0x7FFF
+/** The largest event id within a group. */
+const MLE_MAX_EVENT_ID uint16 = 0xFFFF
+
+/**
+ * The invalid event identifier, the typed form of MLE_EVENT_INVALID_ID
+ * (see ToEventID and Int).
+ */
+const MLE_INVALID_EVENT_ID EventID = 0xFFFFFFFF
+
+/**
+ * <code>EventID</code> is a composite event identifier: the group in the
+ * high 16 bits and the event id within the group in the low 16 bits.
+ * <p>
+ * Groups range from 0 to <code>MLE_MAX_GROUP</code>, and event ids over
+ * the full 16 bits, from 0 to <code>MLE_MAX_EVENT_ID</code>. The
+ * <code>int</code> identifiers used throughout the runtime hold the same
+ * value (see <code>Int</code> and <code>ToEventID</code>). Note that
+ * <code>GetEventId</code> returns the event id as an <code>int16</code>,
+ * so ids above 0x7FFF are negative there; they still round trip through
+ * <code>MakeId</code>.
+ * </p>
+ */
+type EventID uint32
+
+/**
+ * Make a composite event identifier.
+ *
+ * @param group The group that the event belongs to.
+ * @param event The event id within the group.
+ *
+ * @return The composite event identifier is returned, or
+ * <code>MLE_INVALID_EVENT_ID</code> if the group is out of range.
+ */
+func MakeEventID(group int16, event uint16) EventID {
+	if group < 0 {
+		return MLE_INVALID_EVENT_ID
+	}
+	return EventID(uint32(group) << 16 | uint32(event))
+}
+
+/**
+ * Convert an <code>int</code> composite identifier, as made by
+ * <code>MakeId</code>, to an <code>EventID</code>.
+ *
+ * @param cid The composite event identifier.
+ *
+ * @return The event identifier is returned, or
+ * <code>MLE_INVALID_EVENT_ID</code> if the identifier is out of range.
+ */
+func ToEventID(cid int) EventID {
+	if ! IsValidEventId(cid) {
+		return MLE_INVALID_EVENT_ID
+	}
+	return EventID(cid)
+}
+
+/**
+ * Determine whether an <code>int</code> composite identifier has a group
+ * and event id in range.
+ *
+ * @param cid The composite event identifier.
+ *
+ * @return <b>true</b> is returned if the identifier is valid.
+ */
+func IsValidEventId(cid int) bool {
+	return (cid >= 0) && (cid <= int(MLE_MAX_GROUP) << 16 | int(MLE_MAX_EVENT_ID))
+}
+
+/**
+ * Get the group of the event.
+ *
+ * @return The group is returned.
+ */
+func (id EventID) GetGroup() int16 {
+	return int16(id >> 16)
+}
+
+/**
+ * Get the event id within the group.
+ *
+ * @return The event id is returned.
+ */
+func (id EventID) GetEvent() uint16 {
+	return uint16(id)
+}
+
+/**
+ * Determine whether the identifier has a group in range.
+ *
+ * @return <b>true</b> is returned if the identifier is valid.
+ */
+func (id EventID) IsValid() bool {
+	return id.GetGroup() >= 0
+}
+
+/**
+ * Get the identifier as an <code>int</code>, for the runtime methods
+ * taking a composite event identifier.
+ *
+ * @return The composite event identifier is returned;
+ * <code>MLE_EVENT_INVALID_ID</code> for an invalid identifier.
+ */
+func (id EventID) Int() int {
+	if ! id.IsValid() {
+		return MLE_EVENT_INVALID_ID
+	}
+	return int(id)
+}
+
+// String implements the IObject interface.
+func (id EventID) String() string {
+	if ! id.IsValid() {
+		return "invalid"
+	}
+	return fmt.Sprintf("0x%04x:0x%04x", id.GetGroup(), id.GetEvent())
+}
@@ -41,6 +41,10 @@ package event
 
 // Import go packages.
 import (
+	"fmt"
+	"reflect"
+	"sync"
+
 	"github.com/timtadh/data-structures/types"
 	"github.com/timtadh/data-structures/tree/avl"
 	mle_util "github.com/mle/runtime/util"
@@ -94,6 +98,8 @@ type EventSetItem struct {
     m_id types.Int
     /** The named event. May be <b>nil</b>. */
     m_name string
+    /** The payload type of a typed event. May be <b>nil</b>. */
+    m_payloadType reflect.Type
 }
 
 // NewEventSetItem is a constructor that initializes the event id and name.
@@ -177,10 +183,7 @@ func (es *EventSet) Remove(item *EventSetItem) {
 
 // Clear will delete the entire EventSet collection.
 func (es *EventSet) Clear() {
-	for k, next := es.m_tree.Keys()(); next != nil; k, next = next() {
-		key := k
-		es.m_tree.Remove(key)
-	}
+	es.m_tree = avl.NewAvlTree()
 }
 
 // Contains will determine if the EventSet collection has the specified item.
@@ -238,14 +241,10 @@ func (es *EventSet) FindByName(name string) *EventSetItem {
 func (es *EventSet) Find(id int) *EventSetItem {
 	var found *EventSetItem = nil
 
-	// Iterate through the tree to find a matching EventSetItem.
-    for k, v, next := es.m_tree.Iterate()(); next != nil; k, v, next = next() {
-		_ = k
-		var item = v.(*EventSetItem)
-		if item.m_id == types.Int(id) {
-			found = item
-			break
-		}
+	// Look the item up by key, rather than iterating, for titles with
+	// many events.
+	if v, err := es.m_tree.Get(types.Int(id)); (err == nil) && (v != nil) {
+		found = v.(*EventSetItem)
 	}
 
 	return found
@@ -262,7 +261,7 @@ func (es *EventSet) Find(id int) *EventSetItem {
  */
 func (es *EventSet) Last(group int16) *EventSetItem {
 	var found *EventSetItem = nil
-	var lastEid int16 = 0
+	var lastEid uint16 = 0
 
 	// Iterate through the tree to find a matching EventSetItem.
 	for k, v, next := es.m_tree.Iterate()(); next != nil; k, v, next = next() {
@@ -270,7 +269,7 @@ func (es *EventSet) Last(group int16) *EventSetItem {
 		var item = v.(*EventSetItem)
 		gid := GetGroupId(int(item.m_id))
 		if (gid == group) {
-			eid := GetEventId(int(item.m_id))
+			eid := ToEventID(int(item.m_id)).GetEvent()
 			if (eid >= lastEid) {
 				lastEid = eid
 				found = item
@@ -312,6 +311,10 @@ func (es *EventSet) First(group int16) *EventSetItem {
 type MleEventManager struct {
     // The event registry.
     m_eventRegistry *EventSet
+    // The names of the registered groups.
+    m_groups map[int16]string
+    // Internal lock used for protecting the registry.
+    lock sync.Mutex
 }
 
 // NewMleEventManager is the default constructor that returns a singleton
@@ -320,6 +323,7 @@ func NewMleEventManager() *MleEventManager {
 	if GTheEventManager == nil {
 		p := new(MleEventManager)
 		p.m_eventRegistry = NewEventSet()
+		p.m_groups = map[int16]string{MLE_SYSTEM_GROUP: MLE_SYSTEM_GROUP_NAME}
 		// Add default events.
 		p.AddEvent(MLE_PAINT, "")
 		p.AddEvent(MLE_SIZE, "")
@@ -339,9 +343,21 @@ func NewMleEventManager() *MleEventManager {
  * @param name The named event; must be unique or <b>null</b>.
  * 
  * @throws MleRuntimeException This exception is thrown if an event
- * already exists for the specified <i>name</i>.
+ * already exists for the specified <i>name</i>, or if the group or event
+ * id is out of range (see IsValidEventId).
  */
 func (evm *MleEventManager) AddEvent(id int, name string)  *mle_core.MleError {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+	return evm.addEvent(id, name)
+}
+
+// Register an event; the lock must be held.
+func (evm *MleEventManager) addEvent(id int, name string)  *mle_core.MleError {
+	if ! IsValidEventId(id) {
+		msg := fmt.Sprintf("MleEventManager: event 0x%x is out of range.", id)
+		return mle_core.NewMleError(msg, 0, nil)
+	}
 	item := NewEventSetItem(id, name)
  
 	if (evm.m_eventRegistry.Contains(item)) {
@@ -368,6 +384,8 @@ func (evm *MleEventManager) AddEvent(id int, name string)  *mle_core.MleError {
  * returned.
  */
 func (evm *MleEventManager) HasEventByName(name string) bool {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
 	if evm.m_eventRegistry.FindByName(name) != nil {
 		return true
 	}
@@ -384,6 +402,8 @@ func (evm *MleEventManager) HasEventByName(name string) bool {
  * returned.
  */
 func (evm *MleEventManager) HasEvent(id int) bool {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
 	item := NewEventSetItem(id, "");
 	if (evm.m_eventRegistry.Contains(item)) {
 		return true
@@ -402,6 +422,8 @@ func (evm *MleEventManager) HasEvent(id int) bool {
  * returned.
  */
  func (evm *MleEventManager) GetEventId(name string) int  {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
 	item := evm.m_eventRegistry.FindByName(name)
 	if item != nil {
 		return int(item.m_id)
@@ -421,6 +443,8 @@ func (evm *MleEventManager) HasEvent(id int) bool {
  * returned.
  */
 func (evm *MleEventManager) GetEventName(id int) string {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
 	item:= evm.m_eventRegistry.Find(id)
 	if item != nil {
 				 return item.m_name
@@ -428,6 +452,50 @@ func (evm *MleEventManager) GetEventName(id int) string {
 	return ""
 }
 
+// Register an event with the payload type of an EventType. An event
+// registered without a payload type adopts it; an event already
+// registered with a different name or payload type is an error.
+func (evm *MleEventManager) addEventType(id int, name string, payloadType reflect.Type) *mle_core.MleError {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+	if err := evm.addEvent(id, name); err != nil {
+		return err
+	}
+
+	item := evm.m_eventRegistry.Find(id)
+	if item.m_name != name {
+		msg := fmt.Sprintf("MleEventManager: event 0x%08x is registered with name %q, not %q.", id, item.m_name, name)
+		return mle_core.NewMleError(msg, 0, nil)
+	}
+	if (item.m_payloadType != nil) && (item.m_payloadType != payloadType) {
+		msg := fmt.Sprintf("MleEventManager: event 0x%08x is registered with payload type %v, not %v.",
+			id, item.m_payloadType, payloadType)
+		return mle_core.NewMleError(msg, 0, nil)
+	}
+	item.m_payloadType = payloadType
+	return nil
+}
+
+/**
+ * Get the payload type of the specified event.
+ * 
+ * @param id The event identifier.
+ * 
+ * @return The payload type the event was registered with by
+ * <code>RegisterEventType</code> or <code>CreateEventType</code> is
+ * returned. <b>nil</b> is returned if the event is not registered or is
+ * not typed.
+ */
+func (evm *MleEventManager) GetEventPayloadType(id int) reflect.Type {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+	item := evm.m_eventRegistry.Find(id)
+	if item != nil {
+		return item.m_payloadType
+	}
+	return nil
+}
+
 /**
  * Unregister an event from the event manager.
  * 
@@ -435,6 +503,8 @@ func (evm *MleEventManager) GetEventName(id int) string {
  * contain the specified event.
  */
  func (evm *MleEventManager) RemoveEvent(id int) {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
 	item := NewEventSetItem(id, "")
  
 	if evm.m_eventRegistry.Contains(item) {
@@ -444,10 +514,14 @@ func (evm *MleEventManager) GetEventName(id int) string {
 }
 	 
 /**
- * Clear the event manager registry of all events.
+ * Clear the event manager registry of all events, and of all named groups
+ * but the system group.
  */
 func (evm *MleEventManager) Clear() {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
 	evm.m_eventRegistry.Clear()
+	evm.m_groups = map[int16]string{MLE_SYSTEM_GROUP: MLE_SYSTEM_GROUP_NAME}
 }
 
 /**
@@ -456,6 +530,8 @@ func (evm *MleEventManager) Clear() {
  * @return The number of registered events is returned.
  */
 func (evm *MleEventManager) Size() int {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
 	return evm.m_eventRegistry.Size()
 }
 	 
@@ -485,24 +561,16 @@ func SetExitStatus(status bool) {
  * 
  * @param group The group identifier to create an event for.
  * 
- * @return A new event identifier will be returned. Note that the returned event
- * has not yet been added to the event manager (see addEvent(int)).
+ * @return A new event identifier will be returned, or MLE_EVENT_INVALID_ID
+ * if the group is full or out of range. Note that the returned event has not yet been added
+ * to the event manager (see AddEvent), so another caller may be given the
+ * same identifier; AllocateEvent creates and adds an event at once.
  * 
  * @see MleEvent.makeId(short, short)
  */
 func CreateEvent(group int16) int {
-	var id int
- 
-	lastEvent := NewMleEventManager().m_eventRegistry.Last(group)
-	if lastEvent == nil {
-		// First event in the group.
-		id = MakeId(group, 0)
-	} else {
-		// Create the event identifier following the last one registered.
-		eid := GetEventId(int(lastEvent.m_id))
-		eid++;
-		id = MakeId(group, eid)
-	}
-		 
-	return id
+	manager := NewMleEventManager()
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+	return manager.nextEventId(group)
 }
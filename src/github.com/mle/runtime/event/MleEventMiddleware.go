@@ -0,0 +1,177 @@
+/**
+ * @file MleEventMiddleware.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+/** The event continues down the middleware chain, then to its callbacks. */
+const MLE_MIDDLEWARE_CONTINUE int = 0
+/** The event is dropped; its callbacks and listeners are not invoked. */
+const MLE_MIDDLEWARE_DROP int = 1
+/**
+ * The event is delayed: it is queued, and passes through the whole chain
+ * again when the next <code>DispatchEvents</code> pass dispatches it.
+ */
+const MLE_MIDDLEWARE_DELAY int = 2
+
+/**
+ * <code>IMleEventMiddleware</code> is a handler that sees every event
+ * processed by a <code>MleEventDispatcher</code> before its callbacks are
+ * invoked, for logging, permission checks, throttling or transformation.
+ * <p>
+ * Middleware is invoked in the order it was added, for immediate events
+ * when they are processed and for delayed events when they are
+ * dispatched. It is invoked without any dispatcher lock held.
+ * </p>
+ */
+type IMleEventMiddleware interface {
+	/**
+	 * Handle an event before it is dispatched to its callbacks. The
+	 * middleware may modify the event, for instance its call data.
+	 *
+	 * @param event The event.
+	 *
+	 * @return <code>MLE_MIDDLEWARE_CONTINUE</code>,
+	 * <code>MLE_MIDDLEWARE_DROP</code> or <code>MLE_MIDDLEWARE_DELAY</code>
+	 * is returned. Any other value drops the event.
+	 */
+	HandleEvent(event *MleEvent) int
+}
+
+/**
+ * <code>MleEventMiddlewareFunc</code> adapts a function to the
+ * <code>IMleEventMiddleware</code> interface.
+ */
+type MleEventMiddlewareFunc func(event *MleEvent) int
+
+// HandleEvent implements the IMleEventMiddleware interface.
+func (f MleEventMiddlewareFunc) HandleEvent(event *MleEvent) int {
+	return f(event)
+}
+
+/**
+ * <code>MleEventMiddlewareId</code> identifies middleware added to a
+ * dispatcher.
+ */
+type MleEventMiddlewareId struct {
+	// The middleware.
+	m_middleware IMleEventMiddleware
+}
+
+/**
+ * Get the middleware associated with this identifier.
+ *
+ * @return The middleware is returned.
+ */
+func (id *MleEventMiddlewareId) GetMiddleware() IMleEventMiddleware {
+	return id.m_middleware
+}
+
+/**
+ * Add middleware to the end of the chain.
+ *
+ * @param middleware The middleware to add.
+ *
+ * @return An identifier for removing the middleware is returned, or
+ * <b>nil</b> if the middleware is <b>nil</b>.
+ */
+func (dispatcher *MleEventDispatcher) AddMiddleware(middleware IMleEventMiddleware) *MleEventMiddlewareId {
+	if middleware == nil {
+		return nil
+	}
+	id := &MleEventMiddlewareId{middleware}
+
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+	// The chain is copied, so events being handled keep the chain they
+	// started with.
+	chain := make([]*MleEventMiddlewareId, len(dispatcher.m_middleware), len(dispatcher.m_middleware) + 1)
+	copy(chain, dispatcher.m_middleware)
+	dispatcher.m_middleware = append(chain, id)
+	return id
+}
+
+/**
+ * Remove middleware from the chain.
+ *
+ * @param id The identifier returned when the middleware was added.
+ *
+ * @return <b>true</b> is returned if the middleware is removed.
+ */
+func (dispatcher *MleEventDispatcher) RemoveMiddleware(id *MleEventMiddlewareId) bool {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+	for i, next := range dispatcher.m_middleware {
+		if next == id {
+			chain := make([]*MleEventMiddlewareId, 0, len(dispatcher.m_middleware) - 1)
+			chain = append(chain, dispatcher.m_middleware[:i]...)
+			dispatcher.m_middleware = append(chain, dispatcher.m_middleware[i + 1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Get the number of middleware in the chain.
+ *
+ * @return The number of middleware is returned.
+ */
+func (dispatcher *MleEventDispatcher) GetNumMiddleware() int {
+	dispatcher.lock.Lock()
+	defer dispatcher.lock.Unlock()
+	return len(dispatcher.m_middleware)
+}
+
+// Pass an event down the middleware chain, returning the action of the
+// first middleware that does not continue it.
+func (dispatcher *MleEventDispatcher) applyMiddleware(event *MleEvent) int {
+	dispatcher.lock.Lock()
+	chain := dispatcher.m_middleware
+	dispatcher.lock.Unlock()
+
+	for _, id := range chain {
+		action := id.m_middleware.HandleEvent(event)
+		if action == MLE_MIDDLEWARE_DELAY {
+			return action
+		} else if action != MLE_MIDDLEWARE_CONTINUE {
+			return MLE_MIDDLEWARE_DROP
+		}
+	}
+	return MLE_MIDDLEWARE_CONTINUE
+}
@@ -0,0 +1,329 @@
+/**
+ * @file MleEventPlayer.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import go packages.
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	mle_util "github.com/mle/runtime/util"
+	mle_core "github.com/mle/runtime/core"
+)
+
+/**
+ * <code>MleEventRecord</code> is an event loaded from a recording.
+ */
+type MleEventRecord struct {
+	// The order the event was processed in.
+	m_sequence int64
+	// The frame the event was processed in.
+	m_frame int64
+	// The composite event identifier.
+	m_id int
+	// The dispatch type.
+	m_type int16
+	// The dispatch priority.
+	m_priority int
+	// The decoded call data, may be nil.
+	m_calldata mle_util.IObject
+}
+
+/** Get the sequence number giving the order the event was processed in. */
+func (record *MleEventRecord) GetSequence() int64 {
+	return record.m_sequence
+}
+
+/** Get the frame the event was processed in. */
+func (record *MleEventRecord) GetFrame() int64 {
+	return record.m_frame
+}
+
+/** Get the composite event identifier. */
+func (record *MleEventRecord) GetId() int {
+	return record.m_id
+}
+
+/** Get the dispatch type, MLE_EVENT_IMMEDIATE or MLE_EVENT_DELAYED. */
+func (record *MleEventRecord) GetType() int16 {
+	return record.m_type
+}
+
+/** Get the dispatch priority. */
+func (record *MleEventRecord) GetPriority() int {
+	return record.m_priority
+}
+
+/** Get the decoded call data, which may be <b>nil</b>. */
+func (record *MleEventRecord) GetCallData() mle_util.IObject {
+	return record.m_calldata
+}
+
+/**
+ * <code>MleEventRecording</code> is a recording loaded into memory, in the
+ * order the events were processed: by frame, then by sequence number.
+ */
+type MleEventRecording struct {
+	// The format version.
+	m_version int
+	// The codec name.
+	m_codec string
+	// The recorded events.
+	m_records []*MleEventRecord
+}
+
+/**
+ * Load a recording written by an <code>MleEventRecorder</code>.
+ *
+ * @param r The recording to read.
+ * @param codec The call data codec; its name must match the recording's.
+ *
+ * @return The recording is returned. An error is returned if the stream is
+ * not a recording, its version is not supported, the codec does not match
+ * or an event can not be decoded.
+ */
+func LoadMleEventRecording(r io.Reader, codec IMleEventCodec) (*MleEventRecording, *mle_core.MleError) {
+	if codec == nil {
+		return nil, mle_core.NewMleError("MleEventRecording: codec is nil.", 0, nil)
+	}
+
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	var header _RecordingHeader
+	if err := decoder.Decode(&header); err != nil || header.Format != MLE_EVENT_RECORDING_FORMAT {
+		return nil, mle_core.NewMleError("MleEventRecording: not an event recording.", 0, err)
+	}
+	if (header.Version < 1) || (header.Version > MLE_EVENT_RECORDING_VERSION) {
+		msg := fmt.Sprintf("MleEventRecording: unsupported version %d.", header.Version)
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+	if header.Codec != codec.GetName() {
+		msg := fmt.Sprintf("MleEventRecording: recorded with codec %q, not %q.", header.Codec, codec.GetName())
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+
+	p := new(MleEventRecording)
+	p.m_version = header.Version
+	p.m_codec = header.Codec
+	for {
+		var event _RecordedEvent
+		err := decoder.Decode(&event)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			msg := fmt.Sprintf("MleEventRecording: event %d is malformed.", len(p.m_records))
+			return nil, mle_core.NewMleError(msg, 0, err)
+		}
+
+		record := &MleEventRecord{event.Sequence, event.Frame, event.Id, event.Type, event.Priority, nil}
+		if event.HasData {
+			record.m_calldata, err = codec.Decode(event.Id, event.CallData)
+			if err != nil {
+				msg := fmt.Sprintf("MleEventRecording: unable to decode call data of event %d.", len(p.m_records))
+				return nil, mle_core.NewMleError(msg, 0, err)
+			}
+		}
+		p.m_records = append(p.m_records, record)
+	}
+
+	// Order the events as they were processed, whatever order the lines are in.
+	sort.SliceStable(p.m_records, func(i, j int) bool {
+		a, b := p.m_records[i], p.m_records[j]
+		if a.m_frame != b.m_frame {
+			return a.m_frame < b.m_frame
+		}
+		return a.m_sequence < b.m_sequence
+	})
+	return p, nil
+}
+
+/**
+ * Load a recording file written by an <code>MleEventRecorder</code>.
+ *
+ * @param path The path of the file.
+ * @param codec The call data codec.
+ */
+func LoadMleEventRecordingFile(path string, codec IMleEventCodec) (*MleEventRecording, *mle_core.MleError) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, mle_core.NewMleError("MleEventRecording: unable to open " + path + ".", 0, err)
+	}
+	defer file.Close()
+	return LoadMleEventRecording(file, codec)
+}
+
+/** Get the format version of the recording. */
+func (recording *MleEventRecording) GetVersion() int {
+	return recording.m_version
+}
+
+/** Get the name of the codec the recording was written with. */
+func (recording *MleEventRecording) GetCodecName() string {
+	return recording.m_codec
+}
+
+/** Get the recorded events, in the order they were processed. */
+func (recording *MleEventRecording) GetRecords() []*MleEventRecord {
+	return recording.m_records
+}
+
+// Find the index of the first event in or after the specified frame.
+func (recording *MleEventRecording) findFrame(frame int64) int {
+	return sort.Search(len(recording.m_records), func(i int) bool {
+		return recording.m_records[i].m_frame >= frame
+	})
+}
+
+/**
+ * <code>MleEventPlayer</code> replays a recording into a dispatcher, one
+ * frame at a time and in the order of the recorded sequence numbers,
+ * through <code>ProcessEventWithPriority</code>. Since the dispatcher
+ * dispatches delayed events of equal priority in the order they were
+ * queued, the replayed events are dispatched in the recorded order
+ * whatever events the dispatcher has handled before.
+ * <p>
+ * The frame loop replays its frame at the start of each frame (see
+ * <code>MleFrameLoop.SetEventPlayer</code>), so events recorded in a
+ * frame are processed, or queued if delayed, before the frame with the
+ * same number runs. The events fired by timers are recorded, so the timers
+ * must not be posted again while a recording is replayed.
+ * </p>
+ */
+type MleEventPlayer struct {
+	// The recording to replay.
+	m_recording *MleEventRecording
+	// The dispatcher the events are replayed into.
+	m_dispatcher *MleEventDispatcher
+	// The frame replayed next.
+	m_frame int64
+	// The index of the next event to replay.
+	m_next int
+	// Internal lock used for protecting sensitve code.
+	lock sync.Mutex
+}
+
+/**
+ * Construct a player positioned at frame 0.
+ *
+ * @param recording The recording to replay.
+ * @param dispatcher The dispatcher to replay the events into.
+ */
+func NewMleEventPlayer(recording *MleEventRecording, dispatcher *MleEventDispatcher) *MleEventPlayer {
+	p := new(MleEventPlayer)
+	p.m_recording = recording
+	p.m_dispatcher = dispatcher
+	return p
+}
+
+/**
+ * Position the player at the start of the specified frame. Events
+ * recorded in earlier frames are skipped.
+ *
+ * @param frame The recorded frame to replay next.
+ */
+func (player *MleEventPlayer) SeekFrame(frame int64) {
+	player.lock.Lock()
+	defer player.lock.Unlock()
+	player.m_frame = frame
+	player.m_next = player.m_recording.findFrame(frame)
+}
+
+/** Get the recorded frame replayed next. */
+func (player *MleEventPlayer) GetFrame() int64 {
+	player.lock.Lock()
+	defer player.lock.Unlock()
+	return player.m_frame
+}
+
+/** Determine whether every event has been replayed. */
+func (player *MleEventPlayer) IsDone() bool {
+	player.lock.Lock()
+	defer player.lock.Unlock()
+	return player.m_next >= len(player.m_recording.m_records)
+}
+
+/**
+ * Replay the events of the next frame and advance to the following frame.
+ *
+ * @return The number of events replayed is returned.
+ */
+func (player *MleEventPlayer) ReplayNextFrame() int {
+	player.lock.Lock()
+	frame := player.m_frame
+	player.lock.Unlock()
+	return player.ReplayFrame(frame)
+}
+
+/**
+ * Replay the events recorded in the specified frame, and advance to the
+ * following frame. Events of earlier frames that have not been replayed
+ * are skipped, as by <code>SeekFrame</code>.
+ *
+ * @param frame The frame to replay, normally the current frame of the
+ * frame loop.
+ *
+ * @return The number of events replayed is returned.
+ */
+func (player *MleEventPlayer) ReplayFrame(frame int64) int {
+	player.lock.Lock()
+	records := player.m_recording.m_records
+	start := player.m_next
+	for (start < len(records)) && (records[start].m_frame < frame) {
+		start++
+	}
+	end := start
+	for (end < len(records)) && (records[end].m_frame == frame) {
+		end++
+	}
+	player.m_next = end
+	player.m_frame = frame + 1
+	player.lock.Unlock()
+
+	// Replay without the lock, callbacks may use the player.
+	for _, record := range records[start:end] {
+		player.m_dispatcher.ProcessEventWithPriority(record.m_id, record.m_calldata, record.m_type, record.m_priority)
+	}
+	return end - start
+}
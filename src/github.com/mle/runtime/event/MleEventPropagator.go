@@ -0,0 +1,337 @@
+/**
+ * @file MleEventPropagator.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import go packages.
+import (
+	"fmt"
+	"sync"
+
+	mle_util "github.com/mle/runtime/util"
+	mle_core "github.com/mle/runtime/core"
+)
+
+// The callbacks installed on a node of the title hierarchy.
+type _PropagationNode struct {
+	// The callbacks dispatched while capturing, and at the target.
+	m_capture *MleEventDispatcher
+	// The callbacks dispatched at the target, and while bubbling.
+	m_bubble *MleEventDispatcher
+}
+
+/**
+ * <code>MleEventPropagator</code> propagates events through the title
+ * hierarchy, in the manner of DOM events.
+ * <p>
+ * An event targeted at a node is dispatched along the path from the scene
+ * to the target: <code>MleScene</code>, the <code>MleGroup</code> holding
+ * the actor, the <code>MleActor</code> and, for an event targeted at a
+ * role, the <code>MleRole</code>. Capture callbacks are dispatched from the
+ * scene down to the target's parent, then the target's capture and bubble
+ * callbacks, then bubble callbacks from the target's parent back up to the
+ * scene. A callback may stop the propagation with
+ * <code>MleEvent.StopPropagation</code> and veto the sender's default
+ * action with <code>MleEvent.PreventDefault</code>.
+ * </p><p>
+ * Callbacks are installed per node and event id, and are dispatched in
+ * priority order at each node like those of an
+ * <code>MleEventDispatcher</code>. Events are propagated synchronously on
+ * the calling goroutine; installing callbacks is safe from any goroutine.
+ * </p>
+ */
+type MleEventPropagator struct {
+	// The scene at the root of the paths, nil for the current scene.
+	m_scene *mle_core.MleScene
+	// The callbacks by node.
+	m_nodes map[interface{}]*_PropagationNode
+	// Internal lock used for protecting sensitve code.
+	lock sync.Mutex
+}
+
+/**
+ * Construct a propagator.
+ *
+ * @param scene The scene at the root of the paths, or <b>nil</b> to use
+ * the current scene (see <code>mle_core.GetCurrentScene</code>).
+ */
+func NewMleEventPropagator(scene *mle_core.MleScene) *MleEventPropagator {
+	p := new(MleEventPropagator)
+	p.m_scene = scene
+	p.m_nodes = make(map[interface{}]*_PropagationNode)
+	return p
+}
+
+/**
+ * Get the scene at the root of the paths.
+ *
+ * @return The scene is returned; it may be <b>nil</b>.
+ */
+func (propagator *MleEventPropagator) GetScene() *mle_core.MleScene {
+	if propagator.m_scene != nil {
+		return propagator.m_scene
+	}
+	return mle_core.GetCurrentScene()
+}
+
+// Determine whether a node can be on a propagation path.
+func isPropagationNode(node interface{}) bool {
+	switch node.(type) {
+	case *mle_core.MleScene, *mle_core.MleGroup, *mle_core.MleActor, *mle_core.MleRole:
+		return true
+	}
+	return false
+}
+
+/**
+ * Install a callback for the specified event on a node.
+ *
+ * @param node The <code>MleScene</code>, <code>MleGroup</code>,
+ * <code>MleActor</code> or <code>MleRole</code>.
+ * @param event The composite event identifier.
+ * @param callback The callback to install.
+ * @param clientData Client data associated with the dispatch
+ * of the callback.
+ * @param capture <b>true</b> to dispatch the callback while capturing,
+ * <b>false</b> to dispatch it while bubbling. Both are dispatched when
+ * the node is the target.
+ * @param key The callback priority.
+ *
+ * @return A callback identifier is returned, or an error if the node can
+ * not be on a propagation path.
+ */
+func (propagator *MleEventPropagator) InstallEventCB(node interface{}, event int, callback IMleEventCallback,
+	clientData mle_util.IObject, capture bool, key int) (mle_core.IMleCallbackId, *mle_core.MleError) {
+	if ! isPropagationNode(node) {
+		msg := fmt.Sprintf("MleEventPropagator: %T is not a scene, group, actor or role.", node)
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+
+	propagator.lock.Lock()
+	entry := propagator.m_nodes[node]
+	if entry == nil {
+		entry = &_PropagationNode{NewMleEventDispatcher(), NewMleEventDispatcher()}
+		propagator.m_nodes[node] = entry
+	}
+	propagator.lock.Unlock()
+
+	if capture {
+		return entry.m_capture.InstallEventCBWithPriority(event, callback, clientData, key)
+	}
+	return entry.m_bubble.InstallEventCBWithPriority(event, callback, clientData, key)
+}
+
+/**
+ * Uninstall a callback from a node.
+ *
+ * @param node The node the callback was installed on.
+ * @param event The composite event identifier.
+ * @param id The identifier for the callback to uninstall.
+ *
+ * @return <b>true</b> is returned if the callback is uninstalled.
+ */
+func (propagator *MleEventPropagator) UninstallEventCB(node interface{}, event int, id mle_core.IMleCallbackId) bool {
+	propagator.lock.Lock()
+	entry := propagator.m_nodes[node]
+	propagator.lock.Unlock()
+	if entry == nil {
+		return false
+	}
+	return entry.m_capture.UninstallEventCB(event, id) || entry.m_bubble.UninstallEventCB(event, id)
+}
+
+/**
+ * Uninstall every callback from a node, for instance when an actor is
+ * disposed.
+ *
+ * @param node The node.
+ */
+func (propagator *MleEventPropagator) RemoveNode(node interface{}) {
+	propagator.lock.Lock()
+	delete(propagator.m_nodes, node)
+	propagator.lock.Unlock()
+}
+
+// Find the group of the scene holding the actor.
+func findActorGroup(scene *mle_core.MleScene, actor *mle_core.MleActor) *mle_core.MleGroup {
+	for i := 0; i < scene.GetNumberOfGroups(); i++ {
+		group := scene.GetGroup(i)
+		for j := 0; j < group.GetNumberOfActors(); j++ {
+			if group.GetActor(j) == actor {
+				return group
+			}
+		}
+	}
+	return nil
+}
+
+// Determine whether the scene holds the group.
+func hasGroup(scene *mle_core.MleScene, group *mle_core.MleGroup) bool {
+	for i := 0; i < scene.GetNumberOfGroups(); i++ {
+		if scene.GetGroup(i) == group {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Get the propagation path of a target, from the root to the target.
+ * Nodes that are not held by the scene are left out: an actor in no group
+ * of the scene has a path of the actor alone.
+ *
+ * @param target The <code>MleScene</code>, <code>MleGroup</code>,
+ * <code>MleActor</code> or <code>MleRole</code> targeted.
+ *
+ * @return The path is returned, or an error if the target can not be on
+ * a propagation path.
+ */
+func (propagator *MleEventPropagator) GetPath(target interface{}) ([]interface{}, *mle_core.MleError) {
+	if ! isPropagationNode(target) {
+		msg := fmt.Sprintf("MleEventPropagator: %T is not a scene, group, actor or role.", target)
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+
+	scene := propagator.GetScene()
+	var path []interface{}
+	switch node := target.(type) {
+	case *mle_core.MleScene:
+		path = append(path, node)
+	case *mle_core.MleGroup:
+		if (scene != nil) && hasGroup(scene, node) {
+			path = append(path, scene)
+		}
+		path = append(path, node)
+	case *mle_core.MleActor:
+		path = propagator.actorPath(scene, node)
+	case *mle_core.MleRole:
+		if actor := node.GetActor(); actor != nil {
+			path = propagator.actorPath(scene, actor)
+		}
+		path = append(path, node)
+	}
+	return path, nil
+}
+
+// Get the path from the scene to an actor.
+func (propagator *MleEventPropagator) actorPath(scene *mle_core.MleScene, actor *mle_core.MleActor) []interface{} {
+	if scene != nil {
+		if group := findActorGroup(scene, actor); group != nil {
+			return []interface{}{scene, group, actor}
+		}
+	}
+	return []interface{}{actor}
+}
+
+// Dispatch the event to the capture or bubble callbacks of a node.
+func (propagator *MleEventPropagator) dispatchAt(node interface{}, event *MleEvent, capture bool, phase int) {
+	propagator.lock.Lock()
+	entry := propagator.m_nodes[node]
+	propagator.lock.Unlock()
+
+	event.m_propagation.m_currentTarget = node
+	event.m_propagation.m_phase = phase
+	if entry == nil {
+		return
+	}
+	if capture {
+		entry.m_capture.dispatchImmediate(event)
+	} else {
+		entry.m_bubble.dispatchImmediate(event)
+	}
+}
+
+/**
+ * Propagate an event to a target.
+ *
+ * @param event The event to propagate.
+ * @param target The <code>MleScene</code>, <code>MleGroup</code>,
+ * <code>MleActor</code> or <code>MleRole</code> targeted.
+ *
+ * @return <b>false</b> is returned if a callback prevented the default
+ * action; otherwise <b>true</b> is returned. An error is returned if the
+ * target can not be on a propagation path.
+ */
+func (propagator *MleEventPropagator) PropagateEvent(event *MleEvent, target interface{}) (bool, *mle_core.MleError) {
+	path, err := propagator.GetPath(target)
+	if err != nil {
+		return false, err
+	}
+
+	event.m_propagation = new(_EventPropagation)
+	event.m_propagation.m_target = target
+	last := len(path) - 1
+
+	// Capture down to the target's parent.
+	for i := 0; (i < last) && ! event.IsPropagationStopped(); i++ {
+		propagator.dispatchAt(path[i], event, true, MLE_EVENT_PHASE_CAPTURING)
+	}
+
+	// Every callback of the target is dispatched once the event reaches it.
+	if ! event.IsPropagationStopped() {
+		propagator.dispatchAt(path[last], event, true, MLE_EVENT_PHASE_AT_TARGET)
+		propagator.dispatchAt(path[last], event, false, MLE_EVENT_PHASE_AT_TARGET)
+	}
+
+	// Bubble back up to the root.
+	for i := last - 1; (i >= 0) && ! event.IsPropagationStopped(); i-- {
+		propagator.dispatchAt(path[i], event, false, MLE_EVENT_PHASE_BUBBLING)
+	}
+
+	event.m_propagation.m_currentTarget = nil
+	event.m_propagation.m_phase = MLE_EVENT_PHASE_NONE
+	return ! event.IsDefaultPrevented(), nil
+}
+
+/**
+ * Create an event and propagate it to a target.
+ *
+ * @param id The composite event identifier.
+ * @param calldata The call data of the event.
+ * @param target The <code>MleScene</code>, <code>MleGroup</code>,
+ * <code>MleActor</code> or <code>MleRole</code> targeted.
+ *
+ * @return <b>false</b> is returned if a callback prevented the default
+ * action; otherwise <b>true</b> is returned. An error is returned if the
+ * target can not be on a propagation path.
+ */
+func (propagator *MleEventPropagator) ProcessEvent(id int, calldata mle_util.IObject, target interface{}) (bool, *mle_core.MleError) {
+	event := NewMleEventWithIdEvTypeCalldata(propagator, id, MLE_EVENT_IMMEDIATE, calldata)
+	return propagator.PropagateEvent(event, target)
+}
@@ -0,0 +1,252 @@
+/**
+ * @file MleEventRecorder.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import go packages.
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	mle_util "github.com/mle/runtime/util"
+	mle_core "github.com/mle/runtime/core"
+)
+
+/** The format name written in the header of event recordings. */
+const MLE_EVENT_RECORDING_FORMAT string = "mle-events"
+/** The version of the event recording format written by MleEventRecorder. */
+const MLE_EVENT_RECORDING_VERSION int = 1
+
+// The first line of a recording.
+type _RecordingHeader struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	Codec   string `json:"codec"`
+}
+
+// A recorded event, one per line after the header.
+type _RecordedEvent struct {
+	Sequence int64  `json:"sequence"`
+	Frame    int64  `json:"frame"`
+	Id       int    `json:"id"`
+	Type     int16  `json:"type"`
+	Priority int    `json:"priority"`
+	CallData []byte `json:"calldata,omitempty"`
+	HasData  bool   `json:"hasdata,omitempty"`
+}
+
+/**
+ * <code>MleEventRecorder</code> records every event passed to
+ * <code>MleEventDispatcher.ProcessEventWithPriority</code> by a dispatcher
+ * it is set on (see <code>MleEventDispatcher.SetRecorder</code>), for
+ * replay by an <code>MleEventPlayer</code>.
+ * <p>
+ * A recording is a stream of JSON lines: a header giving the format
+ * version and codec name, then one line per event with its sequence
+ * number, frame number, id, dispatch type, priority and call data
+ * serialized by the codec. The sequence number counts the recorded events
+ * from 0 and gives the order they were processed in, which the player
+ * replays them in. The frame number is set with <code>SetFrame</code>,
+ * normally by the frame loop (see <code>MleFrameLoop.SetEventRecorder</code>).
+ * </p><p>
+ * The events fired by timers (see <code>MleEventDispatcher.PostEventAfter</code>)
+ * are processed, and so recorded, like any other event.
+ * </p>
+ */
+type MleEventRecorder struct {
+	// The buffered output.
+	m_writer *bufio.Writer
+	// The underlying output, closed by Close if it is an io.Closer.
+	m_output io.Writer
+	// The encoder writing lines to m_writer.
+	m_encoder *json.Encoder
+	// The call data codec.
+	m_codec IMleEventCodec
+	// The current frame number.
+	m_frame int64
+	// The number of events recorded.
+	m_count int64
+	// The first error encountered, after which nothing is recorded.
+	m_err *mle_core.MleError
+	// Internal lock used for protecting sensitve code.
+	lock sync.Mutex
+}
+
+/**
+ * Construct a recorder, writing the recording header.
+ *
+ * @param w The output the recording is written to.
+ * @param codec The call data codec.
+ *
+ * @return The recorder is returned, or an error if the header can not be
+ * written.
+ */
+func NewMleEventRecorder(w io.Writer, codec IMleEventCodec) (*MleEventRecorder, *mle_core.MleError) {
+	if codec == nil {
+		return nil, mle_core.NewMleError("MleEventRecorder: codec is nil.", 0, nil)
+	}
+
+	p := new(MleEventRecorder)
+	p.m_output = w
+	p.m_writer = bufio.NewWriter(w)
+	p.m_encoder = json.NewEncoder(p.m_writer)
+	p.m_codec = codec
+	header := _RecordingHeader{MLE_EVENT_RECORDING_FORMAT, MLE_EVENT_RECORDING_VERSION, codec.GetName()}
+	if err := p.m_encoder.Encode(header); err != nil {
+		return nil, mle_core.NewMleError("MleEventRecorder: unable to write header.", 0, err)
+	}
+	return p, nil
+}
+
+/**
+ * Create a recording file and a recorder writing to it. The file is closed
+ * by <code>Close</code>.
+ *
+ * @param path The path of the file to create.
+ * @param codec The call data codec.
+ */
+func CreateMleEventRecordingFile(path string, codec IMleEventCodec) (*MleEventRecorder, *mle_core.MleError) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, mle_core.NewMleError("MleEventRecorder: unable to create " + path + ".", 0, err)
+	}
+	recorder, merr := NewMleEventRecorder(file, codec)
+	if merr != nil {
+		file.Close()
+		return nil, merr
+	}
+	return recorder, nil
+}
+
+/**
+ * Set the frame number recorded with subsequent events.
+ *
+ * @param frame The frame number.
+ */
+func (recorder *MleEventRecorder) SetFrame(frame int64) {
+	recorder.lock.Lock()
+	recorder.m_frame = frame
+	recorder.lock.Unlock()
+}
+
+/**
+ * Get the frame number recorded with subsequent events.
+ */
+func (recorder *MleEventRecorder) GetFrame() int64 {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	return recorder.m_frame
+}
+
+/**
+ * Get the number of events recorded.
+ */
+func (recorder *MleEventRecorder) GetCount() int64 {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	return recorder.m_count
+}
+
+/**
+ * Get the first error encountered while recording. Recording stops at the
+ * first error.
+ *
+ * @return The error is returned, or <b>nil</b> if there has been none.
+ */
+func (recorder *MleEventRecorder) GetError() *mle_core.MleError {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	return recorder.m_err
+}
+
+// Record an event.
+func (recorder *MleEventRecorder) record(id int, evType int16, priority int, calldata mle_util.IObject) {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	if recorder.m_err != nil {
+		return
+	}
+
+	event := _RecordedEvent{Sequence: recorder.m_count, Frame: recorder.m_frame, Id: id, Type: evType, Priority: priority}
+	if calldata != nil {
+		data, err := recorder.m_codec.Encode(id, calldata)
+		if err != nil {
+			recorder.m_err = mle_core.NewMleError("MleEventRecorder: unable to encode call data.", 0, err)
+			return
+		}
+		event.CallData = data
+		event.HasData = true
+	}
+	if err := recorder.m_encoder.Encode(event); err != nil {
+		recorder.m_err = mle_core.NewMleError("MleEventRecorder: unable to write event.", 0, err)
+		return
+	}
+	recorder.m_count++
+}
+
+/**
+ * Flush buffered events to the output.
+ */
+func (recorder *MleEventRecorder) Flush() *mle_core.MleError {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	if err := recorder.m_writer.Flush(); err != nil {
+		return mle_core.NewMleError("MleEventRecorder: unable to flush.", 0, err)
+	}
+	return nil
+}
+
+/**
+ * Flush buffered events and close the output if it is an
+ * <code>io.Closer</code>. Remove the recorder from its dispatcher first.
+ */
+func (recorder *MleEventRecorder) Close() *mle_core.MleError {
+	if err := recorder.Flush(); err != nil {
+		return err
+	}
+	if closer, ok := recorder.m_output.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return mle_core.NewMleError("MleEventRecorder: unable to close.", 0, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,444 @@
+/**
+ * @file MleEventRegistry.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import go packages.
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/timtadh/data-structures/types"
+	mle_core "github.com/mle/runtime/core"
+)
+
+/** The name of the event group reserved by the manager. */
+const MLE_SYSTEM_GROUP_NAME string = "system"
+/** The group returned for a group name that is not registered. */
+const MLE_INVALID_GROUP int16 = -1
+
+/** The format name of exported event registries. */
+const MLE_EVENT_REGISTRY_FORMAT string = "mle-event-registry"
+/** The version of the event registry format exported by MleEventManager. */
+const MLE_EVENT_REGISTRY_VERSION int = 1
+
+// An exported event registry.
+type _RegistryFile struct {
+	Format string `json:"format"`
+	Version int `json:"version"`
+	Groups []_RegistryGroup `json:"groups"`
+}
+
+// An exported event group.
+type _RegistryGroup struct {
+	Id int16 `json:"id"`
+	Name string `json:"name,omitempty"`
+	Events []_RegistryEvent `json:"events"`
+}
+
+// An exported event; the id is the event id within its group.
+type _RegistryEvent struct {
+	Id uint16 `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// Determine whether any event is registered in a group; the lock must be
+// held.
+func (evm *MleEventManager) hasGroupEvents(group int16) bool {
+	return evm.m_eventRegistry.First(group) != nil
+}
+
+// Find the group registered with a name; the lock must be held.
+func (evm *MleEventManager) findGroup(name string) int16 {
+	for group, next := range evm.m_groups {
+		if next == name {
+			return group
+		}
+	}
+	return MLE_INVALID_GROUP
+}
+
+/**
+ * Register a named event group, allocating the lowest group that has
+ * neither a name nor any registered event.
+ *
+ * @param name The group name.
+ *
+ * @return The group is returned. If the name is already registered, its
+ * group is returned. An error is returned if the name is empty or every
+ * group is in use.
+ */
+func (evm *MleEventManager) RegisterGroup(name string) (int16, *mle_core.MleError) {
+	if name == "" {
+		return MLE_INVALID_GROUP, mle_core.NewMleError("MleEventManager: group name is empty.", 0, nil)
+	}
+
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+	if group := evm.findGroup(name); group != MLE_INVALID_GROUP {
+		return group, nil
+	}
+	for next := int(MLE_SYSTEM_GROUP) + 1; next <= int(MLE_MAX_GROUP); next++ {
+		group := int16(next)
+		if _, named := evm.m_groups[group]; !named && !evm.hasGroupEvents(group) {
+			evm.m_groups[group] = name
+			return group, nil
+		}
+	}
+	return MLE_INVALID_GROUP, mle_core.NewMleError("MleEventManager: no group is free for " + name + ".", 0, nil)
+}
+
+/**
+ * Register a name for the specified group, for titles and tools that fix
+ * their group numbers.
+ *
+ * @param group The group.
+ * @param name The group name.
+ *
+ * @return An error is returned if the group is out of range or has another
+ * name, or if the name is registered for another group.
+ */
+func (evm *MleEventManager) AddGroup(group int16, name string) *mle_core.MleError {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+	if err := evm.checkGroup(group, name); err != nil {
+		return err
+	}
+	evm.m_groups[group] = name
+	return nil
+}
+
+// Check that a name may be registered for a group; the lock must be held.
+func (evm *MleEventManager) checkGroup(group int16, name string) *mle_core.MleError {
+	if (group < MLE_SYSTEM_GROUP) || (name == "") {
+		msg := fmt.Sprintf("MleEventManager: invalid group %d named \"%s\".", group, name)
+		return mle_core.NewMleError(msg, 0, nil)
+	}
+	if current, named := evm.m_groups[group]; named && (current != name) {
+		msg := fmt.Sprintf("MleEventManager: group %d is already named \"%s\".", group, current)
+		return mle_core.NewMleError(msg, 0, nil)
+	}
+	if other := evm.findGroup(name); (other != MLE_INVALID_GROUP) && (other != group) {
+		msg := fmt.Sprintf("MleEventManager: group name \"%s\" is registered for group %d.", name, other)
+		return mle_core.NewMleError(msg, 0, nil)
+	}
+	return nil
+}
+
+/**
+ * Get the group registered with the specified name.
+ *
+ * @param name The group name.
+ *
+ * @return The group is returned, or <code>MLE_INVALID_GROUP</code> if the
+ * name is not registered.
+ */
+func (evm *MleEventManager) GetGroup(name string) int16 {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+	return evm.findGroup(name)
+}
+
+/**
+ * Get the name of the specified group.
+ *
+ * @param group The group.
+ *
+ * @return The group name is returned, or <b>""</b> if the group is not
+ * named.
+ */
+func (evm *MleEventManager) GetGroupName(group int16) string {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+	return evm.m_groups[group]
+}
+
+/**
+ * Get the named groups.
+ *
+ * @return The named groups are returned in ascending order.
+ */
+func (evm *MleEventManager) GetGroups() []int16 {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+	groups := make([]int16, 0, len(evm.m_groups))
+	for group := range evm.m_groups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i] < groups[j] })
+	return groups
+}
+
+/**
+ * Get the events registered in the specified group.
+ *
+ * @param group The group.
+ *
+ * @return The composite event identifiers are returned in ascending order.
+ */
+func (evm *MleEventManager) GetEvents(group int16) []int {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+	var events []int
+	for k, v, next := evm.m_eventRegistry.m_tree.Iterate()(); next != nil; k, v, next = next() {
+		_ = k
+		var item = v.(*EventSetItem)
+		if GetGroupId(int(item.m_id)) == group {
+			events = append(events, int(item.m_id))
+		}
+	}
+	return events
+}
+
+// Get an unregistered event identifier in a group: the one following the
+// last registered, or else the first free one. MLE_EVENT_INVALID_ID is
+// returned if the group is full or out of range. The lock must be held.
+func (evm *MleEventManager) nextEventId(group int16) int {
+	if group < MLE_SYSTEM_GROUP {
+		return MLE_EVENT_INVALID_ID
+	}
+	lastEvent := evm.m_eventRegistry.Last(group)
+	if lastEvent == nil {
+		// First event in the group.
+		return MakeId(group, 0)
+	}
+	if eid := ToEventID(int(lastEvent.m_id)).GetEvent(); eid < MLE_MAX_EVENT_ID {
+		return MakeEventID(group, eid + 1).Int()
+	}
+	for eid := 0; eid < int(MLE_MAX_EVENT_ID); eid++ {
+		id := MakeEventID(group, uint16(eid)).Int()
+		if ! evm.m_eventRegistry.m_tree.Has(types.Int(id)) {
+			return id
+		}
+	}
+	return MLE_EVENT_INVALID_ID
+}
+
+/**
+ * Create an event in the specified group and register it, so no other
+ * caller is given the same identifier.
+ *
+ * @param group The group.
+ * @param name The named event; must be unique or <b>""</b>.
+ *
+ * @return The composite event identifier is returned. An error is returned
+ * if the group is full or out of range, or the name is already registered.
+ */
+func (evm *MleEventManager) AllocateEvent(group int16, name string) (int, *mle_core.MleError) {
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+	id := evm.nextEventId(group)
+	if id == MLE_EVENT_INVALID_ID {
+		msg := fmt.Sprintf("MleEventManager: no event is free in group %d.", group)
+		return MLE_EVENT_INVALID_ID, mle_core.NewMleError(msg, 0, nil)
+	}
+	if err := evm.addEvent(id, name); err != nil {
+		return MLE_EVENT_INVALID_ID, err
+	}
+	return id, nil
+}
+
+/**
+ * Export the named groups and the registered events as JSON, so event
+ * identifiers can be kept stable across builds and shared with tools.
+ * Payload types are not exported.
+ *
+ * @param w The writer.
+ *
+ * @return An error is returned if the registry can not be written.
+ */
+func (evm *MleEventManager) ExportRegistry(w io.Writer) *mle_core.MleError {
+	evm.lock.Lock()
+	file := _RegistryFile{MLE_EVENT_REGISTRY_FORMAT, MLE_EVENT_REGISTRY_VERSION, nil}
+	groups := make(map[int16]*_RegistryGroup)
+	var order []int16
+	getGroup := func(group int16) *_RegistryGroup {
+		if groups[group] == nil {
+			groups[group] = &_RegistryGroup{group, evm.m_groups[group], []_RegistryEvent{}}
+			order = append(order, group)
+		}
+		return groups[group]
+	}
+	for group := range evm.m_groups {
+		getGroup(group)
+	}
+	for k, v, next := evm.m_eventRegistry.m_tree.Iterate()(); next != nil; k, v, next = next() {
+		_ = k
+		var item = v.(*EventSetItem)
+		group := getGroup(GetGroupId(int(item.m_id)))
+		group.Events = append(group.Events, _RegistryEvent{ToEventID(int(item.m_id)).GetEvent(), item.m_name})
+	}
+	evm.lock.Unlock()
+
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+	for _, group := range order {
+		file.Groups = append(file.Groups, *groups[group])
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err == nil {
+		_, err = w.Write(append(data, '\n'))
+	}
+	if err != nil {
+		return mle_core.NewMleError("MleEventManager: unable to write the event registry.", 0, err)
+	}
+	return nil
+}
+
+/**
+ * Import groups and events exported by <code>ExportRegistry</code>,
+ * adding them to the registry. Nothing is imported if any group or event
+ * conflicts with those already registered, or with another in the
+ * registry imported.
+ *
+ * @param r The reader.
+ *
+ * @return An error is returned if the registry can not be read, or has a
+ * conflict.
+ */
+func (evm *MleEventManager) ImportRegistry(r io.Reader) *mle_core.MleError {
+	var file _RegistryFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return mle_core.NewMleError("MleEventManager: not an event registry.", 0, err)
+	}
+	if file.Format != MLE_EVENT_REGISTRY_FORMAT {
+		return mle_core.NewMleError("MleEventManager: unknown registry format " + file.Format + ".", 0, nil)
+	}
+	if file.Version != MLE_EVENT_REGISTRY_VERSION {
+		msg := fmt.Sprintf("MleEventManager: unsupported registry version %d.", file.Version)
+		return mle_core.NewMleError(msg, 0, nil)
+	}
+
+	evm.lock.Lock()
+	defer evm.lock.Unlock()
+
+	// Check everything before adding anything.
+	groupNames := make(map[string]int16)
+	eventNames := make(map[string]int)
+	ids := make(map[int]bool)
+	for _, group := range file.Groups {
+		if group.Name != "" {
+			if err := evm.checkGroup(group.Id, group.Name); err != nil {
+				return err
+			}
+			if other, found := groupNames[group.Name]; found && (other != group.Id) {
+				msg := fmt.Sprintf("MleEventManager: group name \"%s\" is imported for groups %d and %d.",
+					group.Name, other, group.Id)
+				return mle_core.NewMleError(msg, 0, nil)
+			}
+			groupNames[group.Name] = group.Id
+		}
+		for _, event := range group.Events {
+			id := MakeEventID(group.Id, event.Id).Int()
+			if id == MLE_EVENT_INVALID_ID {
+				msg := fmt.Sprintf("MleEventManager: invalid event %d in group %d.", event.Id, group.Id)
+				return mle_core.NewMleError(msg, 0, nil)
+			}
+			if ids[id] {
+				msg := fmt.Sprintf("MleEventManager: event 0x%08x is imported twice.", id)
+				return mle_core.NewMleError(msg, 0, nil)
+			}
+			ids[id] = true
+			if item := evm.m_eventRegistry.Find(id); (item != nil) && (item.m_name != event.Name) {
+				msg := fmt.Sprintf("MleEventManager: event 0x%08x is registered as \"%s\".", id, item.m_name)
+				return mle_core.NewMleError(msg, 0, nil)
+			}
+			if event.Name != "" {
+				item := evm.m_eventRegistry.FindByName(event.Name)
+				other, found := eventNames[event.Name]
+				if ((item != nil) && (int(item.m_id) != id)) || (found && (other != id)) {
+					msg := fmt.Sprintf("MleEventManager: event name \"%s\" is used by another event.", event.Name)
+					return mle_core.NewMleError(msg, 0, nil)
+				}
+				eventNames[event.Name] = id
+			}
+		}
+	}
+
+	for _, group := range file.Groups {
+		if group.Name != "" {
+			evm.m_groups[group.Id] = group.Name
+		}
+		for _, event := range group.Events {
+			evm.addEvent(MakeEventID(group.Id, event.Id).Int(), event.Name)
+		}
+	}
+	return nil
+}
+
+/**
+ * Export the registry to a file.
+ *
+ * @param path The path of the file to create.
+ *
+ * @return An error is returned if the file can not be written.
+ */
+func (evm *MleEventManager) ExportRegistryFile(path string) *mle_core.MleError {
+	file, err := os.Create(path)
+	if err != nil {
+		return mle_core.NewMleError("MleEventManager: unable to create " + path + ".", 0, err)
+	}
+	if merr := evm.ExportRegistry(file); merr != nil {
+		file.Close()
+		return merr
+	}
+	if err = file.Close(); err != nil {
+		return mle_core.NewMleError("MleEventManager: unable to close " + path + ".", 0, err)
+	}
+	return nil
+}
+
+/**
+ * Import the registry from a file.
+ *
+ * @param path The path of the file to read.
+ *
+ * @return An error is returned if the file can not be read, or has a
+ * conflict.
+ */
+func (evm *MleEventManager) ImportRegistryFile(path string) *mle_core.MleError {
+	file, err := os.Open(path)
+	if err != nil {
+		return mle_core.NewMleError("MleEventManager: unable to open " + path + ".", 0, err)
+	}
+	defer file.Close()
+	return evm.ImportRegistry(file)
+}
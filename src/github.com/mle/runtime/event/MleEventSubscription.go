@@ -0,0 +1,269 @@
+/**
+ * @file MleEventSubscription.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import go packages.
+import (
+	"sync"
+	"sync/atomic"
+
+	mle_util "github.com/mle/runtime/util"
+	mle_core "github.com/mle/runtime/core"
+)
+
+/** Drop an event when the subscription's channel is full. */
+const MLE_SUBSCRIPTION_DROP int = 0
+/** Block the dispatching goroutine until the subscriber receives the event. */
+const MLE_SUBSCRIPTION_BLOCK int = 1
+
+// A callback installed for a subscription.
+type _SubscribedCB struct {
+	// The event or group the callback is installed for.
+	m_id int
+	// Flag indicating whether the callback is installed for a group.
+	m_isGroup bool
+	// The callback identifier.
+	m_cbId mle_core.IMleCallbackId
+}
+
+/**
+ * <code>MleEventSubscription</code> delivers events from an
+ * <code>MleEventDispatcher</code> on a channel, so they may be consumed
+ * with <code>select</code> instead of callbacks.
+ * <p>
+ * Events are sent on the channel by the goroutine dispatching them: the
+ * producer for immediate events, and the caller of
+ * <code>DispatchEvents</code> for delayed events. With
+ * <code>MLE_SUBSCRIPTION_BLOCK</code> that goroutine waits for the
+ * subscriber, so the subscriber must not be the one dispatching.
+ * </p>
+ */
+type MleEventSubscription struct {
+	// The dispatcher the callbacks are installed with.
+	m_dispatcher *MleEventDispatcher
+	// The installed callbacks.
+	m_callbacks []_SubscribedCB
+	// The channel events are delivered on.
+	m_channel chan *MleEvent
+	// The overflow policy.
+	m_policy int
+	// The number of events dropped because the channel was full.
+	m_dropped atomic.Int64
+	// Closed to release blocked senders when the subscription is closed.
+	m_closing chan struct{}
+	// Flag indicating whether the subscription is closed.
+	m_closed bool
+	// Ensures the subscription is closed once.
+	m_closeOnce sync.Once
+	// Internal lock used to keep senders and Close apart.
+	lock sync.RWMutex
+}
+
+// The callback delivering events to a subscription.
+type _SubscriptionCallback struct {
+	*MleEventCallback
+	// The subscription to deliver to.
+	m_subscription *MleEventSubscription
+}
+
+// Dispatch implements the IMleEventCallback interface.
+func (cb *_SubscriptionCallback) Dispatch(event MleEvent, clientdata mle_util.IObject) bool {
+	return cb.m_subscription.deliver(&event)
+}
+
+/**
+ * Subscribe to one or more events.
+ * 
+ * @param buffer The capacity of the subscription's channel.
+ * @param policy The overflow policy, <code>MLE_SUBSCRIPTION_DROP</code> or
+ * <code>MLE_SUBSCRIPTION_BLOCK</code>.
+ * @param events The composite event identifiers.
+ * 
+ * @return The subscription is returned. An error is returned if no event
+ * is given, or the buffer or policy are invalid.
+ */
+func (dispatcher *MleEventDispatcher) NewSubscription(buffer int, policy int, events ...int) (*MleEventSubscription, *mle_core.MleError) {
+	ids := make([]_SubscribedCB, len(events))
+	for i, event := range events {
+		ids[i].m_id = event
+	}
+	return dispatcher.newSubscription(buffer, policy, ids)
+}
+
+/**
+ * Subscribe to every event in one or more groups.
+ * 
+ * @param buffer The capacity of the subscription's channel.
+ * @param policy The overflow policy, <code>MLE_SUBSCRIPTION_DROP</code> or
+ * <code>MLE_SUBSCRIPTION_BLOCK</code>.
+ * @param groups The group identifiers (see <code>GetGroupId</code>).
+ * 
+ * @return The subscription is returned. An error is returned if no group
+ * is given, or the buffer or policy are invalid.
+ */
+func (dispatcher *MleEventDispatcher) NewGroupSubscription(buffer int, policy int, groups ...int16) (*MleEventSubscription, *mle_core.MleError) {
+	ids := make([]_SubscribedCB, len(groups))
+	for i, group := range groups {
+		ids[i].m_id = int(group)
+		ids[i].m_isGroup = true
+	}
+	return dispatcher.newSubscription(buffer, policy, ids)
+}
+
+// Create a subscription and install its callbacks.
+func (dispatcher *MleEventDispatcher) newSubscription(buffer int, policy int, ids []_SubscribedCB) (*MleEventSubscription, *mle_core.MleError) {
+	if len(ids) == 0 {
+		msg := "MleEventSubscription: no events to subscribe to."
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+	if buffer < 0 {
+		msg := "MleEventSubscription: buffer must not be negative."
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+	if (policy != MLE_SUBSCRIPTION_DROP) && (policy != MLE_SUBSCRIPTION_BLOCK) {
+		msg := "MleEventSubscription: invalid overflow policy."
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+
+	p := new(MleEventSubscription)
+	p.m_dispatcher = dispatcher
+	p.m_channel = make(chan *MleEvent, buffer)
+	p.m_policy = policy
+	p.m_closing = make(chan struct{})
+
+	cb := new(_SubscriptionCallback)
+	cb.MleEventCallback = NewMleEventCallback()
+	cb.MleEventCallback.Enable(true)
+	cb.m_subscription = p
+	for _, id := range ids {
+		var err *mle_core.MleError
+		if id.m_isGroup {
+			id.m_cbId, err = dispatcher.InstallGroupCB(int16(id.m_id), cb, nil, 0)
+		} else {
+			id.m_cbId, err = dispatcher.InstallEventCB(id.m_id, cb, nil)
+		}
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.m_callbacks = append(p.m_callbacks, id)
+	}
+
+	return p, nil
+}
+
+// Send an event on the channel according to the overflow policy.
+func (sub *MleEventSubscription) deliver(event *MleEvent) bool {
+	sub.lock.RLock()
+	defer sub.lock.RUnlock()
+	if sub.m_closed {
+		return false
+	}
+
+	if sub.m_policy == MLE_SUBSCRIPTION_BLOCK {
+		select {
+		case sub.m_channel <- event:
+			return true
+		case <-sub.m_closing:
+			return false
+		}
+	}
+
+	select {
+	case sub.m_channel <- event:
+		return true
+	default:
+		sub.m_dropped.Add(1)
+		return false
+	}
+}
+
+/**
+ * Get the channel events are delivered on. The channel is closed by
+ * <code>Close</code>.
+ * 
+ * @return The receive-only channel is returned.
+ */
+func (sub *MleEventSubscription) GetChannel() <-chan *MleEvent {
+	return sub.m_channel
+}
+
+/**
+ * Get the overflow policy.
+ * 
+ * @return <code>MLE_SUBSCRIPTION_DROP</code> or
+ * <code>MLE_SUBSCRIPTION_BLOCK</code> is returned.
+ */
+func (sub *MleEventSubscription) GetPolicy() int {
+	return sub.m_policy
+}
+
+/**
+ * Get the number of events dropped because the channel was full.
+ * 
+ * @return The number of dropped events is returned.
+ */
+func (sub *MleEventSubscription) GetDropped() int64 {
+	return sub.m_dropped.Load()
+}
+
+/**
+ * Close the subscription, uninstalling its callbacks and closing the
+ * channel. Events already buffered may still be received. Closing a closed
+ * subscription does nothing.
+ */
+func (sub *MleEventSubscription) Close() {
+	sub.m_closeOnce.Do(func() {
+		// Release blocked senders, then wait for senders in progress.
+		close(sub.m_closing)
+		sub.lock.Lock()
+		sub.m_closed = true
+		close(sub.m_channel)
+		sub.lock.Unlock()
+
+		for _, id := range sub.m_callbacks {
+			if id.m_isGroup {
+				sub.m_dispatcher.UninstallGroupCB(int16(id.m_id), id.m_cbId)
+			} else {
+				sub.m_dispatcher.UninstallEventCB(id.m_id, id.m_cbId)
+			}
+		}
+	})
+}
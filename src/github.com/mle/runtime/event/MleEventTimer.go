@@ -0,0 +1,425 @@
+/**
+ * @file MleEventTimer.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import go packages.
+import (
+	"container/heap"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mle_util "github.com/mle/runtime/util"
+	mle_core "github.com/mle/runtime/core"
+)
+
+/**
+ * <code>IMleEventClock</code> is the source of time for timed events (see
+ * <code>MleEventDispatcher.SetClock</code>). The scheduler's
+ * <code>MleClock</code> satisfies it.
+ */
+type IMleEventClock interface {
+	/**
+	 * Get the current time.
+	 *
+	 * @return The current time is returned.
+	 */
+	Now() time.Time
+}
+
+/**
+ * <code>MleEventTimer</code> is the handle of an event posted to fire
+ * after a duration, at a frame, or periodically (see
+ * <code>MleEventDispatcher.PostEventAfter</code>). It may be used to
+ * cancel the event.
+ */
+type MleEventTimer struct {
+	// The composite event identifier.
+	m_id int
+	// The call data of the event.
+	m_calldata mle_util.IObject
+	// The dispatch priority.
+	m_priority int
+	// Flag indicating whether the timer fires at frames rather than times.
+	m_isFrame bool
+	// The time the timer fires next.
+	m_when time.Time
+	// The frame the timer fires next.
+	m_frame int64
+	// The time between firings of a periodic timer, or zero.
+	m_period time.Duration
+	// The frames between firings of a periodic timer, or zero.
+	m_framePeriod int64
+	// The number of times the timer has fired.
+	m_fired atomic.Int64
+	// Flag indicating whether the timer has been cancelled.
+	m_cancelled atomic.Bool
+	// The index of the timer in its heap, or -1 when it is not pending.
+	m_index int
+	// The pending timers of the dispatcher the timer was posted to.
+	m_timers *_EventTimers
+}
+
+/**
+ * Get the composite event identifier posted by the timer.
+ */
+func (timer *MleEventTimer) GetId() int {
+	return timer.m_id
+}
+
+/**
+ * Get the number of times the timer has fired.
+ */
+func (timer *MleEventTimer) GetFireCount() int64 {
+	return timer.m_fired.Load()
+}
+
+/**
+ * Determine whether the timer is periodic.
+ */
+func (timer *MleEventTimer) IsPeriodic() bool {
+	return (timer.m_period > 0) || (timer.m_framePeriod > 0)
+}
+
+/**
+ * Determine whether the timer has been cancelled.
+ */
+func (timer *MleEventTimer) IsCancelled() bool {
+	return timer.m_cancelled.Load()
+}
+
+/**
+ * Cancel the timer, removing it from the pending timers. An event it has
+ * already posted is still dispatched.
+ *
+ * @return <b>true</b> is returned if the timer was cancelled by this call.
+ */
+func (timer *MleEventTimer) Cancel() bool {
+	if timer.m_timers == nil {
+		return timer.m_cancelled.CompareAndSwap(false, true)
+	}
+	return timer.m_timers.cancel(timer)
+}
+
+// Determine whether the timer is due.
+func (timer *MleEventTimer) isDue(now time.Time, frame int64) bool {
+	if timer.m_isFrame {
+		return timer.m_frame <= frame
+	}
+	return ! timer.m_when.After(now)
+}
+
+// Reschedule a periodic timer after it fires. Missed periods are skipped.
+func (timer *MleEventTimer) reschedule(now time.Time, frame int64) {
+	if timer.m_isFrame {
+		timer.m_frame += timer.m_framePeriod
+		if timer.m_frame <= frame {
+			timer.m_frame += ((frame - timer.m_frame) / timer.m_framePeriod + 1) * timer.m_framePeriod
+		}
+	} else {
+		timer.m_when = timer.m_when.Add(timer.m_period)
+		if ! timer.m_when.After(now) {
+			timer.m_when = timer.m_when.Add((now.Sub(timer.m_when) / timer.m_period + 1) * timer.m_period)
+		}
+	}
+}
+
+// A heap of pending timers, earliest first.
+type _TimerHeap []*MleEventTimer
+
+func (h _TimerHeap) Len() int {
+	return len(h)
+}
+
+func (h _TimerHeap) Less(i, j int) bool {
+	if h[i].m_isFrame {
+		return h[i].m_frame < h[j].m_frame
+	}
+	return h[i].m_when.Before(h[j].m_when)
+}
+
+func (h _TimerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].m_index = i
+	h[j].m_index = j
+}
+
+func (h *_TimerHeap) Push(x interface{}) {
+	timer := x.(*MleEventTimer)
+	timer.m_index = len(*h)
+	*h = append(*h, timer)
+}
+
+func (h *_TimerHeap) Pop() interface{} {
+	old := *h
+	timer := old[len(old) - 1]
+	old[len(old) - 1] = nil
+	timer.m_index = -1
+	*h = old[:len(old) - 1]
+	return timer
+}
+
+// The pending timers of a dispatcher.
+type _EventTimers struct {
+	// The timers firing at times.
+	m_timeHeap _TimerHeap
+	// The timers firing at frames.
+	m_frameHeap _TimerHeap
+	// The source of time, nil for the system clock.
+	m_clock IMleEventClock
+	// The current frame.
+	m_frame int64
+	// Internal lock used for protecting sensitve code.
+	lock sync.Mutex
+}
+
+// Add a timer. A timer firing at times fires after the specified delay.
+func (timers *_EventTimers) add(timer *MleEventTimer, delay time.Duration) {
+	timers.lock.Lock()
+	defer timers.lock.Unlock()
+	if timer.m_isFrame {
+		heap.Push(&timers.m_frameHeap, timer)
+	} else {
+		timer.m_when = timers.now().Add(delay)
+		heap.Push(&timers.m_timeHeap, timer)
+	}
+}
+
+// Get the current time.
+func (timers *_EventTimers) now() time.Time {
+	if timers.m_clock == nil {
+		return time.Now()
+	}
+	return timers.m_clock.Now()
+}
+
+// Cancel a timer and remove it from its heap if it is pending.
+func (timers *_EventTimers) cancel(timer *MleEventTimer) bool {
+	timers.lock.Lock()
+	defer timers.lock.Unlock()
+	if ! timer.m_cancelled.CompareAndSwap(false, true) {
+		return false
+	}
+	if timer.m_index >= 0 {
+		if timer.m_isFrame {
+			heap.Remove(&timers.m_frameHeap, timer.m_index)
+		} else {
+			heap.Remove(&timers.m_timeHeap, timer.m_index)
+		}
+	}
+	return true
+}
+
+// Remove the due timers, rescheduling periodic ones, and return them in
+// the order they fell due.
+func (timers *_EventTimers) due() []*MleEventTimer {
+	timers.lock.Lock()
+	defer timers.lock.Unlock()
+
+	now := timers.now()
+	var due []*MleEventTimer
+	for _, h := range []*_TimerHeap{&timers.m_timeHeap, &timers.m_frameHeap} {
+		var fired []*MleEventTimer
+		for (h.Len() > 0) && (*h)[0].isDue(now, timers.m_frame) {
+			timer := heap.Pop(h).(*MleEventTimer)
+			due = append(due, timer)
+			fired = append(fired, timer)
+		}
+		// Reschedule after popping, so a periodic timer fires once per pass.
+		for _, timer := range fired {
+			if timer.IsPeriodic() {
+				timer.reschedule(now, timers.m_frame)
+				heap.Push(h, timer)
+			}
+		}
+	}
+	return due
+}
+
+// Get the number of pending timers. Cancelled timers are not pending.
+func (timers *_EventTimers) pending() int {
+	timers.lock.Lock()
+	defer timers.lock.Unlock()
+	return timers.m_timeHeap.Len() + timers.m_frameHeap.Len()
+}
+
+/**
+ * Set the source of time for timed events. The system clock is used by
+ * default.
+ * 
+ * @param clock The clock, or <b>nil</b> for the system clock.
+ */
+func (dispatcher *MleEventDispatcher) SetClock(clock IMleEventClock) {
+	dispatcher.m_timers.lock.Lock()
+	dispatcher.m_timers.m_clock = clock
+	dispatcher.m_timers.lock.Unlock()
+}
+
+/**
+ * Set the current frame, used by events posted at frames. The frame loop
+ * sets it before each frame (see <code>MleFrameLoop.SetEventDispatcher</code>).
+ * 
+ * @param frame The frame number.
+ */
+func (dispatcher *MleEventDispatcher) SetFrame(frame int64) {
+	dispatcher.m_timers.lock.Lock()
+	dispatcher.m_timers.m_frame = frame
+	dispatcher.m_timers.lock.Unlock()
+}
+
+/**
+ * Get the current frame.
+ */
+func (dispatcher *MleEventDispatcher) GetFrame() int64 {
+	dispatcher.m_timers.lock.Lock()
+	defer dispatcher.m_timers.lock.Unlock()
+	return dispatcher.m_timers.m_frame
+}
+
+/**
+ * Get the number of timed events that have not fired, or will fire again,
+ * and have not been cancelled.
+ */
+func (dispatcher *MleEventDispatcher) GetNumPendingTimers() int {
+	return dispatcher.m_timers.pending()
+}
+
+// Create a timer for an event.
+func (dispatcher *MleEventDispatcher) newTimer(id int, calldata mle_util.IObject, priority int) *MleEventTimer {
+	timer := new(MleEventTimer)
+	timer.m_id = id
+	timer.m_calldata = calldata
+	timer.m_priority = priority
+	timer.m_index = -1
+	timer.m_timers = &dispatcher.m_timers
+	return timer
+}
+
+/**
+ * Post an event to be dispatched after a duration. The event is queued
+ * by the first <code>DispatchEvents</code> pass at or after that time.
+ * 
+ * @param id The composite event identifier.
+ * @param calldata The call data of the event.
+ * @param priority The event dispatch priority.
+ * @param delay The duration to wait.
+ * 
+ * @return The timer handle is returned.
+ */
+func (dispatcher *MleEventDispatcher) PostEventAfter(id int, calldata mle_util.IObject, priority int, delay time.Duration) *MleEventTimer {
+	timer := dispatcher.newTimer(id, calldata, priority)
+	dispatcher.m_timers.add(timer, delay)
+	return timer
+}
+
+/**
+ * Post an event to be dispatched periodically. The first event is queued
+ * one period from now; periods missed between dispatch passes are skipped.
+ * 
+ * @param id The composite event identifier.
+ * @param calldata The call data of each event.
+ * @param priority The event dispatch priority.
+ * @param period The time between events.
+ * 
+ * @return The timer handle is returned, or an error if the period is not
+ * positive.
+ */
+func (dispatcher *MleEventDispatcher) PostEventEvery(id int, calldata mle_util.IObject, priority int, period time.Duration) (*MleEventTimer, *mle_core.MleError) {
+	if period <= 0 {
+		return nil, mle_core.NewMleError("MleEventDispatcher: timer period must be positive.", 0, nil)
+	}
+	timer := dispatcher.newTimer(id, calldata, priority)
+	timer.m_period = period
+	dispatcher.m_timers.add(timer, period)
+	return timer, nil
+}
+
+/**
+ * Post an event to be dispatched at a frame. The event is queued by the
+ * first <code>DispatchEvents</code> pass once the dispatcher's frame (see
+ * <code>SetFrame</code>) has reached it.
+ * 
+ * @param id The composite event identifier.
+ * @param calldata The call data of the event.
+ * @param priority The event dispatch priority.
+ * @param frame The frame number.
+ * 
+ * @return The timer handle is returned.
+ */
+func (dispatcher *MleEventDispatcher) PostEventAtFrame(id int, calldata mle_util.IObject, priority int, frame int64) *MleEventTimer {
+	timer := dispatcher.newTimer(id, calldata, priority)
+	timer.m_isFrame = true
+	timer.m_frame = frame
+	dispatcher.m_timers.add(timer, 0)
+	return timer
+}
+
+/**
+ * Post an event to be dispatched every number of frames, starting that
+ * many frames after the current frame.
+ * 
+ * @param id The composite event identifier.
+ * @param calldata The call data of each event.
+ * @param priority The event dispatch priority.
+ * @param frames The number of frames between events.
+ * 
+ * @return The timer handle is returned, or an error if the number of
+ * frames is not positive.
+ */
+func (dispatcher *MleEventDispatcher) PostEventEveryFrames(id int, calldata mle_util.IObject, priority int, frames int64) (*MleEventTimer, *mle_core.MleError) {
+	if frames <= 0 {
+		return nil, mle_core.NewMleError("MleEventDispatcher: timer period must be positive.", 0, nil)
+	}
+	timer := dispatcher.newTimer(id, calldata, priority)
+	timer.m_isFrame = true
+	timer.m_framePeriod = frames
+	timer.m_frame = dispatcher.GetFrame() + frames
+	dispatcher.m_timers.add(timer, 0)
+	return timer, nil
+}
+
+// Queue the events of the due timers. They are processed like any other
+// delayed event, so they are recorded by the dispatcher's recorder.
+func (dispatcher *MleEventDispatcher) fireTimers() {
+	for _, timer := range dispatcher.m_timers.due() {
+		timer.m_fired.Add(1)
+		dispatcher.ProcessEventWithPriority(timer.m_id, timer.m_calldata, MLE_EVENT_DELAYED, timer.m_priority)
+	}
+}
@@ -0,0 +1,296 @@
+/**
+ * @file MleEventType.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import go packages.
+import (
+	"fmt"
+	"reflect"
+
+	mle_util "github.com/mle/runtime/util"
+	mle_core "github.com/mle/runtime/core"
+)
+
+/**
+ * <code>EventType</code> identifies an event whose call data is a payload
+ * of type <b>T</b>.
+ * <p>
+ * An <code>EventType</code> wraps a composite event identifier (see
+ * <code>MakeId</code>) registered with the event manager together with its
+ * payload type. Handlers installed with <code>Subscribe</code> receive the
+ * payload directly, and <code>Emit</code> only accepts a payload of the
+ * registered type, so payloads are checked at compile time rather than by
+ * type assertions on <code>MleEvent</code> call data.
+ * </p>
+ */
+type EventType[T any] struct {
+	// The composite event identifier.
+	m_id int
+	// The event name. May be empty.
+	m_name string
+}
+
+/**
+ * Register a typed event with the event manager.
+ * 
+ * @param id The composite event identifier.
+ * @param name The named event; must be unique or empty.
+ * 
+ * @return The typed event is returned. An error is returned if the name
+ * is used by another event, or the event is already registered with a
+ * different name or payload type.
+ */
+func RegisterEventType[T any](id int, name string) (*EventType[T], *mle_core.MleError) {
+	payloadType := reflect.TypeOf((*T)(nil)).Elem()
+	err := NewMleEventManager().addEventType(id, name, payloadType)
+	if err != nil {
+		return nil, err
+	}
+
+	p := new(EventType[T])
+	p.m_id = id
+	p.m_name = name
+	return p, nil
+}
+
+/**
+ * Create and register a typed event in the specified group. The event is
+ * allocated and registered at once, so no other caller is given the same
+ * identifier.
+ * 
+ * @param group The group identifier to create an event for.
+ * @param name The named event; must be unique or empty.
+ * 
+ * @return The typed event is returned, or an error if it can not be
+ * registered.
+ * 
+ * @see MleEventManager.AllocateEvent(int16, string)
+ */
+func CreateEventType[T any](group int16, name string) (*EventType[T], *mle_core.MleError) {
+	id, err := NewMleEventManager().AllocateEvent(group, name)
+	if err != nil {
+		return nil, err
+	}
+	return RegisterEventType[T](id, name)
+}
+
+/**
+ * Get the composite event identifier.
+ * 
+ * @return The event identifier is returned.
+ */
+func (et *EventType[T]) GetId() int {
+	return et.m_id
+}
+
+/**
+ * Get the event name.
+ * 
+ * @return The name is returned; it may be empty.
+ */
+func (et *EventType[T]) GetName() string {
+	return et.m_name
+}
+
+/**
+ * Get the payload carried by an event of this type. This may be used by
+ * untyped callbacks and listeners.
+ * 
+ * @param event The event to get the payload from.
+ * 
+ * @return The payload and <b>true</b> are returned if the event is of this
+ * type and carries a payload of type <b>T</b>. Otherwise, the zero value
+ * and <b>false</b> are returned.
+ */
+func (et *EventType[T]) GetPayload(event *MleEvent) (T, bool) {
+	var payload T
+	if (event == nil) || (event.GetId() != et.m_id) {
+		return payload, false
+	}
+	calldata, ok := event.GetCallData().(*TypedCallData[T])
+	if ! ok {
+		return payload, false
+	}
+	return calldata.GetPayload(), true
+}
+
+// String implements the IObject interface.
+func (et *EventType[T]) String() string {
+	if et.m_name != "" {
+		return et.m_name
+	}
+	return fmt.Sprintf("0x%08x", et.m_id)
+}
+
+/**
+ * <code>TypedCallData</code> carries the payload of a typed event as the
+ * call data of an <code>MleEvent</code>.
+ */
+type TypedCallData[T any] struct {
+	// The event payload.
+	m_payload T
+}
+
+/**
+ * Construct call data carrying the specified payload.
+ * 
+ * @param payload The event payload.
+ */
+func NewTypedCallData[T any](payload T) *TypedCallData[T] {
+	p := new(TypedCallData[T])
+	p.m_payload = payload
+	return p
+}
+
+/**
+ * Get the event payload.
+ * 
+ * @return The payload is returned.
+ */
+func (cd *TypedCallData[T]) GetPayload() T {
+	return cd.m_payload
+}
+
+// String implements the IObject interface.
+func (cd *TypedCallData[T]) String() string {
+	return fmt.Sprint(cd.m_payload)
+}
+
+// The callback installed by Subscribe, adapting a typed handler.
+type _TypedCallback[T any] struct {
+	*MleEventCallback
+	// The handler invoked with the payload.
+	m_handler func(T)
+}
+
+// Dispatch implements the IMleEventCallback interface. Call data that is not
+// a payload of type T is not passed to the handler; nil call data is passed
+// as the zero value.
+func (cb *_TypedCallback[T]) Dispatch(event MleEvent, clientdata mle_util.IObject) bool {
+	var payload T
+	switch calldata := event.GetCallData().(type) {
+	case *TypedCallData[T]:
+		payload = calldata.GetPayload()
+	case nil:
+	default:
+		return false
+	}
+	cb.m_handler(payload)
+	return true
+}
+
+/**
+ * Subscribe a handler to a typed event.
+ * 
+ * @param dispatcher The dispatcher to install the handler with.
+ * @param event The typed event.
+ * @param handler The handler invoked with the payload of each event.
+ * 
+ * @return A callback identifier is returned, which may be used with the
+ * dispatcher to enable, disable, reprioritize or uninstall the handler.
+ */
+func Subscribe[T any](dispatcher *MleEventDispatcher, event *EventType[T], handler func(T)) (mle_core.IMleCallbackId, *mle_core.MleError) {
+	return SubscribeWithPriority(dispatcher, event, handler, 0)
+}
+
+/**
+ * Subscribe a handler to a typed event with a dispatch priority. Handlers
+ * with a higher priority are dispatched first.
+ * 
+ * @param dispatcher The dispatcher to install the handler with.
+ * @param event The typed event.
+ * @param handler The handler invoked with the payload of each event.
+ * @param priority The handler priority.
+ * 
+ * @return A callback identifier is returned.
+ */
+func SubscribeWithPriority[T any](dispatcher *MleEventDispatcher, event *EventType[T], handler func(T), priority int) (mle_core.IMleCallbackId, *mle_core.MleError) {
+	if (event == nil) || (handler == nil) {
+		msg := "MleEventType: event and handler must not be nil."
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+
+	cb := new(_TypedCallback[T])
+	cb.MleEventCallback = NewMleEventCallback()
+	cb.MleEventCallback.Enable(true)
+	cb.m_handler = handler
+	return dispatcher.InstallEventCBWithPriority(event.m_id, cb, nil, priority)
+}
+
+/**
+ * Unsubscribe a handler from a typed event.
+ * 
+ * @param dispatcher The dispatcher the handler was installed with.
+ * @param event The typed event.
+ * @param id The callback identifier returned by <code>Subscribe</code>.
+ * 
+ * @return <b>true</b> is returned if the handler is uninstalled.
+ */
+func Unsubscribe[T any](dispatcher *MleEventDispatcher, event *EventType[T], id mle_core.IMleCallbackId) bool {
+	return dispatcher.UninstallEventCB(event.m_id, id)
+}
+
+/**
+ * Dispatch a typed event immediately.
+ * 
+ * @param dispatcher The dispatcher to process the event with.
+ * @param event The typed event.
+ * @param payload The event payload.
+ * 
+ * @return The result of the last handler dispatched is returned; see
+ * <code>MleEventDispatcher.ProcessEvent</code>.
+ */
+func Emit[T any](dispatcher *MleEventDispatcher, event *EventType[T], payload T) bool {
+	return dispatcher.ProcessEvent(event.m_id, NewTypedCallData(payload), MLE_EVENT_IMMEDIATE)
+}
+
+/**
+ * Queue a typed event for dispatching by <code>DispatchEvents</code>.
+ * 
+ * @param dispatcher The dispatcher to queue the event with.
+ * @param event The typed event.
+ * @param payload The event payload.
+ * @param priority The event dispatch priority.
+ * 
+ * @return <b>true</b> is returned if the event is queued.
+ */
+func EmitDelayed[T any](dispatcher *MleEventDispatcher, event *EventType[T], payload T, priority int) bool {
+	return dispatcher.ProcessEventWithPriority(event.m_id, NewTypedCallData(payload), MLE_EVENT_DELAYED, priority)
+}
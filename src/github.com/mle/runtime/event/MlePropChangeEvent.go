@@ -41,7 +41,7 @@ package event
 
 // Import Magic Lantern packages.
 import (
-	mle_util "github.com/mle/runtime/util"
+	mle_core "github.com/mle/runtime/core"
 )
 
 /**
@@ -49,24 +49,14 @@ import (
  * <p>
  * This class is used by <code>IMleObject</code>s (i.e. <code>Actor</code>s and
  * <code>Set</code>s) to notify <code>IMlePropChangeListeners</code> of changes
- * made to their properties.
+ * made to their properties. It is declared in the core package, which fires it.
  * </p>
  *
+ * @see mle_core.MlePropChangeEvent
+ *
  * @author Mark S. Millard
  */
-type MlePropChangeEvent struct {
-	/** Common event data. */
-	m_event MleEvent
-	/** The source that caused this event to be fired. */
-	m_source *mle_util.Object
-
-	/** The name of the property. */
-	m_name string
-	/** The old value of the property. */
-	m_oldValue interface{}
-	/** The new value of the property. */
-	m_newValue interface{}
-}
+type MlePropChangeEvent = mle_core.MlePropChangeEvent
 
 /**
  * Constructs a new <code>MlePropChangeEvent.</code>
@@ -76,42 +66,6 @@ type MlePropChangeEvent struct {
  * @param oldValue The old value of the property.
  * @param newValue The new value of the property.
  */
-func NewMlePropChangeEvent(source *mle_util.Object, propertyName string, oldValue interface{}, newValue interface{}) *MlePropChangeEvent {
-	p := new(MlePropChangeEvent)
-	p.m_source = source
-	p.m_name = propertyName
-	p.m_oldValue = oldValue
-	p.m_newValue = newValue
-	return p
-}
-
-/**
- * Gets the name of the property that was changed.
- *
- * @return The name of the property that was changed is returned.
- */
-func (event *MlePropChangeEvent) GetPropertyName() string {
-	return event.m_name
-}
-
-/**
- * Gets the old value for the property, expressed as an <code>Object</code>.
- *
- * @return The old value for the property, expressed as an <code>Object</code>
- * is returned. May be <b>null</b> if multiple values have changed, as in a
- * property array.
- */
-func (event *MlePropChangeEvent) GetOldValue() interface{} {
-	return event.m_oldValue
-}
-
-/**
- * Gets the new value for the property, expressed as an <code>Object</code>.
- *
- * @return The new value for the property, expressed as an <code>Object</code>
- * is returned. May be <b>null</b> if multiple values have changed, as in a
- * property array.
- */
-func (event *MlePropChangeEvent) GetNewValue() interface{} {
-	return event.m_newValue
+func NewMlePropChangeEvent(source interface{}, propertyName string, oldValue interface{}, newValue interface{}) *MlePropChangeEvent {
+	return mle_core.NewMlePropChangeEvent(source, propertyName, oldValue, newValue)
 }
@@ -0,0 +1,173 @@
+/**
+ * @file MlePropChangeRouter.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package event
+
+// Import Magic Lantern packages.
+import (
+	mle_core "github.com/mle/runtime/core"
+	mle_util "github.com/mle/runtime/util"
+)
+
+// The call data of a routed property change event.
+type _PropChangeCallData struct {
+	m_router  *MlePropChangeRouter
+	m_event   *mle_core.MlePropChangeEvent
+	m_deliver func(event *mle_core.MlePropChangeEvent)
+}
+
+// String implements IObject interface.
+func (data *_PropChangeCallData) String() string {
+	return data.m_event.String()
+}
+
+// The callback which delivers routed property change events.
+type _PropChangeCallback struct {
+	MleEventCallback
+	m_router *MlePropChangeRouter
+}
+
+// Dispatch implements IMleEventCallback interface.
+func (cb *_PropChangeCallback) Dispatch(event MleEvent, clientdata mle_util.IObject) bool {
+	data, ok := event.GetCallData().(*_PropChangeCallData)
+	if !ok || (data.m_router != cb.m_router) {
+		// Not posted by this router.
+		return false
+	}
+	data.m_deliver(data.m_event)
+	return true
+}
+
+/**
+ * <code>MlePropChangeRouter</code> routes property change events through
+ * a <code>MleEventDispatcher</code>.
+ * <p>
+ * Set the router as the property change dispatcher of an <code>Actor</code>
+ * or <code>Set</code>. Each property change is then queued as a delayed
+ * event and delivered to the property change listeners when the event
+ * dispatcher's <code>DispatchEvents()</code> method is called.
+ * </p>
+ *
+ * @see mle_core.MleActor#SetPropertyChangeDispatcher
+ * @see mle_core.MleSet#SetPropertyChangeDispatcher
+ *
+ * @author Mark S. Millard
+ */
+type MlePropChangeRouter struct {
+	/** The event dispatcher. */
+	m_dispatcher *MleEventDispatcher
+	/** The composite event identifier used for property changes. */
+	m_event int
+	/** The dispatch priority of the queued events. */
+	m_priority int
+	/** The identifier of the installed callback. */
+	m_id mle_core.IMleCallbackId
+}
+
+/**
+ * Constructs a new <code>MlePropChangeRouter</code>, installing a callback
+ * for the specified event.
+ *
+ * @param dispatcher The event dispatcher to route through.
+ * @param event The composite event identifier used for property changes.
+ *
+ * @return A new router is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the callback
+ * can not be installed.
+ */
+func NewMlePropChangeRouter(dispatcher *MleEventDispatcher, event int) (*MlePropChangeRouter, *mle_core.MleError) {
+	if dispatcher == nil {
+		return nil, mle_core.NewMleError("MlePropChangeRouter: dispatcher must not be nil.", 0, nil)
+	}
+	p := new(MlePropChangeRouter)
+	p.m_dispatcher = dispatcher
+	p.m_event = event
+
+	cb := new(_PropChangeCallback)
+	cb.Enable(true)
+	cb.m_router = p
+	id, err := dispatcher.InstallEventCB(event, cb, nil)
+	if err != nil {
+		return nil, err
+	}
+	p.m_id = id
+	return p, nil
+}
+
+/**
+ * Get the composite event identifier used for property changes.
+ *
+ * @return The event identifier is returned.
+ */
+func (router *MlePropChangeRouter) GetEvent() int {
+	return router.m_event
+}
+
+/**
+ * Set the dispatch priority of the queued events.
+ *
+ * @param priority The event dispatch priority.
+ */
+func (router *MlePropChangeRouter) SetPriority(priority int) {
+	router.m_priority = priority
+}
+
+/**
+ * Post a property change event as a delayed event.
+ *
+ * @param event The property change event.
+ * @param deliver The function which delivers the event to the listeners.
+ *
+ * @return <b>true</b> is returned if the event was queued.
+ */
+func (router *MlePropChangeRouter) PostPropChange(event *mle_core.MlePropChangeEvent, deliver func(event *mle_core.MlePropChangeEvent)) bool {
+	data := &_PropChangeCallData{router, event, deliver}
+	return router.m_dispatcher.ProcessEventWithPriority(router.m_event, data, MLE_EVENT_DELAYED, router.m_priority)
+}
+
+/**
+ * Uninstall the router's callback. Queued events which have not yet been
+ * dispatched will not be delivered.
+ *
+ * @return <b>true</b> is returned if the callback was uninstalled.
+ */
+func (router *MlePropChangeRouter) Dispose() bool {
+	return router.m_dispatcher.UninstallEventCB(router.m_event, router.m_id)
+}
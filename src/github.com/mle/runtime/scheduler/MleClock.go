@@ -0,0 +1,92 @@
+/**
+ * @file MleClock.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package scheduler
+
+// Import go packages.
+import (
+	"time"
+)
+
+/**
+ * <code>MleClock</code> is the source of time used by the
+ * <code>MleFrameLoop</code> and <code>MleScheduler</code>. A clock may be
+ * substituted so that they can be driven deterministically.
+ *
+ * @see MleFrameLoop
+ * @see MleScheduler
+ */
+type MleClock interface {
+	/**
+	 * Get the current time.
+	 *
+	 * @return The current time is returned.
+	 */
+	Now() time.Time
+
+	/**
+	 * Pause the calling goroutine for the specified duration.
+	 *
+	 * @param d The duration to sleep.
+	 */
+	Sleep(d time.Duration)
+}
+
+/**
+ * <code>MleSystemClock</code> is a <code>MleClock</code> using the
+ * system time.
+ */
+type MleSystemClock struct{}
+
+/**
+ * Creates a new MleSystemClock.
+ */
+func NewMleSystemClock() *MleSystemClock {
+	return new(MleSystemClock)
+}
+
+// Now implements the MleClock interface.
+func (c *MleSystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep implements the MleClock interface.
+func (c *MleSystemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
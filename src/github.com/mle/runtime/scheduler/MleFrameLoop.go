@@ -0,0 +1,596 @@
+/**
+ * @file MleFrameLoop.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package scheduler
+
+// Import go packages.
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_event "github.com/mle/runtime/event"
+	mle_util "github.com/mle/runtime/util"
+)
+
+/** Frames are run with a fixed timestep, catching up when behind. */
+const MLE_FRAME_FIXED_TIMESTEP int = 1
+
+/** Frames are run with the measured time since the previous frame. */
+const MLE_FRAME_VARIABLE_TIMESTEP int = 2
+
+/** The default maximum number of frames run to catch up, per iteration. */
+const MLE_FRAME_DEFAULT_MAX_STEPS int = 5
+
+/**
+ * <code>MleFrameStats</code> holds the timing statistics of a
+ * <code>MleFrameLoop</code>.
+ */
+type MleFrameStats struct {
+	// The number of frames run.
+	m_frames int64
+	// The number of frames which took longer than the frame period.
+	m_overruns int64
+	// The number of fixed timesteps dropped because the loop fell too far behind.
+	m_dropped int64
+	// The timestep of the last frame.
+	m_delta time.Duration
+	// The time taken to run the last frame.
+	m_lastFrameTime time.Duration
+	// The longest time taken to run a frame.
+	m_maxFrameTime time.Duration
+	// The total time taken to run all frames.
+	m_totalFrameTime time.Duration
+}
+
+/**
+ * Get the number of frames run.
+ *
+ * @return The frame count is returned.
+ */
+func (stats MleFrameStats) GetFrameCount() int64 {
+	return stats.m_frames
+}
+
+/**
+ * Get the number of frames which took longer than the frame period.
+ *
+ * @return The overrun count is returned.
+ */
+func (stats MleFrameStats) GetOverrunCount() int64 {
+	return stats.m_overruns
+}
+
+/**
+ * Get the number of fixed timesteps dropped because the loop fell more than
+ * the maximum number of catch up steps behind.
+ *
+ * @return The dropped frame count is returned.
+ */
+func (stats MleFrameStats) GetDroppedFrameCount() int64 {
+	return stats.m_dropped
+}
+
+/**
+ * Get the timestep of the last frame.
+ *
+ * @return The timestep is returned.
+ */
+func (stats MleFrameStats) GetDeltaTime() time.Duration {
+	return stats.m_delta
+}
+
+/**
+ * Get the time taken to run the last frame.
+ *
+ * @return The frame time is returned.
+ */
+func (stats MleFrameStats) GetLastFrameTime() time.Duration {
+	return stats.m_lastFrameTime
+}
+
+/**
+ * Get the longest time taken to run a frame.
+ *
+ * @return The maximum frame time is returned.
+ */
+func (stats MleFrameStats) GetMaxFrameTime() time.Duration {
+	return stats.m_maxFrameTime
+}
+
+/**
+ * Get the average time taken to run a frame.
+ *
+ * @return The average frame time is returned, or 0 if no frame has run.
+ */
+func (stats MleFrameStats) GetAverageFrameTime() time.Duration {
+	if stats.m_frames == 0 {
+		return 0
+	}
+	return stats.m_totalFrameTime / time.Duration(stats.m_frames)
+}
+
+// The callback which stops the frame loop when MLE_QUIT is dispatched.
+type _FrameLoopQuitCallback struct {
+	mle_event.MleEventCallback
+	m_loop *MleFrameLoop
+}
+
+// Dispatch implements IMleEventCallback interface.
+func (cb *_FrameLoopQuitCallback) Dispatch(event mle_event.MleEvent, clientdata mle_util.IObject) bool {
+	cb.m_loop.Stop()
+	return true
+}
+
+/**
+ * The <code>MleFrameLoop</code> class drives a <code>MleScheduler</code>
+ * at a target frame rate. Each frame runs every scheduled phase once.
+ * <p>
+ * In fixed timestep mode, every frame advances the title by the frame
+ * period; when the loop falls behind, up to a maximum number of frames are
+ * run back to back to catch up and the remaining timesteps are dropped.
+ * In variable timestep mode, one frame is run per period and its timestep
+ * is the measured time since the previous frame.
+ * </p><p>
+ * The loop exits when its context is done, when <code>Stop</code> is
+ * called, when it is Ok to exit (see <code>mle_event.OkToExit</code>)
+ * or when the <code>MLE_QUIT</code> event is dispatched by the event
+ * dispatcher set with <code>SetEventDispatcher</code>.
+ * </p>
+ *
+ * @see MleScheduler
+ * @see MleClock
+ */
+type MleFrameLoop struct {
+	// The scheduler run each frame.
+	m_scheduler *MleScheduler
+	// The time between frames.
+	m_period time.Duration
+	// The timestep mode.
+	m_mode int
+	// The maximum number of frames run to catch up, per iteration.
+	m_maxSteps int
+	// The source of time.
+	m_clock MleClock
+	// The event dispatcher whose events are dispatched each iteration, may be nil.
+	m_dispatcher *mle_event.MleEventDispatcher
+	// The identifier of the MLE_QUIT callback.
+	m_quitId mle_core.IMleCallbackId
+	// The recorder told the frame number of each frame, may be nil.
+	m_recorder *mle_event.MleEventRecorder
+	// The player replaying events at the start of each frame, may be nil.
+	m_player *mle_event.MleEventPlayer
+	// The timing statistics.
+	m_stats MleFrameStats
+	// Flag indicating that the loop is running.
+	m_running bool
+	// Flag indicating that the loop should stop.
+	m_stop bool
+	// Flag indicating that the loop is paused.
+	m_paused bool
+	// The number of frames requested while paused.
+	m_steps int
+	// Internal lock used for protecting sensitve code.
+	lock sync.Mutex
+}
+
+/**
+ * Creates a new MleFrameLoop in fixed timestep mode.
+ *
+ * @param scheduler The scheduler to run each frame.
+ * @param rate The target frame rate, in frames per second.
+ *
+ * @return A new frame loop is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the rate is not
+ * positive, or is too high for a frame period of at least a nanosecond.
+ */
+func NewMleFrameLoop(scheduler *MleScheduler, rate float64) (*MleFrameLoop, *mle_core.MleError) {
+	p := new(MleFrameLoop)
+	p.m_scheduler = scheduler
+	p.m_mode = MLE_FRAME_FIXED_TIMESTEP
+	p.m_maxSteps = MLE_FRAME_DEFAULT_MAX_STEPS
+	p.m_clock = NewMleSystemClock()
+	if err := p.SetFrameRate(rate); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+/**
+ * Set the target frame rate.
+ *
+ * @param rate The target frame rate, in frames per second.
+ *
+ * @return An error is returned, and the frame rate is unchanged, if the
+ * rate is not positive or is too high for a frame period of at least a
+ * nanosecond.
+ */
+func (loop *MleFrameLoop) SetFrameRate(rate float64) *mle_core.MleError {
+	// Written so that NaN is rejected too.
+	if !(rate > 0) || !(float64(time.Second) / rate >= 1) {
+		return mle_core.NewMleError("MleFrameLoop: invalid frame rate "+strconv.FormatFloat(rate, 'g', -1, 64)+".", 0, nil)
+	}
+	loop.lock.Lock()
+	loop.m_period = time.Duration(float64(time.Second) / rate)
+	loop.lock.Unlock()
+	return nil
+}
+
+/**
+ * Get the time between frames.
+ *
+ * @return The frame period is returned.
+ */
+func (loop *MleFrameLoop) GetFramePeriod() time.Duration {
+	loop.lock.Lock()
+	defer loop.lock.Unlock()
+	return loop.m_period
+}
+
+/**
+ * Set the timestep mode.
+ *
+ * @param mode Either <code>MLE_FRAME_FIXED_TIMESTEP</code> or
+ * <code>MLE_FRAME_VARIABLE_TIMESTEP</code>.
+ *
+ * @return An error is returned if the mode is unknown.
+ */
+func (loop *MleFrameLoop) SetMode(mode int) *mle_core.MleError {
+	if (mode != MLE_FRAME_FIXED_TIMESTEP) && (mode != MLE_FRAME_VARIABLE_TIMESTEP) {
+		return mle_core.NewMleError("MleFrameLoop: unknown timestep mode.", 0, nil)
+	}
+	loop.lock.Lock()
+	loop.m_mode = mode
+	loop.lock.Unlock()
+	return nil
+}
+
+/**
+ * Set the maximum number of frames run back to back to catch up in
+ * fixed timestep mode.
+ *
+ * @param steps The maximum number of frames, at least 1.
+ */
+func (loop *MleFrameLoop) SetMaxCatchUpSteps(steps int) {
+	if steps < 1 {
+		steps = 1
+	}
+	loop.lock.Lock()
+	loop.m_maxSteps = steps
+	loop.lock.Unlock()
+}
+
+/**
+ * Set the source of time. The clock is also used by the scheduler for
+ * the frame time, and by the event dispatcher for timed events. The clock
+ * must not be changed while the loop is running.
+ *
+ * @param clock The clock. If <b>nil</b>, the system clock is used.
+ */
+func (loop *MleFrameLoop) SetClock(clock MleClock) {
+	if clock == nil {
+		clock = NewMleSystemClock()
+	}
+	loop.lock.Lock()
+	loop.m_clock = clock
+	dispatcher := loop.m_dispatcher
+	loop.lock.Unlock()
+	loop.m_scheduler.SetClock(clock)
+	if dispatcher != nil {
+		dispatcher.SetClock(clock)
+	}
+}
+
+/**
+ * Set the event dispatcher. Its delayed events are dispatched once per
+ * loop iteration, and the loop stops when it dispatches <code>MLE_QUIT</code>.
+ * Its timed events use the loop's clock, and its frame is set to the number
+ * of frames run so far before events are dispatched and before each frame.
+ *
+ * @param dispatcher The event dispatcher, or <b>nil</b> for none.
+ *
+ * @return An error is returned if the <code>MLE_QUIT</code> callback can
+ * not be installed.
+ */
+func (loop *MleFrameLoop) SetEventDispatcher(dispatcher *mle_event.MleEventDispatcher) *mle_core.MleError {
+	loop.lock.Lock()
+	defer loop.lock.Unlock()
+
+	if loop.m_dispatcher != nil {
+		loop.m_dispatcher.UninstallEventCB(mle_event.MLE_QUIT, loop.m_quitId)
+		loop.m_dispatcher = nil
+		loop.m_quitId = nil
+	}
+	if dispatcher != nil {
+		cb := new(_FrameLoopQuitCallback)
+		cb.Enable(true)
+		cb.m_loop = loop
+		id, err := dispatcher.InstallEventCB(mle_event.MLE_QUIT, cb, nil)
+		if err != nil {
+			return err
+		}
+		dispatcher.SetClock(loop.m_clock)
+		loop.m_dispatcher = dispatcher
+		loop.m_quitId = id
+	}
+	return nil
+}
+
+/**
+ * Set the event recorder. Before each frame runs, the recorder's frame is
+ * set to the number of frames run so far.
+ *
+ * @param recorder The event recorder, or <b>nil</b> for none.
+ */
+func (loop *MleFrameLoop) SetEventRecorder(recorder *mle_event.MleEventRecorder) {
+	loop.lock.Lock()
+	loop.m_recorder = recorder
+	loop.lock.Unlock()
+}
+
+/**
+ * Set the event player. Before each frame runs, the player replays the
+ * events recorded in the frame with the same number.
+ *
+ * @param player The event player, or <b>nil</b> for none.
+ */
+func (loop *MleFrameLoop) SetEventPlayer(player *mle_event.MleEventPlayer) {
+	loop.lock.Lock()
+	loop.m_player = player
+	loop.lock.Unlock()
+}
+
+/**
+ * Get the timing statistics.
+ *
+ * @return A copy of the statistics is returned.
+ */
+func (loop *MleFrameLoop) GetStats() MleFrameStats {
+	loop.lock.Lock()
+	defer loop.lock.Unlock()
+	return loop.m_stats
+}
+
+/**
+ * Pause the loop. While paused, no frames are run, but events are still
+ * dispatched and exit requests are honored.
+ */
+func (loop *MleFrameLoop) Pause() {
+	loop.lock.Lock()
+	loop.m_paused = true
+	loop.lock.Unlock()
+}
+
+/**
+ * Resume the paused loop. Time spent paused is not caught up.
+ */
+func (loop *MleFrameLoop) Resume() {
+	loop.lock.Lock()
+	loop.m_paused = false
+	loop.m_steps = 0
+	loop.lock.Unlock()
+}
+
+/**
+ * Determine whether the loop is paused.
+ *
+ * @return <b>true</b> is returned if the loop is paused.
+ */
+func (loop *MleFrameLoop) IsPaused() bool {
+	loop.lock.Lock()
+	defer loop.lock.Unlock()
+	return loop.m_paused
+}
+
+/**
+ * Run a single frame, with a timestep of one frame period, while the
+ * loop is paused. Nothing is done if the loop is not paused.
+ */
+func (loop *MleFrameLoop) Step() {
+	loop.lock.Lock()
+	if loop.m_paused {
+		loop.m_steps++
+	}
+	loop.lock.Unlock()
+}
+
+/**
+ * Stop the loop. The loop exits once the current frame has completed.
+ */
+func (loop *MleFrameLoop) Stop() {
+	loop.lock.Lock()
+	loop.m_stop = true
+	loop.lock.Unlock()
+}
+
+/**
+ * Runs frames until the loop is asked to exit.
+ *
+ * @param ctx The context used to cancel the loop and its running tasks.
+ *
+ * @return <b>nil</b> is returned if the loop exited because it was stopped
+ * or it is Ok to exit. Otherwise, an error is returned.
+ */
+func (loop *MleFrameLoop) Run(ctx context.Context) *mle_core.MleError {
+	loop.lock.Lock()
+	if loop.m_running {
+		loop.lock.Unlock()
+		return mle_core.NewMleError("MleFrameLoop: loop is already running.", 0, nil)
+	}
+	loop.m_running = true
+	loop.m_stop = false
+	clock := loop.m_clock
+	loop.lock.Unlock()
+
+	defer func() {
+		loop.lock.Lock()
+		loop.m_running = false
+		loop.lock.Unlock()
+	}()
+
+	last := clock.Now()
+	// Start with a full timestep so the first frame runs immediately.
+	accumulator := loop.GetFramePeriod()
+	for {
+		if ctx.Err() != nil {
+			return mle_core.NewMleError("MleFrameLoop: loop cancelled.", 0, ctx.Err())
+		}
+		if dispatcher := loop.getDispatcher(); dispatcher != nil {
+			dispatcher.SetFrame(loop.GetStats().GetFrameCount())
+			dispatcher.DispatchEvents()
+		}
+		if loop.isStopped() {
+			return nil
+		}
+
+		loop.lock.Lock()
+		period := loop.m_period
+		mode := loop.m_mode
+		maxSteps := loop.m_maxSteps
+		paused := loop.m_paused
+		step := paused && (loop.m_steps > 0)
+		if step {
+			loop.m_steps--
+		}
+		loop.lock.Unlock()
+		if period <= 0 {
+			return mle_core.NewMleError("MleFrameLoop: frame rate is not set.", 0, nil)
+		}
+
+		start := clock.Now()
+		elapsed := start.Sub(last)
+		last = start
+
+		var err *mle_core.MleError
+		if paused {
+			accumulator = 0
+			if step {
+				err = loop.runFrame(ctx, clock, period, period)
+			}
+		} else if mode == MLE_FRAME_FIXED_TIMESTEP {
+			accumulator += elapsed
+			for steps := 0; (accumulator >= period) && (steps < maxSteps) && (err == nil); steps++ {
+				err = loop.runFrame(ctx, clock, period, period)
+				accumulator -= period
+			}
+			if accumulator >= period {
+				// Too far behind to catch up, drop the remaining timesteps.
+				dropped := int64(accumulator / period)
+				accumulator %= period
+				loop.lock.Lock()
+				loop.m_stats.m_dropped += dropped
+				loop.lock.Unlock()
+			}
+		} else {
+			err = loop.runFrame(ctx, clock, elapsed, period)
+		}
+		if err != nil {
+			return err
+		}
+		if loop.isStopped() {
+			return nil
+		}
+
+		// Wait for the next frame.
+		if spent := clock.Now().Sub(start); spent < period {
+			clock.Sleep(period - spent)
+		}
+	}
+}
+
+// Run one frame and update the statistics.
+func (loop *MleFrameLoop) runFrame(ctx context.Context, clock MleClock, delta time.Duration, period time.Duration) *mle_core.MleError {
+	loop.lock.Lock()
+	loop.m_stats.m_delta = delta
+	frame := loop.m_stats.m_frames
+	dispatcher := loop.m_dispatcher
+	recorder := loop.m_recorder
+	player := loop.m_player
+	loop.lock.Unlock()
+
+	if dispatcher != nil {
+		dispatcher.SetFrame(frame)
+	}
+	// Replayed events are recorded in the frame they are replayed in.
+	if recorder != nil {
+		recorder.SetFrame(frame)
+	}
+	if player != nil {
+		player.ReplayFrame(frame)
+	}
+
+	start := clock.Now()
+	err := loop.m_scheduler.RunFrame(ctx)
+	frameTime := clock.Now().Sub(start)
+
+	loop.lock.Lock()
+	loop.m_stats.m_frames++
+	loop.m_stats.m_lastFrameTime = frameTime
+	loop.m_stats.m_totalFrameTime += frameTime
+	if frameTime > loop.m_stats.m_maxFrameTime {
+		loop.m_stats.m_maxFrameTime = frameTime
+	}
+	if frameTime > period {
+		loop.m_stats.m_overruns++
+	}
+	loop.lock.Unlock()
+
+	if (err != nil) && (ctx.Err() == nil) {
+		// A task aborted the frame; the failure has been reported, continue with the next frame.
+		return nil
+	}
+	return err
+}
+
+// Get the event dispatcher.
+func (loop *MleFrameLoop) getDispatcher() *mle_event.MleEventDispatcher {
+	loop.lock.Lock()
+	defer loop.lock.Unlock()
+	return loop.m_dispatcher
+}
+
+// Determine whether the loop has been asked to exit.
+func (loop *MleFrameLoop) isStopped() bool {
+	loop.lock.Lock()
+	defer loop.lock.Unlock()
+	return loop.m_stop || mle_event.OkToExit()
+}
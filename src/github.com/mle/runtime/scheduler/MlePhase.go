@@ -42,7 +42,11 @@ package scheduler
 // Import go packages.
 import (
 	"bytes"
+	"context"
+	"runtime/pprof"
+	"strconv"
 	"sync"
+	"time"
 
 	mle_util "github.com/mle/runtime/util"
 	mle_core "github.com/mle/runtime/core"
@@ -72,6 +76,14 @@ type MlePhase struct {
 	m_tasks *mle_util.Vector
 	// The name of the phase.
 	m_name string
+	// The worker pool overriding the scheduler's pool, may be nil.
+	m_pool *MleWorkerPool
+	// Flag indicating that the tasks run serially on a single worker.
+	m_serial bool
+	// The frames on which the scheduler runs the phase.
+	m_schedule _RunSchedule
+	// The number of times the phase has been run on its own.
+	m_runs int64
 	// Internal lock used for protecting sensitve code.
 	lock sync.Mutex
 }
@@ -121,6 +133,101 @@ func (p *MlePhase) GetName() string {
 	return p.m_name
 }
 	 
+/**
+ * Enable or disable this phase. The scheduler skips a disabled phase.
+ *
+ * @param enabled <b>true</b> to enable the phase; <b>false</b> to disable it.
+ */
+func (p *MlePhase) SetEnabled(enabled bool) {
+	p.lock.Lock()
+	p.m_schedule.m_disabled = !enabled
+	p.lock.Unlock()
+}
+
+/**
+ * Determine whether this phase is enabled.
+ *
+ * @return <b>true</b> is returned if the phase is enabled.
+ */
+func (p *MlePhase) IsEnabled() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return !p.m_schedule.m_disabled
+}
+
+/**
+ * Have the scheduler run this phase every <i>interval</i> frames, starting
+ * on frame <i>offset</i>. Frames are counted by the scheduler from 0.
+ *
+ * @param interval The number of frames between runs; 1 runs every frame.
+ * @param offset The first frame run.
+ *
+ * @throws MleRuntimeException This exception is thrown if the interval
+ * is not positive or the offset is negative.
+ *
+ * @see MleTask#SetFrameInterval
+ */
+func (p *MlePhase) SetFrameInterval(interval int, offset int) *mle_core.MleError {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.m_schedule.setFrameInterval(interval, offset)
+}
+
+/**
+ * Get the number of frames between runs of this phase.
+ *
+ * @return The interval and offset are returned.
+ */
+func (p *MlePhase) GetFrameInterval() (int, int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.m_schedule.m_interval == 0 {
+		return 1, p.m_schedule.m_offset
+	}
+	return p.m_schedule.m_interval, p.m_schedule.m_offset
+}
+
+/**
+ * Have the scheduler run this phase at most once every <i>period</i>,
+ * measured by the frame time of the scheduler.
+ *
+ * @param period The minimum time between runs; 0 runs regardless of time.
+ */
+func (p *MlePhase) SetTimeInterval(period time.Duration) {
+	p.lock.Lock()
+	p.m_schedule.m_period = period
+	p.lock.Unlock()
+}
+
+/**
+ * Get the minimum time between runs of this phase.
+ *
+ * @return The period is returned.
+ */
+func (p *MlePhase) GetTimeInterval() time.Duration {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.m_schedule.m_period
+}
+
+/**
+ * Set the condition which must hold for the scheduler to run this phase.
+ *
+ * @param condition The condition. If <b>nil</b>, the phase runs whenever
+ * it is due.
+ */
+func (p *MlePhase) SetRunCondition(condition func() bool) {
+	p.lock.Lock()
+	p.m_schedule.m_condition = condition
+	p.lock.Unlock()
+}
+
+// Determine whether the phase runs on the specified frame, recording the
+// run if it does.
+func (p *MlePhase) shouldRun(frame int64, now time.Time) bool {
+	return p.m_schedule.shouldRun(&p.lock, frame, now)
+}
+
 /**
  * Get the number of registered tasks for this phase.
  *
@@ -134,6 +241,11 @@ func (p *MlePhase) GetNumberOfTasks() int	 {
 
 /**
  * Add a task to this phase.
+ * <p>
+ * The task's dependencies on other tasks of this phase determine the
+ * order in which the tasks run. The task is not added if its
+ * dependencies would form a cycle.
+ * </p>
  *
  * @param task An instance of the MleTask class.
  *
@@ -145,8 +257,14 @@ func (p *MlePhase) GetNumberOfTasks() int	 {
  */
 func (p *MlePhase) AddTask(task *MleTask) bool {
 	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	tasks := append(p.getTasks(), task)
+	if _, err := _NewTaskGraph(tasks); err != nil {
+		mle_core.MleLogError("MlePhase: unable to add task to phase "+p.m_name+": "+err.What, false)
+		return false
+	}
 	p.m_tasks.AddElement(task)
-	p.lock.Unlock()
 	return true
 }
 	 
@@ -284,34 +402,302 @@ func (p *MlePhase) GetTaskWithName(name string) *MleTask {
 /**
  * Executes the tasks registered with this phase. <code>Run</code>
  * will not return until all tasks have been completed.
+ *
+ * @param done If not <b>nil</b>, the channel is signaled when
+ * the tasks have been completed.
  */
 func (p *MlePhase) Run(done chan bool) {
+	p.RunContext(context.Background())
+	if done != nil {
+		done <- true
+	}
+}
+
+/**
+ * Executes the tasks registered with this phase, propagating the
+ * cancellation of <i>ctx</i> to them. <code>RunContext</code>
+ * will not return until all tasks that were invoked have been completed.
+ * No task is invoked if <i>ctx</i> is already done. Failed tasks
+ * are logged.
+ * <p>
+ * Run on its own, a phase counts its runs as frames, which determine the
+ * tasks that are due. The phase's own schedule only applies when it is run
+ * by the scheduler.
+ * </p>
+ *
+ * @param ctx The context used to cancel the tasks.
+ *
+ * @return <b>nil</b> is returned if the phase ran to completion.
+ * Otherwise, an error wrapping the context's error, or the failure of a
+ * task with the <code>MLE_TASK_FAILURE_ABORT_FRAME</code> policy, is returned.
+ */
+func (p *MlePhase) RunContext(ctx context.Context) *mle_core.MleError {
+	p.lock.Lock()
+	env := &_PhaseEnv{m_frameIndex: p.m_runs, m_frameTime: time.Now()}
+	p.m_runs++
+	p.lock.Unlock()
+	return p.runContext(ctx, env)
+}
+
+// _PhaseEnv holds the state of the scheduler a phase runs with.
+type _PhaseEnv struct {
+	// The worker pool running the tasks, may be nil.
+	m_pool *MleWorkerPool
+	// The function reporting task failures, may be nil.
+	m_report func(task *MleTask, err *mle_core.MleError)
+	// The profiler recording the phase, may be nil.
+	m_profiler *MleProfiler
+	// The frame being recorded by the profiler.
+	m_frame *MleFrameProfile
+	// The index of the frame, counted from 0.
+	m_frameIndex int64
+	// The time the frame started.
+	m_frameTime time.Time
+}
+
+// _TaskResult is the completion of a task run by a phase.
+type _TaskResult struct {
+	// The completed task.
+	m_task *MleTask
+	// Flag indicating that the task ran, rather than being skipped.
+	m_ran bool
+	// The error of the task, nil if it succeeded or was skipped.
+	m_err error
+}
+
+// Execute the tasks, on the phase's worker pool if it has one, otherwise
+// on the pool of the environment. If there is no pool, each task runs on its
+// own thread. Task failures are passed to the report function of the
+// environment, or logged if it has none.
+func (p *MlePhase) runContext(ctx context.Context, env *_PhaseEnv) *mle_core.MleError {
+	if ctx.Err() != nil {
+		return mle_core.NewMleError("MlePhase: phase "+p.m_name+" not run.", 0, ctx.Err())
+	}
+
 	var buf bytes.Buffer
 	buf.WriteString("*** EXECTUING PHASE ")
 	buf.WriteString(p.m_name)
 	buf.WriteString(" ***")
 	mle_core.MleLogInfo(buf.String(), false)
-		 
-	/* Invoke tasks which have been registered. */
-	for i := 0; i < len(*p.m_tasks); i++	{
-		task := p.m_tasks.ElementAt(i).(*MleTask)
-		task.Invoke()
+
+	/* Take a snapshot of the tasks so they may be modified while running. */
+	tasks := p.copyTasks()
+	graph, err := _NewTaskGraph(tasks)
+	if err != nil {
+		return mle_core.NewMleError("MlePhase: phase "+p.m_name+" not run: "+err.What, 0, nil)
+	}
+	pool := env.m_pool
+	if phasePool := p.acquireWorkerPool(); phasePool != nil {
+		defer phasePool.release()
+		pool = phasePool
+	}
+
+	/* A task failing with the abort frame policy cancels the remaining tasks. */
+	phaseCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var aborted *mle_core.MleError
+
+	profiler := env.m_profiler
+	phaseStart := time.Now()
+	if profiler != nil {
+		phaseCtx = pprof.WithLabels(phaseCtx, pprof.Labels("mle_phase", p.m_name))
+	}
+
+	/*
+	 * Invoke tasks which have been registered. Each task is invoked once the
+	 * tasks it depends on have completed, so independent tasks run concurrently.
+	 */
+	pending := make(map[*MleTask]int, len(tasks))
+	for _, task := range tasks {
+		pending[task] = len(graph.m_dependencies[task])
+	}
+	completed := make(chan _TaskResult, len(tasks))
+	launch := func(task *MleTask) {
+		if (phaseCtx.Err() != nil) || !task.shouldRun(env.m_frameIndex, env.m_frameTime) {
+			// Cancelled, disabled or not due, skip the task.
+			completed <- _TaskResult{task, false, nil}
+		} else if pool != nil {
+			onDone := func(err error) { completed <- _TaskResult{task, true, err} }
+			if err := task.invokeOnPool(phaseCtx, pool, onDone); err != nil {
+				mle_core.MleLogError("MlePhase: task "+_TaskLabel(task)+" not run: "+err.What, false)
+				completed <- _TaskResult{task, false, nil}
+			}
+		} else {
+			task.InvokeContext(phaseCtx)
+			go func() {
+				task.Wait()
+				completed <- _TaskResult{task, true, task.GetLastError()}
+			}()
+		}
 	}
-		 
+	for _, task := range graph.m_order {
+		if pending[task] == 0 {
+			launch(task)
+		}
+	}
+
 	/* Wait for all tasks to complete before returning */
-	tasksCompleted := false
-	waitForTaskCompletion:
-	for ! tasksCompleted {
-		for i := 0; i < len(*p.m_tasks); i++	{
-			task := p.m_tasks.ElementAt(i).(*MleTask)
-			if (task.IsRunning()) {
-				continue waitForTaskCompletion
+	for remaining := len(tasks); remaining > 0; remaining-- {
+		result := <-completed
+		if (profiler != nil) && result.m_ran {
+			start := result.m_task.GetLastStartTime()
+			if !start.IsZero() {
+				profiler.recordTask(env.m_frame, p.GetName(), _TaskLabel(result.m_task),
+					start, result.m_task.GetLastEndTime(), result.m_err != nil)
+			}
+		}
+		if result.m_err != nil {
+			failure := p.taskError(result.m_task, result.m_err)
+			if env.m_report != nil {
+				env.m_report(result.m_task, failure)
+			} else {
+				mle_core.MleLogError(failure.What, false)
+			}
+			policy, _ := result.m_task.GetFailurePolicy()
+			if (policy == MLE_TASK_FAILURE_ABORT_FRAME) && (aborted == nil) {
+				aborted = mle_core.NewMleError("MlePhase: phase "+p.m_name+" aborted: "+failure.What, 0, failure)
+				cancel()
+			}
+		}
+		for _, dependent := range graph.m_dependents[result.m_task] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				launch(dependent)
 			}
 		}
-	    tasksCompleted = true;
 	}
-} 
- 
+
+	if profiler != nil {
+		profiler.recordPhase(env.m_frame, p.GetName(), phaseStart, time.Now())
+	}
+
+	if ctx.Err() != nil {
+		return mle_core.NewMleError("MlePhase: phase "+p.m_name+" cancelled.", 0, ctx.Err())
+	}
+	return aborted
+}
+
+// Create the error reported for a failed task, naming the task and phase.
+func (p *MlePhase) taskError(task *MleTask, err error) *mle_core.MleError {
+	msg := "MlePhase: task " + _TaskLabel(task) + " in phase " + p.GetName() +
+		" failed after " + strconv.Itoa(task.GetAttempts()) + " attempt(s): " + err.Error()
+	return mle_core.NewMleError(msg, 0, err)
+}
+
+/**
+ * Set the number of workers running the tasks of this phase, overriding
+ * the worker pool of the scheduler.
+ * <p>
+ * The new pool is used the next time the phase runs; a running phase keeps
+ * the previous pool, which is closed once the phase has completed. This
+ * method must not be called by a task.
+ * </p>
+ *
+ * @param size The number of workers. If 0, the phase's workers are released
+ * and the scheduler's worker pool is used.
+ */
+func (p *MlePhase) SetWorkerPoolSize(size int) {
+	var pool *MleWorkerPool
+	if size > 0 {
+		pool = NewMleWorkerPool(size)
+	}
+	p.setWorkerPool(pool, false)
+}
+
+/**
+ * Pin this phase to run its tasks serially on a single worker. The worker
+ * is locked to one OS thread, as required by code which is not thread-safe,
+ * such as rendering code.
+ *
+ * @param serial <b>true</b> to run serially; <b>false</b> to release the
+ * worker and use the scheduler's worker pool.
+ */
+func (p *MlePhase) SetSerial(serial bool) {
+	var pool *MleWorkerPool
+	if serial {
+		pool = newMleWorkerPool(1, true)
+	}
+	p.setWorkerPool(pool, serial)
+}
+
+/**
+ * Determine whether this phase runs its tasks serially on a single worker.
+ *
+ * @return <b>true</b> is returned if the phase is serial.
+ */
+func (p *MlePhase) IsSerial() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.m_serial
+}
+
+/**
+ * Get the worker pool of this phase.
+ *
+ * @return The pool overriding the scheduler's worker pool is returned,
+ * or <b>nil</b> if the phase uses the scheduler's pool.
+ */
+func (p *MlePhase) GetWorkerPool() *MleWorkerPool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.m_pool
+}
+
+// Get the worker pool of this phase, acquired for a run of the phase.
+func (p *MlePhase) acquireWorkerPool() *MleWorkerPool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.m_pool.acquire()
+	return p.m_pool
+}
+
+// Replace the worker pool of this phase, closing the previous pool once
+// the runs of the phase using it have completed.
+func (p *MlePhase) setWorkerPool(pool *MleWorkerPool, serial bool) {
+	p.lock.Lock()
+	old := p.m_pool
+	p.m_pool = pool
+	p.m_serial = serial
+	p.lock.Unlock()
+
+	if old != nil {
+		old.retire()
+	}
+}
+
+/**
+ * Get the tasks of this phase in execution order. A task follows all of
+ * the tasks of this phase it depends on.
+ *
+ * @return The ordered tasks are returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the
+ * task dependencies form a cycle.
+ */
+func (p *MlePhase) GetExecutionOrder() ([]*MleTask, *mle_core.MleError) {
+	graph, err := _NewTaskGraph(p.copyTasks())
+	if err != nil {
+		return nil, err
+	}
+	return graph.m_order, nil
+}
+
+// Get a snapshot of the tasks.
+func (p *MlePhase) copyTasks() []*MleTask {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.getTasks()
+}
+
+// Get a snapshot of the tasks. The caller must hold the lock.
+func (p *MlePhase) getTasks() []*MleTask {
+	tasks := make([]*MleTask, len(*p.m_tasks))
+	for i := range tasks {
+		tasks[i] = p.m_tasks.ElementAt(i).(*MleTask)
+	}
+	return tasks
+}
+
 // String implements the IObject interface.
 func (p *MlePhase) String() string {
     return p.m_name
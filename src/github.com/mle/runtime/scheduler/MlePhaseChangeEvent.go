@@ -0,0 +1,149 @@
+/**
+ * @file MlePhaseChangeEvent.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package scheduler
+
+// Import go packages.
+import (
+	"strconv"
+)
+
+/** A phase was added to the scheduler. */
+const MLE_PHASE_ADDED int = 1
+
+/** A phase was removed from the scheduler. */
+const MLE_PHASE_REMOVED int = 2
+
+/** A phase was moved to another position in the scheduler. */
+const MLE_PHASE_MOVED int = 3
+
+/**
+ * <code>MlePhaseChangeEvent</code> describes a change to the phases of a
+ * <code>MleScheduler</code>. The event is delivered once the change has
+ * taken effect, which is at the end of the frame if the change was made
+ * while a frame was running.
+ *
+ * @see MleScheduler#AddPhaseChangeListener
+ */
+type MlePhaseChangeEvent struct {
+	// The scheduler whose phases changed.
+	m_scheduler *MleScheduler
+	// The kind of change.
+	m_type int
+	// The phase which changed.
+	m_phase *MlePhase
+	// The index of the phase before the change, -1 if it was added.
+	m_oldIndex int
+	// The index of the phase after the change, -1 if it was removed.
+	m_newIndex int
+}
+
+/**
+ * Get the scheduler whose phases changed.
+ *
+ * @return The scheduler is returned.
+ */
+func (event *MlePhaseChangeEvent) GetScheduler() *MleScheduler {
+	return event.m_scheduler
+}
+
+/**
+ * Get the kind of change.
+ *
+ * @return <code>MLE_PHASE_ADDED</code>, <code>MLE_PHASE_REMOVED</code> or
+ * <code>MLE_PHASE_MOVED</code> is returned.
+ */
+func (event *MlePhaseChangeEvent) GetType() int {
+	return event.m_type
+}
+
+/**
+ * Get the phase which changed.
+ *
+ * @return The phase is returned.
+ */
+func (event *MlePhaseChangeEvent) GetPhase() *MlePhase {
+	return event.m_phase
+}
+
+/**
+ * Get the index of the phase before the change.
+ *
+ * @return The index is returned, or -1 if the phase was added.
+ */
+func (event *MlePhaseChangeEvent) GetOldIndex() int {
+	return event.m_oldIndex
+}
+
+/**
+ * Get the index of the phase after the change.
+ *
+ * @return The index is returned, or -1 if the phase was removed.
+ */
+func (event *MlePhaseChangeEvent) GetNewIndex() int {
+	return event.m_newIndex
+}
+
+// String implements the IObject interface.
+func (event *MlePhaseChangeEvent) String() string {
+	var change string
+	switch event.m_type {
+	case MLE_PHASE_ADDED:
+		change = "added at " + strconv.Itoa(event.m_newIndex)
+	case MLE_PHASE_REMOVED:
+		change = "removed from " + strconv.Itoa(event.m_oldIndex)
+	default:
+		change = "moved from " + strconv.Itoa(event.m_oldIndex) + " to " + strconv.Itoa(event.m_newIndex)
+	}
+	return "Phase " + event.m_phase.GetName() + " " + change
+}
+
+/**
+ * This interface is used to declare a listener for changes to the phases
+ * of a <code>MleScheduler</code>.
+ */
+type IMlePhaseChangeListener interface {
+	/**
+	 * Called when the phases of the scheduler have changed. The listener is
+	 * called without holding the scheduler's lock, and may modify the phases.
+	 *
+	 * @param event The change.
+	 */
+	PhaseChanged(event *MlePhaseChangeEvent)
+}
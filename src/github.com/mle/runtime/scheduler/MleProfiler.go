@@ -0,0 +1,729 @@
+/**
+ * @file MleProfiler.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package scheduler
+
+// Import go packages.
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	mle_core "github.com/mle/runtime/core"
+)
+
+/** The span of a frame. */
+const MLE_PROFILE_FRAME string = "frame"
+
+/** The span of a phase. */
+const MLE_PROFILE_PHASE string = "phase"
+
+/** The span of a task. */
+const MLE_PROFILE_TASK string = "task"
+
+/** The default number of frames, and of samples per histogram, kept by a profiler. */
+const MLE_PROFILE_DEFAULT_HISTORY int = 120
+
+// The upper bounds of the histogram buckets. The last bucket holds the
+// durations exceeding the last bound.
+var g_profileBuckets = []time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	1 * time.Millisecond,
+	2 * time.Millisecond,
+	4 * time.Millisecond,
+	8 * time.Millisecond,
+	16 * time.Millisecond,
+	33 * time.Millisecond,
+	66 * time.Millisecond,
+	100 * time.Millisecond,
+}
+
+/**
+ * Get the upper bounds of the buckets of a <code>MleTimingStats</code>
+ * histogram.
+ *
+ * @return A copy of the bounds is returned. The histogram has one more
+ * bucket, holding the durations exceeding the last bound.
+ */
+func GetProfileBucketBounds() []time.Duration {
+	return append([]time.Duration(nil), g_profileBuckets...)
+}
+
+/**
+ * <code>MleProfileSpan</code> records when a frame, phase or task ran.
+ */
+type MleProfileSpan struct {
+	// The kind of span, MLE_PROFILE_FRAME, MLE_PROFILE_PHASE or MLE_PROFILE_TASK.
+	m_kind string
+	// The name of the frame, phase or task.
+	m_name string
+	// The name of the phase a task belongs to.
+	m_phase string
+	// The time the span started.
+	m_start time.Time
+	// The time the span ended.
+	m_end time.Time
+	// Flag indicating that the task failed.
+	m_failed bool
+}
+
+/**
+ * Get the kind of span.
+ *
+ * @return <code>MLE_PROFILE_FRAME</code>, <code>MLE_PROFILE_PHASE</code>
+ * or <code>MLE_PROFILE_TASK</code> is returned.
+ */
+func (span *MleProfileSpan) GetKind() string {
+	return span.m_kind
+}
+
+/**
+ * Get the name of the frame, phase or task.
+ *
+ * @return The name is returned.
+ */
+func (span *MleProfileSpan) GetName() string {
+	return span.m_name
+}
+
+/**
+ * Get the name of the phase.
+ *
+ * @return The name of the phase, or of the phase a task belongs to, is
+ * returned. An empty string is returned for a frame.
+ */
+func (span *MleProfileSpan) GetPhaseName() string {
+	return span.m_phase
+}
+
+/**
+ * Get the time the span started.
+ *
+ * @return The start time is returned.
+ */
+func (span *MleProfileSpan) GetStartTime() time.Time {
+	return span.m_start
+}
+
+/**
+ * Get the time the span ended.
+ *
+ * @return The end time is returned.
+ */
+func (span *MleProfileSpan) GetEndTime() time.Time {
+	return span.m_end
+}
+
+/**
+ * Get the duration of the span.
+ *
+ * @return The duration is returned.
+ */
+func (span *MleProfileSpan) GetDuration() time.Duration {
+	return span.m_end.Sub(span.m_start)
+}
+
+/**
+ * Determine whether the task failed.
+ *
+ * @return <b>true</b> is returned if the span is a task which failed.
+ */
+func (span *MleProfileSpan) Failed() bool {
+	return span.m_failed
+}
+
+// _GoroutineSample is the number of goroutines at a point in a frame.
+type _GoroutineSample struct {
+	// The time of the sample.
+	m_time time.Time
+	// The number of goroutines.
+	m_count int
+}
+
+/**
+ * <code>MleFrameProfile</code> records the phases and tasks run by one
+ * frame of a <code>MleScheduler</code>. A frame profile does not change
+ * once the frame has ended.
+ */
+type MleFrameProfile struct {
+	// The span of the frame.
+	MleProfileSpan
+	// The number of the frame, starting at 1.
+	m_number int64
+	// The spans of the phases and tasks, in order of completion.
+	m_spans []*MleProfileSpan
+	// The number of goroutines sampled during the frame.
+	m_goroutines []_GoroutineSample
+	// Internal lock used for protecting sensitve code.
+	lock sync.Mutex
+}
+
+/**
+ * Get the number of the frame.
+ *
+ * @return The frame number, starting at 1, is returned.
+ */
+func (frame *MleFrameProfile) GetNumber() int64 {
+	return frame.m_number
+}
+
+/**
+ * Get the spans of the phases and tasks run by the frame.
+ *
+ * @return A copy of the spans is returned, in order of completion.
+ */
+func (frame *MleFrameProfile) GetSpans() []*MleProfileSpan {
+	frame.lock.Lock()
+	defer frame.lock.Unlock()
+	return append([]*MleProfileSpan(nil), frame.m_spans...)
+}
+
+/**
+ * Get the largest number of goroutines sampled during the frame. The
+ * number is sampled when the frame starts and after each phase.
+ *
+ * @return The goroutine count is returned.
+ */
+func (frame *MleFrameProfile) GetGoroutineCount() int {
+	frame.lock.Lock()
+	defer frame.lock.Unlock()
+	count := 0
+	for _, sample := range frame.m_goroutines {
+		if sample.m_count > count {
+			count = sample.m_count
+		}
+	}
+	return count
+}
+
+// Add the span of a phase or task.
+func (frame *MleFrameProfile) addSpan(span *MleProfileSpan) {
+	frame.lock.Lock()
+	frame.m_spans = append(frame.m_spans, span)
+	frame.lock.Unlock()
+}
+
+// Sample the number of goroutines.
+func (frame *MleFrameProfile) sampleGoroutines() {
+	sample := _GoroutineSample{time.Now(), runtime.NumGoroutine()}
+	frame.lock.Lock()
+	frame.m_goroutines = append(frame.m_goroutines, sample)
+	frame.lock.Unlock()
+}
+
+/**
+ * <code>MleTimingStats</code> holds a rolling window of the durations of
+ * a frame, phase or task, and a histogram of them.
+ */
+type MleTimingStats struct {
+	// The durations in the window, a ring buffer.
+	m_samples []time.Duration
+	// The index of the next sample in the ring buffer.
+	m_next int
+	// The total number of durations recorded.
+	m_total int64
+}
+
+// Create stats with a window of the specified size.
+func newMleTimingStats(size int) *MleTimingStats {
+	p := new(MleTimingStats)
+	p.m_samples = make([]time.Duration, 0, size)
+	return p
+}
+
+// Record a duration, replacing the oldest if the window is full.
+func (stats *MleTimingStats) add(d time.Duration) {
+	if len(stats.m_samples) < cap(stats.m_samples) {
+		stats.m_samples = append(stats.m_samples, d)
+	} else {
+		stats.m_samples[stats.m_next] = d
+		stats.m_next = (stats.m_next + 1) % len(stats.m_samples)
+	}
+	stats.m_total++
+}
+
+// Copy the stats.
+func (stats *MleTimingStats) copy() *MleTimingStats {
+	p := new(MleTimingStats)
+	p.m_samples = append(make([]time.Duration, 0, cap(stats.m_samples)), stats.m_samples...)
+	p.m_next = stats.m_next
+	p.m_total = stats.m_total
+	return p
+}
+
+/**
+ * Get the number of durations in the window.
+ *
+ * @return The sample count is returned.
+ */
+func (stats *MleTimingStats) GetCount() int {
+	return len(stats.m_samples)
+}
+
+/**
+ * Get the total number of durations recorded, including those no longer
+ * in the window.
+ *
+ * @return The total count is returned.
+ */
+func (stats *MleTimingStats) GetTotalCount() int64 {
+	return stats.m_total
+}
+
+/**
+ * Get the shortest duration in the window.
+ *
+ * @return The minimum is returned, or 0 if the window is empty.
+ */
+func (stats *MleTimingStats) GetMin() time.Duration {
+	return stats.GetPercentile(0)
+}
+
+/**
+ * Get the longest duration in the window.
+ *
+ * @return The maximum is returned, or 0 if the window is empty.
+ */
+func (stats *MleTimingStats) GetMax() time.Duration {
+	return stats.GetPercentile(100)
+}
+
+/**
+ * Get the mean duration in the window.
+ *
+ * @return The mean is returned, or 0 if the window is empty.
+ */
+func (stats *MleTimingStats) GetMean() time.Duration {
+	if len(stats.m_samples) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, d := range stats.m_samples {
+		sum += d
+	}
+	return sum / time.Duration(len(stats.m_samples))
+}
+
+/**
+ * Get a percentile of the durations in the window, using the nearest rank.
+ *
+ * @param p The percentile, from 0 to 100.
+ *
+ * @return The duration is returned, or 0 if the window is empty.
+ */
+func (stats *MleTimingStats) GetPercentile(p float64) time.Duration {
+	n := len(stats.m_samples)
+	if n == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), stats.m_samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(p / 100 * float64(n-1) + 0.5)
+	if rank < 0 {
+		rank = 0
+	} else if rank >= n {
+		rank = n - 1
+	}
+	return sorted[rank]
+}
+
+/**
+ * Get the histogram of the durations in the window.
+ *
+ * @return The number of durations in each bucket is returned.
+ *
+ * @see GetProfileBucketBounds
+ */
+func (stats *MleTimingStats) GetHistogram() []int {
+	counts := make([]int, len(g_profileBuckets)+1)
+	for _, d := range stats.m_samples {
+		i := sort.Search(len(g_profileBuckets), func(i int) bool { return d <= g_profileBuckets[i] })
+		counts[i]++
+	}
+	return counts
+}
+
+// String implements the IObject interface.
+func (stats *MleTimingStats) String() string {
+	return fmt.Sprintf("n=%d mean=%v p50=%v p95=%v max=%v", stats.GetCount(), stats.GetMean(),
+		stats.GetPercentile(50), stats.GetPercentile(95), stats.GetMax())
+}
+
+/**
+ * <code>MleProfiler</code> records the timing of the frames, phases and
+ * tasks run by a <code>MleScheduler</code>.
+ * <p>
+ * The profiler keeps the most recent frames, and rolling histograms of the
+ * durations of each frame, phase and task. The frames may be exported as a
+ * Chrome trace, which can be loaded by viewers such as chrome://tracing or
+ * Perfetto. While a profiler is set, tasks also run with "mle_phase" and
+ * "mle_task" pprof labels, so that CPU profiles can be filtered by task.
+ * </p>
+ *
+ * @see MleScheduler#SetProfiler
+ */
+type MleProfiler struct {
+	// The number of frames, and of samples per histogram, kept.
+	m_history int
+	// The time the profiler was created or reset, the origin of trace timestamps.
+	m_epoch time.Time
+	// The most recent frames, oldest first.
+	m_frames []*MleFrameProfile
+	// The number of frames started.
+	m_frameCount int64
+	// The stats of the frames.
+	m_frameStats *MleTimingStats
+	// The stats of each phase, by phase name.
+	m_phaseStats map[string]*MleTimingStats
+	// The stats of each task, by phase and task name.
+	m_taskStats map[[2]string]*MleTimingStats
+	// Internal lock used for protecting sensitve code.
+	lock sync.Mutex
+}
+
+/**
+ * Creates a new MleProfiler.
+ *
+ * @param history The number of frames, and of samples per histogram, kept.
+ * If not positive, <code>MLE_PROFILE_DEFAULT_HISTORY</code> is used.
+ */
+func NewMleProfiler(history int) *MleProfiler {
+	if history <= 0 {
+		history = MLE_PROFILE_DEFAULT_HISTORY
+	}
+	p := new(MleProfiler)
+	p.m_history = history
+	p.Reset()
+	return p
+}
+
+/**
+ * Discard the recorded frames and stats.
+ */
+func (p *MleProfiler) Reset() {
+	p.lock.Lock()
+	p.m_epoch = time.Now()
+	p.m_frames = nil
+	p.m_frameCount = 0
+	p.m_frameStats = newMleTimingStats(p.m_history)
+	p.m_phaseStats = make(map[string]*MleTimingStats)
+	p.m_taskStats = make(map[[2]string]*MleTimingStats)
+	p.lock.Unlock()
+}
+
+// Start recording a frame.
+func (p *MleProfiler) beginFrame() *MleFrameProfile {
+	p.lock.Lock()
+	p.m_frameCount++
+	frame := new(MleFrameProfile)
+	frame.m_number = p.m_frameCount
+	p.lock.Unlock()
+
+	frame.m_kind = MLE_PROFILE_FRAME
+	frame.m_name = fmt.Sprintf("Frame %d", frame.m_number)
+	frame.m_start = time.Now()
+	frame.sampleGoroutines()
+	return frame
+}
+
+// Stop recording a frame, keeping it in the history.
+func (p *MleProfiler) endFrame(frame *MleFrameProfile) {
+	frame.m_end = time.Now()
+
+	p.lock.Lock()
+	p.m_frames = append(p.m_frames, frame)
+	if len(p.m_frames) > p.m_history {
+		p.m_frames = p.m_frames[len(p.m_frames)-p.m_history:]
+	}
+	p.m_frameStats.add(frame.GetDuration())
+	p.lock.Unlock()
+}
+
+// Record the span of a phase run by a frame.
+func (p *MleProfiler) recordPhase(frame *MleFrameProfile, phase string, start time.Time, end time.Time) {
+	frame.addSpan(&MleProfileSpan{MLE_PROFILE_PHASE, phase, phase, start, end, false})
+	frame.sampleGoroutines()
+
+	p.lock.Lock()
+	stats, found := p.m_phaseStats[phase]
+	if !found {
+		stats = newMleTimingStats(p.m_history)
+		p.m_phaseStats[phase] = stats
+	}
+	stats.add(end.Sub(start))
+	p.lock.Unlock()
+}
+
+// Record the span of a task run by a frame.
+func (p *MleProfiler) recordTask(frame *MleFrameProfile, phase string, task string, start time.Time, end time.Time, failed bool) {
+	frame.addSpan(&MleProfileSpan{MLE_PROFILE_TASK, task, phase, start, end, failed})
+
+	key := [2]string{phase, task}
+	p.lock.Lock()
+	stats, found := p.m_taskStats[key]
+	if !found {
+		stats = newMleTimingStats(p.m_history)
+		p.m_taskStats[key] = stats
+	}
+	stats.add(end.Sub(start))
+	p.lock.Unlock()
+}
+
+/**
+ * Get the recorded frames.
+ *
+ * @return The most recent frames are returned, oldest first.
+ */
+func (p *MleProfiler) GetFrames() []*MleFrameProfile {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return append([]*MleFrameProfile(nil), p.m_frames...)
+}
+
+/**
+ * Get the most recent frame.
+ *
+ * @return The frame is returned, or <b>nil</b> if no frame has been recorded.
+ */
+func (p *MleProfiler) GetLatestFrame() *MleFrameProfile {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.m_frames) == 0 {
+		return nil
+	}
+	return p.m_frames[len(p.m_frames)-1]
+}
+
+/**
+ * Get the stats of the frame durations.
+ *
+ * @return A copy of the stats is returned.
+ */
+func (p *MleProfiler) GetFrameStats() *MleTimingStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.m_frameStats.copy()
+}
+
+/**
+ * Get the stats of the durations of a phase.
+ *
+ * @param phase The name of the phase.
+ *
+ * @return A copy of the stats is returned, or <b>nil</b> if the phase
+ * has not been recorded.
+ */
+func (p *MleProfiler) GetPhaseStats(phase string) *MleTimingStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if stats, found := p.m_phaseStats[phase]; found {
+		return stats.copy()
+	}
+	return nil
+}
+
+/**
+ * Get the stats of the durations of a task.
+ *
+ * @param phase The name of the phase the task belongs to.
+ * @param task The name of the task.
+ *
+ * @return A copy of the stats is returned, or <b>nil</b> if the task
+ * has not been recorded.
+ */
+func (p *MleProfiler) GetTaskStats(phase string, task string) *MleTimingStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if stats, found := p.m_taskStats[[2]string{phase, task}]; found {
+		return stats.copy()
+	}
+	return nil
+}
+
+// _TraceEvent is an event of the Chrome trace event format.
+type _TraceEvent struct {
+	Name      string                 `json:"name"`
+	Category  string                 `json:"cat,omitempty"`
+	Phase     string                 `json:"ph"`
+	Timestamp float64                `json:"ts"`
+	Duration  float64                `json:"dur,omitempty"`
+	Pid       int                    `json:"pid"`
+	Tid       int                    `json:"tid"`
+	Args      map[string]interface{} `json:"args,omitempty"`
+}
+
+/**
+ * Export the recorded frames in the Chrome trace event format.
+ * <p>
+ * Frames and phases are shown on the "scheduler" thread. Tasks are
+ * spread over "tasks" threads so that concurrently running tasks do not
+ * overlap. The goroutine count is shown as a counter.
+ * </p>
+ *
+ * @param w The writer the JSON trace is written to.
+ *
+ * @throws MleRuntimeException This exception is thrown if the trace can
+ * not be written.
+ */
+func (p *MleProfiler) WriteChromeTrace(w io.Writer) *mle_core.MleError {
+	p.lock.Lock()
+	epoch := p.m_epoch
+	frames := append([]*MleFrameProfile(nil), p.m_frames...)
+	p.lock.Unlock()
+
+	micros := func(t time.Time) float64 {
+		return float64(t.Sub(epoch).Nanoseconds()) / 1000
+	}
+	complete := func(span *MleProfileSpan, tid int, args map[string]interface{}) _TraceEvent {
+		return _TraceEvent{span.m_name, span.m_kind, "X", micros(span.m_start),
+			float64(span.GetDuration().Nanoseconds()) / 1000, 1, tid, args}
+	}
+
+	events := []_TraceEvent{
+		{Name: "process_name", Phase: "M", Pid: 1, Args: map[string]interface{}{"name": "MleScheduler"}},
+		{Name: "thread_name", Phase: "M", Pid: 1, Tid: 0, Args: map[string]interface{}{"name": "scheduler"}},
+	}
+	lanes := 0
+	for _, frame := range frames {
+		events = append(events, complete(&frame.MleProfileSpan, 0, map[string]interface{}{"frame": frame.m_number}))
+
+		/* Assign each task the first lane which is free when it starts. */
+		var laneEnds []time.Time
+		spans := frame.GetSpans()
+		sort.SliceStable(spans, func(i, j int) bool { return spans[i].m_start.Before(spans[j].m_start) })
+		for _, span := range spans {
+			if span.m_kind != MLE_PROFILE_TASK {
+				events = append(events, complete(span, 0, nil))
+				continue
+			}
+			lane := 0
+			for (lane < len(laneEnds)) && laneEnds[lane].After(span.m_start) {
+				lane++
+			}
+			if lane == len(laneEnds) {
+				laneEnds = append(laneEnds, span.m_end)
+			} else {
+				laneEnds[lane] = span.m_end
+			}
+			if lane+1 > lanes {
+				lanes = lane + 1
+			}
+			args := map[string]interface{}{"phase": span.m_phase}
+			if span.m_failed {
+				args["failed"] = true
+			}
+			events = append(events, complete(span, lane+1, args))
+		}
+
+		frame.lock.Lock()
+		for _, sample := range frame.m_goroutines {
+			events = append(events, _TraceEvent{Name: "goroutines", Phase: "C", Timestamp: micros(sample.m_time),
+				Pid: 1, Args: map[string]interface{}{"count": sample.m_count}})
+		}
+		frame.lock.Unlock()
+	}
+	for lane := 1; lane <= lanes; lane++ {
+		events = append(events, _TraceEvent{Name: "thread_name", Phase: "M", Pid: 1, Tid: lane,
+			Args: map[string]interface{}{"name": fmt.Sprintf("tasks %d", lane)}})
+	}
+
+	trace := map[string]interface{}{"traceEvents": events, "displayTimeUnit": "ms"}
+	if err := json.NewEncoder(w).Encode(trace); err != nil {
+		return mle_core.NewMleError("MleProfiler: unable to write trace.", 0, err)
+	}
+	return nil
+}
+
+// String implements the IObject interface.
+func (p *MleProfiler) String() string {
+	var buf bytes.Buffer
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	buf.WriteString("Frames: ")
+	buf.WriteString(p.m_frameStats.String())
+	buf.WriteString("\n")
+	if len(p.m_frames) > 0 {
+		latest := p.m_frames[len(p.m_frames)-1]
+		buf.WriteString(fmt.Sprintf("Latest frame %d: %v, %d goroutines\n",
+			latest.m_number, latest.GetDuration(), latest.GetGoroutineCount()))
+	}
+
+	phases := make([]string, 0, len(p.m_phaseStats))
+	for phase := range p.m_phaseStats {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	tasks := make([][2]string, 0, len(p.m_taskStats))
+	for key := range p.m_taskStats {
+		tasks = append(tasks, key)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i][0] != tasks[j][0] {
+			return tasks[i][0] < tasks[j][0]
+		}
+		return tasks[i][1] < tasks[j][1]
+	})
+
+	for _, phase := range phases {
+		buf.WriteString("Phase ")
+		buf.WriteString(phase)
+		buf.WriteString(": ")
+		buf.WriteString(p.m_phaseStats[phase].String())
+		buf.WriteString("\n")
+		for _, key := range tasks {
+			if key[0] == phase {
+				buf.WriteString("\tTask ")
+				buf.WriteString(key[1])
+				buf.WriteString(": ")
+				buf.WriteString(p.m_taskStats[key].String())
+				buf.WriteString("\n")
+			}
+		}
+	}
+	return buf.String()
+}
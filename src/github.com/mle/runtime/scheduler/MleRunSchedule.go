@@ -0,0 +1,118 @@
+/**
+ * @file MleRunSchedule.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package scheduler
+
+// Import go packages.
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	mle_core "github.com/mle/runtime/core"
+)
+
+// _RunSchedule determines on which frames a task or phase runs. The owner
+// of the schedule guards it with its lock.
+type _RunSchedule struct {
+	// Flag indicating whether the owner has been disabled.
+	m_disabled bool
+	// The number of frames between runs; 0 or 1 runs every frame.
+	m_interval int
+	// The index of the first frame run.
+	m_offset int
+	// The minimum time between runs; 0 runs regardless of time.
+	m_period time.Duration
+	// The frame time of the last run.
+	m_lastRun time.Time
+	// The condition which must hold for a run, may be nil.
+	m_condition func() bool
+}
+
+// Validate and set the frame interval of a schedule.
+func (r *_RunSchedule) setFrameInterval(interval int, offset int) *mle_core.MleError {
+	if interval < 1 {
+		return mle_core.NewMleError("Frame interval "+strconv.Itoa(interval)+" is not positive.", 0, nil)
+	}
+	if offset < 0 {
+		return mle_core.NewMleError("Frame offset "+strconv.Itoa(offset)+" is negative.", 0, nil)
+	}
+	r.m_interval = interval
+	r.m_offset = offset
+	return nil
+}
+
+// Determine whether the schedule is due on the specified frame, not
+// considering the run condition.
+func (r *_RunSchedule) isDue(frame int64, now time.Time) bool {
+	if r.m_disabled {
+		return false
+	}
+	if r.m_interval > 1 || r.m_offset > 0 {
+		interval := int64(r.m_interval)
+		if interval < 1 {
+			interval = 1
+		}
+		if (frame < int64(r.m_offset)) || ((frame-int64(r.m_offset))%interval != 0) {
+			return false
+		}
+	}
+	if (r.m_period > 0) && !r.m_lastRun.IsZero() && (now.Sub(r.m_lastRun) < r.m_period) {
+		return false
+	}
+	return true
+}
+
+// Determine whether the owner runs on the specified frame, recording the
+// run if it does. The run condition is evaluated without holding the lock
+// of the owner, so that it may use the owner's methods.
+func (r *_RunSchedule) shouldRun(lock *sync.Mutex, frame int64, now time.Time) bool {
+	lock.Lock()
+	due := r.isDue(frame, now)
+	condition := r.m_condition
+	lock.Unlock()
+
+	if !due || ((condition != nil) && !condition()) {
+		return false
+	}
+	lock.Lock()
+	r.m_lastRun = now
+	lock.Unlock()
+	return true
+}
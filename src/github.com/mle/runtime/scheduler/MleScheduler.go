@@ -41,15 +41,39 @@ package scheduler
 
 // Import go packages.
 import (
+	"context"
 	"runtime"
 	"fmt"
 	"strconv"
+	"strings"
 	"bytes"
 	"sync"
 
 	mle_util "github.com/mle/runtime/util"
+	mle_core "github.com/mle/runtime/core"
 )
 
+/** The maximum number of task failures collected by the scheduler. */
+const MLE_MAX_TASK_ERRORS int = 100
+
+/**
+ * This interface is used to declare a handler for the task failures
+ * of a <code>MleScheduler</code>.
+ */
+type IMleTaskErrorHandler interface {
+	/**
+	 * Handle the failure of a task. The handler is called by the goroutine
+	 * running the scheduler, after the failure policy of the task has been
+	 * applied.
+	 *
+	 * @param phase The phase the task belongs to.
+	 * @param task The task which failed.
+	 * @param err The failure, naming the task and phase. The error returned by
+	 * the task, or the <code>PanicError</code> if it panicked, is wrapped.
+	 */
+	TaskFailed(phase *MlePhase, task *MleTask, err *mle_core.MleError)
+}
+
 /**
  * The <code>MleScheduler</code> class is used to schedule phases of execution
  * which might be required by a runtime engine. For example, a 3D game engine
@@ -77,6 +101,32 @@ type MleScheduler struct {
     m_phases *mle_util.Vector
     // Flag indicating that it is ok to exit.
 	m_exitOK bool
+	// Cancels the context of the current run, nil if not running.
+	m_cancel context.CancelFunc
+	// Closed when the current run has stopped, nil if not running.
+	m_stopped chan bool
+	// The worker pool running the tasks, may be nil.
+	m_pool *MleWorkerPool
+	// The handler of task failures, may be nil.
+	m_errorHandler IMleTaskErrorHandler
+	// The most recent task failures.
+	m_taskErrors []*mle_core.MleError
+	// The profiler recording the frames, may be nil.
+	m_profiler *MleProfiler
+	// The clock providing the frame time.
+	m_clock MleClock
+	// The index of the next frame.
+	m_frameIndex int64
+	// The number of frames running.
+	m_running int
+	// The phases changed while a frame runs, nil if there are no changes.
+	m_staged *mle_util.Vector
+	// The phase changes to deliver at the end of the frame.
+	m_pendingChanges []*MlePhaseChangeEvent
+	// The listeners notified of phase changes.
+	m_phaseListeners []IMlePhaseChangeListener
+	// Closed when the phases change, nil if no one is waiting.
+	m_phasesChanged chan bool
 	// Internal lock used for protecting sensitve code.
 	lock sync.Mutex
 }
@@ -86,18 +136,243 @@ func NewMleScheduler() *MleScheduler {
 	p := new(MleScheduler)
 	p.m_phases = mle_util.NewVector()
 	p.m_exitOK = false
+	p.m_clock = NewMleSystemClock()
 	return p
 }
 
 /**
- * Flags scheduler that it is Ok to exit.
+ * Flags scheduler that it is Ok to exit. The context of the current
+ * run is cancelled, so that running tasks are asked to stop.
  */
 func (s *MleScheduler) SetExitOk() {
 	s.lock.Lock()
 	s.m_exitOK = true
+	if s.m_cancel != nil {
+		s.m_cancel()
+	}
 	s.lock.Unlock()
 }
 
+// Determine whether the scheduler has been flagged to exit.
+func (s *MleScheduler) isExitOk() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.m_exitOK
+}
+
+/**
+ * Set the number of workers running the tasks of the scheduled phases.
+ * Without a worker pool, each task runs on its own thread. A phase may
+ * override the scheduler's pool.
+ *
+ * <p>
+ * The new pool is used from the next frame. A frame which is running
+ * keeps the previous pool, which is closed once the frame has ended.
+ * This method must not be called by a task, since closing the previous
+ * pool waits for its workers when no frame is running.
+ * </p>
+ *
+ * @param size The number of workers. If 0, the workers are released and
+ * each task runs on its own thread.
+ *
+ * @see MlePhase#SetWorkerPoolSize
+ * @see MlePhase#SetSerial
+ */
+func (s *MleScheduler) SetWorkerPoolSize(size int) {
+	var pool *MleWorkerPool
+	if size > 0 {
+		pool = NewMleWorkerPool(size)
+	}
+
+	s.lock.Lock()
+	old := s.m_pool
+	s.m_pool = pool
+	s.lock.Unlock()
+
+	if old != nil {
+		old.retire()
+	}
+}
+
+/**
+ * Get the worker pool running the tasks of the scheduled phases.
+ *
+ * @return The worker pool is returned, or <b>nil</b> if there is none.
+ */
+func (s *MleScheduler) GetWorkerPool() *MleWorkerPool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.m_pool
+}
+
+/**
+ * Set the handler of task failures.
+ *
+ * @param handler The handler. If <b>nil</b>, task failures are only collected.
+ *
+ * @see GetTaskErrors
+ */
+func (s *MleScheduler) SetTaskErrorHandler(handler IMleTaskErrorHandler) {
+	s.lock.Lock()
+	s.m_errorHandler = handler
+	s.lock.Unlock()
+}
+
+/**
+ * Get the task failures collected by the scheduler. At most
+ * <code>MLE_MAX_TASK_ERRORS</code> of the most recent failures are kept.
+ *
+ * @return A copy of the failures is returned, oldest first. Each failure
+ * names the task and phase.
+ */
+func (s *MleScheduler) GetTaskErrors() []*mle_core.MleError {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]*mle_core.MleError(nil), s.m_taskErrors...)
+}
+
+/**
+ * Clear the task failures collected by the scheduler.
+ */
+func (s *MleScheduler) ClearTaskErrors() {
+	s.lock.Lock()
+	s.m_taskErrors = nil
+	s.lock.Unlock()
+}
+
+/**
+ * Set the profiler recording the timing of the frames, phases and tasks.
+ * Each round of <code>RunContext</code>, and each call to
+ * <code>RunFrame</code>, is recorded as a frame.
+ *
+ * @param profiler The profiler. If <b>nil</b>, profiling is disabled.
+ */
+func (s *MleScheduler) SetProfiler(profiler *MleProfiler) {
+	s.lock.Lock()
+	s.m_profiler = profiler
+	s.lock.Unlock()
+}
+
+/**
+ * Get the profiler recording the timing of the frames, phases and tasks.
+ *
+ * @return The profiler is returned, or <b>nil</b> if there is none.
+ */
+func (s *MleScheduler) GetProfiler() *MleProfiler {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.m_profiler
+}
+
+/**
+ * Set the clock providing the frame time, which determines when the phases
+ * and tasks with a time interval are due.
+ *
+ * @param clock The clock. If <b>nil</b>, the system clock is used.
+ */
+func (s *MleScheduler) SetClock(clock MleClock) {
+	if clock == nil {
+		clock = NewMleSystemClock()
+	}
+	s.lock.Lock()
+	s.m_clock = clock
+	s.lock.Unlock()
+}
+
+/**
+ * Get the index of the next frame. Frames are counted from 0.
+ *
+ * @return The frame index is returned.
+ */
+func (s *MleScheduler) GetFrameIndex() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.m_frameIndex
+}
+
+// Start a frame, returning the environment the phases of the frame run with
+// and the phases to run. Changes to the phases made while the frame runs
+// take effect when it ends.
+func (s *MleScheduler) beginFrame() (*_PhaseEnv, []*MlePhase) {
+	env := new(_PhaseEnv)
+	s.lock.Lock()
+	s.m_running++
+	phases := make([]*MlePhase, len(*s.m_phases))
+	for i := range phases {
+		phases[i] = s.m_phases.ElementAt(i).(*MlePhase)
+	}
+	env.m_pool = s.m_pool
+	env.m_pool.acquire()
+	env.m_profiler = s.m_profiler
+	env.m_frameIndex = s.m_frameIndex
+	env.m_frameTime = s.m_clock.Now()
+	s.m_frameIndex++
+	s.lock.Unlock()
+	if env.m_profiler != nil {
+		env.m_frame = env.m_profiler.beginFrame()
+	}
+	return env, phases
+}
+
+// End a frame started by beginFrame, applying the changes to the phases
+// made while it ran.
+func (s *MleScheduler) endFrame(env *_PhaseEnv) {
+	if env.m_profiler != nil {
+		env.m_profiler.endFrame(env.m_frame)
+	}
+
+	var changes []*MlePhaseChangeEvent
+	s.lock.Lock()
+	s.m_running--
+	if s.m_running == 0 {
+		if s.m_staged != nil {
+			s.m_phases = s.m_staged
+			s.m_staged = nil
+		}
+		changes = s.m_pendingChanges
+		s.m_pendingChanges = nil
+		if (len(changes) > 0) && (s.m_phasesChanged != nil) {
+			close(s.m_phasesChanged)
+			s.m_phasesChanged = nil
+		}
+	}
+	s.lock.Unlock()
+
+	// Close the worker pool if it was replaced while the frame ran.
+	env.m_pool.release()
+	s.firePhaseChanges(changes)
+}
+
+// Run a phase of a frame, unless it is not due.
+func (s *MleScheduler) runPhase(ctx context.Context, env *_PhaseEnv, phase *MlePhase) *mle_core.MleError {
+	if !phase.shouldRun(env.m_frameIndex, env.m_frameTime) {
+		return nil
+	}
+	phaseEnv := *env
+	phaseEnv.m_report = s.taskErrorReporter(phase)
+	return phase.runContext(ctx, &phaseEnv)
+}
+
+// Create the function collecting the task failures of the specified phase
+// and passing them to the handler.
+func (s *MleScheduler) taskErrorReporter(phase *MlePhase) func(task *MleTask, err *mle_core.MleError) {
+	return func(task *MleTask, err *mle_core.MleError) {
+		s.lock.Lock()
+		s.m_taskErrors = append(s.m_taskErrors, err)
+		if len(s.m_taskErrors) > MLE_MAX_TASK_ERRORS {
+			s.m_taskErrors = s.m_taskErrors[len(s.m_taskErrors)-MLE_MAX_TASK_ERRORS:]
+		}
+		handler := s.m_errorHandler
+		s.lock.Unlock()
+
+		if handler != nil {
+			handler.TaskFailed(phase, task, err)
+		} else {
+			mle_core.MleLogError(err.What, false)
+		}
+	}
+}
+
 /**
  * Gets the number of registered phases for this scheduler.
  *
@@ -105,11 +380,13 @@ func (s *MleScheduler) SetExitOk() {
  * registered with this scheduler.
  */
 func (s *MleScheduler) GetNumberOfPhases() int {
-	return len(*s.m_phases)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(*s.viewPhases())
 }
 
 /**
- * Adds a phase to this scheduler.
+ * Adds a phase to this scheduler, after the registered phases.
  *
  * @param phase An instance of <code>MlePhase</code>.
  *
@@ -118,11 +395,147 @@ func (s *MleScheduler) GetNumberOfPhases() int {
  * <b>true</b> is returned; otherwise, <b>false</b> will be returned.
  *
  * @see MlePhase
+ * @see InsertPhaseAt
  */
 func (s *MleScheduler) AddPhase(phase *MlePhase) bool {
+	return s.insertPhase(phase, func(phases *mle_util.Vector) int {
+		return len(*phases)
+	})
+}
+
+/**
+ * Inserts a phase into this scheduler at index <code>n</code>.
+ * <p>
+ * The phases may be changed while the scheduler is running. Changes made
+ * while a frame is running take effect at the end of the frame; until
+ * then, the scheduler's accessors report the changed phases. The tasks of
+ * the phase may only depend on tasks of earlier phases. A phase may only
+ * be registered once.
+ * </p>
+ *
+ * @param n The index of the phase, from 0 to the number of phases.
+ * @param phase An instance of <code>MlePhase</code>.
+ *
+ * @return <b>true</b> is returned if the phase was inserted.
+ *
+ * @see AddPhaseChangeListener
+ */
+func (s *MleScheduler) InsertPhaseAt(n int, phase *MlePhase) bool {
+	return s.insertPhase(phase, func(phases *mle_util.Vector) int {
+		return n
+	})
+}
+
+/**
+ * Inserts a phase into this scheduler before another phase.
+ *
+ * @param phase An instance of <code>MlePhase</code>.
+ * @param before The registered phase which will follow <code>phase</code>.
+ *
+ * @return <b>true</b> is returned if the phase was inserted.
+ *
+ * @see InsertPhaseAt
+ */
+func (s *MleScheduler) InsertPhaseBefore(phase *MlePhase, before *MlePhase) bool {
+	return s.insertPhase(phase, func(phases *mle_util.Vector) int {
+		return _PhaseIndex(phases, before)
+	})
+}
+
+/**
+ * Inserts a phase into this scheduler after another phase.
+ *
+ * @param phase An instance of <code>MlePhase</code>.
+ * @param after The registered phase which will precede <code>phase</code>.
+ *
+ * @return <b>true</b> is returned if the phase was inserted.
+ *
+ * @see InsertPhaseAt
+ */
+func (s *MleScheduler) InsertPhaseAfter(phase *MlePhase, after *MlePhase) bool {
+	return s.insertPhase(phase, func(phases *mle_util.Vector) int {
+		if index := _PhaseIndex(phases, after); index >= 0 {
+			return index + 1
+		}
+		return -1
+	})
+}
+
+// Insert a phase at the index located among the changed phases.
+func (s *MleScheduler) insertPhase(phase *MlePhase, locate func(phases *mle_util.Vector) int) bool {
+	if phase == nil {
+		return false
+	}
+
+	s.lock.Lock()
+	phases := s.viewPhases()
+	index := locate(phases)
+	var err *mle_core.MleError
+	if _PhaseIndex(phases, phase) >= 0 {
+		err = mle_core.NewMleError("phase is already registered.", 0, nil)
+	} else if (index < 0) || (index > len(*phases)) {
+		err = mle_core.NewMleError("invalid position "+strconv.Itoa(index)+".", 0, nil)
+	} else {
+		err = _CheckDependencies(s.stagedPhases()[:index], phase.copyTasks())
+	}
+	if err != nil {
+		s.lock.Unlock()
+		mle_core.MleLogError("MleScheduler: unable to add phase "+phase.GetName()+": "+err.What, false)
+		return false
+	}
+	s.editPhases().Insert(index, phase)
+	changes := s.phasesChanged(&MlePhaseChangeEvent{s, MLE_PHASE_ADDED, phase, -1, index})
+	s.lock.Unlock()
+
+	s.firePhaseChanges(changes)
+	return true
+}
+
+/**
+ * Moves a registered phase to index <code>n</code>. The move is rejected
+ * if a task would run before a task of a later phase it depends on.
+ *
+ * @param phase The phase to move.
+ * @param n The new index of the phase, from 0 to the number of phases
+ * less one.
+ *
+ * @return <b>true</b> is returned if the phase was moved.
+ *
+ * @see InsertPhaseAt
+ */
+func (s *MleScheduler) MovePhase(phase *MlePhase, n int) bool {
 	s.lock.Lock()
-	s.m_phases.AddElement(phase)
+	order := s.stagedPhases()
+	from := _PhaseIndex(s.viewPhases(), phase)
+	var err *mle_core.MleError
+	if from < 0 {
+		err = mle_core.NewMleError("phase is not registered.", 0, nil)
+	} else if (n < 0) || (n >= len(order)) {
+		err = mle_core.NewMleError("invalid position "+strconv.Itoa(n)+".", 0, nil)
+	} else if from == n {
+		s.lock.Unlock()
+		return true
+	} else {
+		order = append(order[:from], order[from+1:]...)
+		order = append(order[:n], append([]*MlePhase{phase}, order[n:]...)...)
+		for i := range order {
+			if err = _CheckDependencies(order[:i], order[i].copyTasks()); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		s.lock.Unlock()
+		mle_core.MleLogError("MleScheduler: unable to move phase "+_PhaseLabel(phase)+": "+err.What, false)
+		return false
+	}
+	phases := s.editPhases()
+	phases.Delete(from)
+	phases.Insert(n, phase)
+	changes := s.phasesChanged(&MlePhaseChangeEvent{s, MLE_PHASE_MOVED, phase, from, n})
 	s.lock.Unlock()
+
+	s.firePhaseChanges(changes)
 	return true
 }
 
@@ -139,8 +552,16 @@ func (s *MleScheduler) AddPhase(phase *MlePhase) bool {
  */
 func (s *MleScheduler) DeletePhase(phase *MlePhase) bool {
 	s.lock.Lock()
-	s.m_phases.RemoveElement(phase)
+	index := _PhaseIndex(s.viewPhases(), phase)
+	if index < 0 {
+		s.lock.Unlock()
+		return false
+	}
+	s.editPhases().Delete(index)
+	changes := s.phasesChanged(&MlePhaseChangeEvent{s, MLE_PHASE_REMOVED, phase, index, -1})
 	s.lock.Unlock()
+
+	s.firePhaseChanges(changes)
 	return true
 }
 
@@ -160,9 +581,9 @@ func (s *MleScheduler) GetPhase(n int) *MlePhase {
 
 	s.lock.Lock()
 
-	e := s.m_phases.ElementAt(n)
-	if e != nil {
-		phase = e.(*MlePhase)
+	phases := s.viewPhases()
+	if (n >= 0) && (n < len(*phases)) {
+		phase = phases.ElementAt(n).(*MlePhase)
 	} else {
 		phase = nil
 	}
@@ -189,8 +610,9 @@ func (s *MleScheduler) GetPhaseWithName(name string) *MlePhase {
 
 	s.lock.Lock()
 
-	for i := 0; i < len(*s.m_phases); i++ {
-		curPhase := s.m_phases.ElementAt(i).(*MlePhase)
+	phases := s.viewPhases()
+	for i := 0; i < len(*phases); i++ {
+		curPhase := phases.ElementAt(i).(*MlePhase)
 		curName := curPhase.GetName()
 		if name == curName {
 			/** Names are equal */
@@ -204,9 +626,140 @@ func (s *MleScheduler) GetPhaseWithName(name string) *MlePhase {
 	return phase
 }
 
+/**
+ * Add a listener notified when the phases of this scheduler change.
+ *
+ * @param listener The listener to add.
+ */
+func (s *MleScheduler) AddPhaseChangeListener(listener IMlePhaseChangeListener) {
+	if listener == nil {
+		return
+	}
+	s.lock.Lock()
+	s.m_phaseListeners = append(s.m_phaseListeners, listener)
+	s.lock.Unlock()
+}
+
+/**
+ * Remove a listener notified when the phases of this scheduler change.
+ *
+ * @param listener The listener to remove.
+ *
+ * @return <b>true</b> is returned if the listener was registered.
+ */
+func (s *MleScheduler) RemovePhaseChangeListener(listener IMlePhaseChangeListener) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i, l := range s.m_phaseListeners {
+		if l == listener {
+			s.m_phaseListeners = append(s.m_phaseListeners[:i:i], s.m_phaseListeners[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Get the phases, including the changes made while a frame runs. The
+// caller must hold the lock.
+func (s *MleScheduler) viewPhases() *mle_util.Vector {
+	if s.m_staged != nil {
+		return s.m_staged
+	}
+	return s.m_phases
+}
+
+// Get the phases to change. While a frame runs, the changes are made to
+// a copy, which replaces the phases at the end of the frame. The caller
+// must hold the lock.
+func (s *MleScheduler) editPhases() *mle_util.Vector {
+	if s.m_running == 0 {
+		return s.m_phases
+	}
+	if s.m_staged == nil {
+		s.m_staged = mle_util.NewVector()
+		s.m_staged.AppendVector(*s.m_phases...)
+	}
+	return s.m_staged
+}
+
+// Record a change to the phases, returning the changes to deliver now.
+// While a frame runs, the change is delivered at the end of the frame.
+// The caller must hold the lock.
+func (s *MleScheduler) phasesChanged(event *MlePhaseChangeEvent) []*MlePhaseChangeEvent {
+	if s.m_running > 0 {
+		s.m_pendingChanges = append(s.m_pendingChanges, event)
+		return nil
+	}
+	if s.m_phasesChanged != nil {
+		close(s.m_phasesChanged)
+		s.m_phasesChanged = nil
+	}
+	return []*MlePhaseChangeEvent{event}
+}
+
+// Notify the listeners of changes to the phases.
+func (s *MleScheduler) firePhaseChanges(changes []*MlePhaseChangeEvent) {
+	if len(changes) == 0 {
+		return
+	}
+	s.lock.Lock()
+	listeners := append([]IMlePhaseChangeListener(nil), s.m_phaseListeners...)
+	s.lock.Unlock()
+
+	for _, event := range changes {
+		for _, listener := range listeners {
+			listener.PhaseChanged(event)
+		}
+	}
+}
+
+// Wait until there is a phase to run.
+//
+// Return
+//   true is returned if there is a phase, false if ctx is done.
+func (s *MleScheduler) waitForPhases(ctx context.Context) bool {
+	for {
+		s.lock.Lock()
+		if len(*s.m_phases) > 0 {
+			s.lock.Unlock()
+			return true
+		}
+		if s.m_phasesChanged == nil {
+			s.m_phasesChanged = make(chan bool)
+		}
+		changed := s.m_phasesChanged
+		s.lock.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// Find the index of a phase.
+func _PhaseIndex(phases *mle_util.Vector, phase *MlePhase) int {
+	for i, element := range *phases {
+		if element.(*MlePhase) == phase {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get the name of a phase for reporting.
+func _PhaseLabel(phase *MlePhase) string {
+	if phase.GetName() != "" {
+		return phase.GetName()
+	}
+	return "empty"
+}
+
 /**
  * Adds a task to the specified phase. The phase must have been previously
- * registered with this scheduler.
+ * registered with this scheduler. The task's dependencies which do not
+ * belong to the phase must belong to an earlier phase.
  *
  * @param phase The phase which the <code>task</code> will be added to.
  * @param task The task which will be added to the specifed
@@ -221,17 +774,43 @@ func (s *MleScheduler) GetPhaseWithName(name string) *MlePhase {
  * @see MleTask
  */
 func (s *MleScheduler) AddTask(phase *MlePhase, task *MleTask) bool {
-	status := false
-
 	s.lock.Lock()
+	defer s.lock.Unlock()
 
-	if s.m_phases.Contains(phase) {
-		status = phase.AddTask(task)
+	phases := s.stagedPhases()
+	for i := range phases {
+		if phases[i] == phase {
+			tasks := append(phase.copyTasks(), task)
+			if err := _CheckDependencies(phases[:i], tasks); err != nil {
+				mle_core.MleLogError("MleScheduler: unable to add task to phase "+phase.GetName()+": "+err.What, false)
+				return false
+			}
+			return phase.AddTask(task)
+		}
 	}
+	return false
+}
 
-	s.lock.Unlock()
-
-	return status
+// Check that the dependencies of tasks which do not belong to the tasks
+// belong to one of the specified earlier phases.
+func _CheckDependencies(earlier []*MlePhase, tasks []*MleTask) *mle_core.MleError {
+	var previous []*MleTask
+	for _, phase := range earlier {
+		previous = append(previous, phase.copyTasks()...)
+	}
+	for _, task := range tasks {
+		refs, names := _UnresolvedDependencies(task.GetDependencies(), task.GetDependencyNames(), tasks)
+		refs, names = _UnresolvedDependencies(refs, names, previous)
+		if len(refs) > 0 {
+			return mle_core.NewMleError("Task "+_TaskLabel(task)+" depends on "+_TaskLabel(refs[0])+
+				", which is not in the same or an earlier phase.", 0, nil)
+		}
+		if len(names) > 0 {
+			return mle_core.NewMleError("Task "+_TaskLabel(task)+" depends on "+names[0]+
+				", which is not in the same or an earlier phase.", 0, nil)
+		}
+	}
+	return nil
 }
 
 /**
@@ -255,7 +834,7 @@ func (s *MleScheduler) DeleteTask(phase *MlePhase, task *MleTask) bool {
 
 	s.lock.Lock()
 
-	if s.m_phases.Contains(phase) {
+	if _PhaseIndex(s.viewPhases(), phase) >= 0 {
 		status = phase.DeleteTask(task)
 	}
 
@@ -265,8 +844,8 @@ func (s *MleScheduler) DeleteTask(phase *MlePhase, task *MleTask) bool {
 }
 
 /**
- * Executes scheduled phases. The order of execution is based on the order
- * in which the phases were registered with this scheduler. A phase must
+ * Executes scheduled phases. The order of execution is the order of the
+ * phases registered with this scheduler. A phase must
  * complete before the next phase is executed. A phase is complete when all
  * tasks registered with that phase have completed and are no longer
  * running.
@@ -276,33 +855,161 @@ func (s *MleScheduler) DeleteTask(phase *MlePhase, task *MleTask) bool {
  * algorithm.
  * </p><p>
  * To discontinue execution, the scheduler must be flagged by specifying
- * that it is Ok to exit. This is done using the <code>setExitOk</code>
- * method. The scheduler will finish executing the current phase and exit
- * prior to starting the next phase.
+ * that it is Ok to exit. This is done using the <code>SetExitOk</code>
+ * or <code>Shutdown</code> methods. The running tasks are cancelled
+ * and the scheduler exits once they have completed.
  * </p><p>
  * No state is maintained between phases. Therefore an application which
  * has discontinued execution by setting the exit Ok flag should not expect
  * the scheduler to start up again at the next phase if this method is
  * invoked again. <code>run</code> will always invalidate the exit Ok flag
  * and start at the first scheduled phase.
+ * </p>
+ *
+ * @param done If not <b>nil</b>, the channel is signaled when the
+ * scheduler has exited.
  */
 func (s *MleScheduler) Run(done chan bool) {
+	s.RunContext(context.Background())
+	if done != nil {
+		done <- true
+	}
+}
+
+/**
+ * Executes scheduled phases until <i>ctx</i> is done or the scheduler
+ * is flagged to exit. The cancellation of <i>ctx</i> is propagated to the
+ * running tasks. While no phase is registered, the scheduler waits for one.
+ *
+ * @param ctx The context used to cancel the scheduler.
+ *
+ * @return <b>nil</b> is returned if the scheduler was flagged to exit.
+ * Otherwise, an error wrapping the context's error is returned.
+ *
+ * @see Run
+ */
+func (s *MleScheduler) RunContext(ctx context.Context) *mle_core.MleError {
+	s.lock.Lock()
+	if s.m_stopped != nil {
+		s.lock.Unlock()
+		return mle_core.NewMleError("MleScheduler: scheduler is already running.", 0, nil)
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	stopped := make(chan bool)
+	s.m_cancel = cancel
+	s.m_stopped = stopped
 	s.m_exitOK = false
-	for i := 0; i < len(*s.m_phases); i++ {
-		/* Fork off tasks in task list scheduled for this phase. */ 
-		phase := s.m_phases.ElementAt(i).(* MlePhase)
- 
-		/* Wait for phase[i] to complete. */
-		phase.Run(nil)
-		if s.m_exitOK {
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		cancel()
+		s.m_cancel = nil
+		s.m_stopped = nil
+		s.lock.Unlock()
+		close(stopped)
+	}()
+
+	for !s.isExitOk() && (runCtx.Err() == nil) {
+		/* Nothing to schedule, wait for a phase or to be stopped. */
+		if !s.waitForPhases(runCtx) {
 			break
 		}
+		env, phases := s.beginFrame()
+		for _, phase := range phases {
+			/* Fork off tasks in task list scheduled for this phase and wait for them to complete. */
+			err := s.runPhase(runCtx, env, phase)
+			if s.isExitOk() || (runCtx.Err() != nil) {
+				break
+			}
+			if err != nil {
+				/* A task aborted the frame, start over at the first phase. */
+				break
+			}
+
+			/* Encourage other goroutines to run. */
+			runtime.Gosched()
+		}
+		s.endFrame(env)
+	}
+
+	if ctx.Err() != nil {
+		return mle_core.NewMleError("MleScheduler: run cancelled.", 0, ctx.Err())
+	}
+	return nil
+}
+
+/**
+ * Executes each scheduled phase once, in the order in which the phases
+ * were registered. A phase must complete before the next phase is executed.
+ *
+ * @param ctx The context used to cancel the tasks.
+ *
+ * @return <b>nil</b> is returned if all phases ran to completion.
+ * Otherwise, the error of the cancelled or aborted phase is returned.
+ *
+ * @see MleFrameLoop
+ */
+func (s *MleScheduler) RunFrame(ctx context.Context) *mle_core.MleError {
+	env, phases := s.beginFrame()
+	defer s.endFrame(env)
+	for _, phase := range phases {
+		if err := s.runPhase(ctx, env, phase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Shuts down the scheduler. The scheduler is flagged to exit, the running
+ * tasks are cancelled and <code>Shutdown</code> waits for them to complete.
+ *
+ * @param ctx The context whose deadline limits the wait.
+ *
+ * @return <b>nil</b> is returned if the scheduler has stopped. Otherwise,
+ * if <i>ctx</i> is done before the running tasks complete, an error
+ * wrapping the context's error is returned.
+ */
+func (s *MleScheduler) Shutdown(ctx context.Context) *mle_core.MleError {
+	s.lock.Lock()
+	s.m_exitOK = true
+	if s.m_cancel != nil {
+		s.m_cancel()
+	}
+	stopped := s.m_stopped
+	s.lock.Unlock()
 
-		/* Encourage other goroutines to run. */
-		runtime.Gosched()
+	if stopped == nil {
+		return nil
+	}
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return mle_core.NewMleError("MleScheduler: shutdown incomplete, tasks are still running.", 0, ctx.Err())
 	}
 }
 
+// Take a snapshot of the registered phases.
+func (s *MleScheduler) getPhases() []*MlePhase {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.stagedPhases()
+}
+
+// Take a snapshot of the registered phases, including the changes made
+// while a frame runs, so its indices match those of viewPhases. The caller
+// must hold the lock.
+func (s *MleScheduler) stagedPhases() []*MlePhase {
+	view := s.viewPhases()
+	phases := make([]*MlePhase, len(*view))
+	for i := range phases {
+		phases[i] = view.ElementAt(i).(*MlePhase)
+	}
+	return phases
+}
+
 /**
  * Run the specified phase.
  * 
@@ -314,35 +1021,50 @@ func (s *MleScheduler) Go(phase *MlePhase) {
 
 /**
  * Dumps the list of registered phases for this scheduler. It will also
- * list the tasks associated with each phase.
+ * list the tasks associated with each phase, in their resolved execution
+ * order, along with the tasks they depend on. If the scheduler has a
+ * profiler, the latest timing stats are listed as well.
  */
 func (s *MleScheduler) Dump() {
 	var buf bytes.Buffer
 
-	for i := 0; i < s.GetNumberOfPhases(); i++ {
-		phase := s.GetPhase(i)
-
+	for i, phase := range s.getPhases() {
 		buf.WriteString("Phase ")
 		buf.WriteString(strconv.Itoa(i+1))
 		buf.WriteString(": ")
 		buf.WriteString(phase.GetName())
 		buf.WriteString("\n")
-			 
-		for j := 0; j < phase.GetNumberOfTasks(); j++ {
-			task := phase.GetTask(j)
 
+		tasks, err := phase.GetExecutionOrder()
+		if err != nil {
+			buf.WriteString("\t")
+			buf.WriteString(err.What)
+			buf.WriteString("\n")
+			continue
+		}
+		for j, task := range tasks {
 			buf.WriteString("\tTask ")
 			buf.WriteString(strconv.Itoa(j+1))
 			buf.WriteString(": ")
+			buf.WriteString(_TaskLabel(task))
 
-			if (task.GetName() != "") {
-				buf.WriteString(task.GetName())
-			} else {
-				buf.WriteString("empty")
+			var deps []string
+			for _, dep := range task.GetDependencies() {
+				deps = append(deps, _TaskLabel(dep))
+			}
+			deps = append(deps, task.GetDependencyNames()...)
+			if len(deps) > 0 {
+				buf.WriteString(" (after ")
+				buf.WriteString(strings.Join(deps, ", "))
+				buf.WriteString(")")
 			}
 			buf.WriteString("\n")
 		}
 	}
 
+	if profiler := s.GetProfiler(); profiler != nil {
+		buf.WriteString(profiler.String())
+	}
+
 	fmt.Println(buf.String())
 }
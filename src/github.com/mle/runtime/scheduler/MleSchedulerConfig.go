@@ -0,0 +1,449 @@
+/**
+ * @file MleSchedulerConfig.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package scheduler
+
+// Import go packages.
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_util "github.com/mle/runtime/util"
+)
+
+// The failure policies, by the name used in a configuration.
+var g_failurePolicyNames = map[string]int{
+	"ignore":      MLE_TASK_FAILURE_IGNORE,
+	"retry":       MLE_TASK_FAILURE_RETRY,
+	"disable":     MLE_TASK_FAILURE_DISABLE,
+	"abort-frame": MLE_TASK_FAILURE_ABORT_FRAME,
+}
+
+/**
+ * <code>MleTaskConfig</code> describes a task of a <code>MlePhaseConfig</code>.
+ */
+type MleTaskConfig struct {
+	/** The name of the task, unique within the scheduler. */
+	Name string `json:"name"`
+	/** The registered Runnable class; if empty, the name of the task is used. */
+	Runnable string `json:"runnable,omitempty"`
+	/** The names of the tasks which must complete before this task runs. */
+	After []string `json:"after,omitempty"`
+	/** Whether the task is enabled; enabled if omitted. */
+	Enabled *bool `json:"enabled,omitempty"`
+	/** The number of frames between runs; every frame if omitted. */
+	FrameInterval int `json:"frameInterval,omitempty"`
+	/** The first frame run. */
+	FrameOffset int `json:"frameOffset,omitempty"`
+	/** The minimum time between runs, as a duration such as "250ms". */
+	TimeInterval string `json:"timeInterval,omitempty"`
+	/** The failure policy: "ignore", "retry", "disable" or "abort-frame". */
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+	/** The number of retries of the "retry" failure policy. */
+	Retries int `json:"retries,omitempty"`
+}
+
+/**
+ * <code>MlePhaseConfig</code> describes a phase of a
+ * <code>MleSchedulerConfig</code>.
+ */
+type MlePhaseConfig struct {
+	/** The name of the phase, unique within the scheduler. */
+	Name string `json:"name"`
+	/** Whether the phase is enabled; enabled if omitted. */
+	Enabled *bool `json:"enabled,omitempty"`
+	/** Whether the tasks run serially on a single worker. */
+	Serial bool `json:"serial,omitempty"`
+	/** The number of workers overriding the scheduler's pool; 0 uses the scheduler's pool. */
+	WorkerPoolSize int `json:"workerPoolSize,omitempty"`
+	/** The number of frames between runs; every frame if omitted. */
+	FrameInterval int `json:"frameInterval,omitempty"`
+	/** The first frame run. */
+	FrameOffset int `json:"frameOffset,omitempty"`
+	/** The minimum time between runs, as a duration such as "1s". */
+	TimeInterval string `json:"timeInterval,omitempty"`
+	/** The tasks of the phase. */
+	Tasks []MleTaskConfig `json:"tasks,omitempty"`
+}
+
+/**
+ * <code>MleSchedulerConfig</code> describes the phases and tasks of a
+ * <code>MleScheduler</code>, so that they may be declared in a file rather
+ * than wired in code.
+ * <p>
+ * The phases run in the order they are listed. The Runnable of each task
+ * is created from a class registered with <code>RegisterRunnableClass</code>.
+ * A configuration is loaded from JSON or YAML; both use the same keys.
+ * </p>
+ *
+ * @see NewMleSchedulerFromConfig
+ * @see mle_core.RegisterRunnableClass
+ */
+type MleSchedulerConfig struct {
+	/** The number of workers running the tasks; 0 runs each task on its own thread. */
+	WorkerPoolSize int `json:"workerPoolSize,omitempty"`
+	/** The phases, in the order they run. */
+	Phases []MlePhaseConfig `json:"phases"`
+}
+
+/**
+ * Load a scheduler configuration from JSON. Unknown fields are rejected.
+ *
+ * @param r The reader of the JSON configuration.
+ *
+ * @return The validated configuration is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the JSON can not
+ * be decoded or the configuration is not valid.
+ */
+func LoadMleSchedulerConfig(r io.Reader) (*MleSchedulerConfig, *mle_core.MleError) {
+	config := new(MleSchedulerConfig)
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
+		return nil, mle_core.NewMleError("MleSchedulerConfig: unable to decode configuration: "+err.Error(), 0, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+/**
+ * Load a scheduler configuration from YAML. The keys are the same as those
+ * of the JSON configuration, and unknown keys are rejected.
+ *
+ * @param r The reader of the YAML configuration.
+ *
+ * @return The validated configuration is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the YAML can not
+ * be decoded or the configuration is not valid.
+ */
+func LoadMleSchedulerConfigYaml(r io.Reader) (*MleSchedulerConfig, *mle_core.MleError) {
+	value, err := mle_util.DecodeYaml(r)
+	if err != nil {
+		return nil, mle_core.NewMleError("MleSchedulerConfig: unable to decode configuration: "+err.Error(), 0, err)
+	}
+
+	// Decode the YAML document by the JSON keys of the configuration.
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, mle_core.NewMleError("MleSchedulerConfig: unable to decode configuration: "+err.Error(), 0, err)
+	}
+	return LoadMleSchedulerConfig(bytes.NewReader(data))
+}
+
+/**
+ * Load a scheduler configuration from a file. A file with a ".yaml" or
+ * ".yml" extension is decoded as YAML; any other file as JSON.
+ *
+ * @param path The path of the file.
+ *
+ * @return The validated configuration is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the file can not
+ * be read or decoded, or the configuration is not valid.
+ */
+func LoadMleSchedulerConfigFile(path string) (*MleSchedulerConfig, *mle_core.MleError) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, mle_core.NewMleError("MleSchedulerConfig: unable to open "+path+".", 0, err)
+	}
+	defer file.Close()
+	ext := strings.ToLower(filepath.Ext(path))
+	if (ext == ".yaml") || (ext == ".yml") {
+		return LoadMleSchedulerConfigYaml(file)
+	}
+	return LoadMleSchedulerConfig(file)
+}
+
+// Collects the problems found by validation.
+type _ConfigErrors []error
+
+// Record a problem.
+func (errs *_ConfigErrors) add(msg string) {
+	*errs = append(*errs, mle_core.NewMleError(msg, 0, nil))
+}
+
+// Check that the frame and time intervals of a phase or task are valid.
+func (errs *_ConfigErrors) checkSchedule(owner string, interval int, offset int, period string) {
+	if interval < 0 {
+		errs.add(owner + " has negative frame interval " + strconv.Itoa(interval) + ".")
+	}
+	if offset < 0 {
+		errs.add(owner + " has negative frame offset " + strconv.Itoa(offset) + ".")
+	}
+	if period != "" {
+		if d, err := time.ParseDuration(period); (err != nil) || (d < 0) {
+			errs.add(owner + " has invalid time interval \"" + period + "\".")
+		}
+	}
+}
+
+/**
+ * Validate the configuration. Every problem found is reported: empty or
+ * duplicate names, unregistered Runnable classes, invalid intervals or
+ * failure policies, and dependencies which are unknown, on a later phase,
+ * or form a cycle.
+ *
+ * @throws MleRuntimeException This exception is thrown if the
+ * configuration is not valid. The error lists the problems, and wraps
+ * an error for each of them.
+ */
+func (config *MleSchedulerConfig) Validate() *mle_core.MleError {
+	var errs _ConfigErrors
+	registry := mle_core.GetMleClassRegistryInstance()
+
+	if config.WorkerPoolSize < 0 {
+		errs.add("Negative worker pool size " + strconv.Itoa(config.WorkerPoolSize) + ".")
+	}
+
+	phaseNames := make(map[string]bool)
+	taskNames := make(map[string]bool)
+	for i, phaseConfig := range config.Phases {
+		phase := "Phase " + strconv.Itoa(i+1)
+		if phaseConfig.Name == "" {
+			errs.add(phase + " has no name.")
+		} else {
+			phase = "Phase " + phaseConfig.Name
+			if phaseNames[phaseConfig.Name] {
+				errs.add(phase + " is declared more than once.")
+			}
+			phaseNames[phaseConfig.Name] = true
+		}
+		if phaseConfig.WorkerPoolSize < 0 {
+			errs.add(phase + " has negative worker pool size " + strconv.Itoa(phaseConfig.WorkerPoolSize) + ".")
+		}
+		errs.checkSchedule(phase, phaseConfig.FrameInterval, phaseConfig.FrameOffset, phaseConfig.TimeInterval)
+
+		/* Check the tasks, then their dependencies on this and earlier phases. */
+		var tasks []*MleTask
+		for j, taskConfig := range phaseConfig.Tasks {
+			task := "Task " + strconv.Itoa(j+1) + " of " + phase
+			if taskConfig.Name == "" {
+				errs.add(task + " has no name.")
+				continue
+			}
+			task = "Task " + taskConfig.Name
+			if taskNames[taskConfig.Name] {
+				errs.add(task + " is declared more than once.")
+			}
+			taskNames[taskConfig.Name] = true
+
+			class := taskConfig.getRunnableClass()
+			if entry := registry.Lookup(class); entry == nil {
+				if _, found := mle_util.GClassRegistry[class]; !found {
+					errs.add(task + " has unregistered Runnable class " + class + ".")
+				}
+			} else if entry.GetKind() != mle_core.MLE_CLASS_RUNNABLE {
+				errs.add(task + " has class " + class + ", which is a " + entry.GetKind().String() + ", not a Runnable.")
+			}
+			errs.checkSchedule(task, taskConfig.FrameInterval, taskConfig.FrameOffset, taskConfig.TimeInterval)
+			if taskConfig.FailurePolicy != "" {
+				if _, found := g_failurePolicyNames[taskConfig.FailurePolicy]; !found {
+					errs.add(task + " has unknown failure policy \"" + taskConfig.FailurePolicy + "\".")
+				}
+			}
+			if taskConfig.Retries < 0 {
+				errs.add(task + " has negative number of retries " + strconv.Itoa(taskConfig.Retries) + ".")
+			}
+
+			placeholder := NewMleTaskWithName(nil, taskConfig.Name)
+			for _, dep := range taskConfig.After {
+				placeholder.AddDependencyWithName(dep)
+			}
+			tasks = append(tasks, placeholder)
+		}
+		for _, task := range tasks {
+			for _, dep := range task.GetDependencyNames() {
+				// Tasks of later phases have not been declared yet.
+				if !taskNames[dep] {
+					errs.add("Task " + task.GetName() + " runs after unknown or later task " + dep + ".")
+				}
+			}
+		}
+		if _, err := _NewTaskGraph(tasks); err != nil {
+			errs.add(phase + ": " + err.What)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.(*mle_core.MleError).What)
+	}
+	msg := "MleSchedulerConfig: invalid configuration: " + strings.Join(msgs, " ")
+	return mle_core.NewMleError(msg, 0, errors.Join(errs...))
+}
+
+// Get the registered Runnable class of a task.
+func (config *MleTaskConfig) getRunnableClass() string {
+	if config.Runnable != "" {
+		return config.Runnable
+	}
+	return config.Name
+}
+
+// Apply the enabled flag, intervals and failure policy of the configuration to a task.
+func (config *MleTaskConfig) configure(task *MleTask) *mle_core.MleError {
+	if config.Enabled != nil {
+		task.SetEnabled(*config.Enabled)
+	}
+	if (config.FrameInterval > 0) || (config.FrameOffset > 0) {
+		interval := config.FrameInterval
+		if interval == 0 {
+			interval = 1
+		}
+		if err := task.SetFrameInterval(interval, config.FrameOffset); err != nil {
+			return err
+		}
+	}
+	if config.TimeInterval != "" {
+		period, _ := time.ParseDuration(config.TimeInterval)
+		task.SetTimeInterval(period)
+	}
+	if config.FailurePolicy != "" {
+		return task.SetFailurePolicy(g_failurePolicyNames[config.FailurePolicy], config.Retries)
+	}
+	return nil
+}
+
+// Apply the enabled flag, intervals and worker pool of the configuration to a phase.
+func (config *MlePhaseConfig) configure(phase *MlePhase) *mle_core.MleError {
+	if config.Enabled != nil {
+		phase.SetEnabled(*config.Enabled)
+	}
+	if (config.FrameInterval > 0) || (config.FrameOffset > 0) {
+		interval := config.FrameInterval
+		if interval == 0 {
+			interval = 1
+		}
+		if err := phase.SetFrameInterval(interval, config.FrameOffset); err != nil {
+			return err
+		}
+	}
+	if config.TimeInterval != "" {
+		period, _ := time.ParseDuration(config.TimeInterval)
+		phase.SetTimeInterval(period)
+	}
+	if config.Serial {
+		phase.SetSerial(true)
+	} else if config.WorkerPoolSize > 0 {
+		phase.SetWorkerPoolSize(config.WorkerPoolSize)
+	}
+	return nil
+}
+
+// Configure a phase and add the tasks of the configuration, creating a
+// Runnable for each task from its registered class.
+func (config *MlePhaseConfig) build(phase *MlePhase) *mle_core.MleError {
+	if err := config.configure(phase); err != nil {
+		return err
+	}
+	registry := mle_core.GetMleClassRegistryInstance()
+	for _, taskConfig := range config.Tasks {
+		instance, err := registry.NewInstance(taskConfig.getRunnableClass(), mle_core.MLE_CLASS_RUNNABLE)
+		if err != nil {
+			return mle_core.NewMleError("MleSchedulerConfig: task "+taskConfig.Name+": "+err.What, 0, err)
+		}
+		runnable, ok := instance.(mle_util.Runnable)
+		if !ok {
+			msg := "MleSchedulerConfig: task " + taskConfig.Name + ": class " + taskConfig.getRunnableClass() + " is not a Runnable."
+			return mle_core.NewMleError(msg, 0, nil)
+		}
+		task := NewMleTaskWithName(runnable, taskConfig.Name)
+		for _, dep := range taskConfig.After {
+			task.AddDependencyWithName(dep)
+		}
+		if err := taskConfig.configure(task); err != nil {
+			return err
+		}
+		phase.AddTask(task)
+	}
+	return nil
+}
+
+/**
+ * Creates a new MleScheduler with the phases and tasks described by a
+ * configuration. A Runnable is created for each task from its registered
+ * class.
+ *
+ * @param config The configuration.
+ *
+ * @return The configured scheduler is returned.
+ *
+ * @throws MleRuntimeException This exception is thrown if the
+ * configuration is not valid, or a Runnable can not be created.
+ */
+func NewMleSchedulerFromConfig(config *MleSchedulerConfig) (*MleScheduler, *mle_core.MleError) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	scheduler := NewMleScheduler()
+	var phases []*MlePhase
+	for _, phaseConfig := range config.Phases {
+		phase := NewMlePhaseWithName(phaseConfig.Name)
+		phases = append(phases, phase)
+		err := phaseConfig.build(phase)
+		if (err == nil) && !scheduler.AddPhase(phase) {
+			err = mle_core.NewMleError("MleSchedulerConfig: unable to add phase "+phaseConfig.Name+".", 0, nil)
+		}
+		if err != nil {
+			// Release the workers of the phases built so far.
+			for _, built := range phases {
+				built.SetWorkerPoolSize(0)
+			}
+			return nil, err
+		}
+	}
+	scheduler.SetWorkerPoolSize(config.WorkerPoolSize)
+	return scheduler, nil
+}
@@ -41,17 +41,38 @@ package scheduler
 
 // Import go packages.
 import (
+	"context"
+	"runtime/pprof"
+	"strconv"
 	"sync"
+	"time"
 
 	mle_util "github.com/mle/runtime/util"
+	mle_core "github.com/mle/runtime/core"
 )
 
+/** A failed task is reported and otherwise ignored. This is the default. */
+const MLE_TASK_FAILURE_IGNORE int = 0
+
+/** A failed task is run again, up to the task's number of retries. */
+const MLE_TASK_FAILURE_RETRY int = 1
+
+/** A failed task is reported and disabled, so that it no longer runs. */
+const MLE_TASK_FAILURE_DISABLE int = 2
+
+/** A failed task is reported and the remaining tasks of the frame are skipped. */
+const MLE_TASK_FAILURE_ABORT_FRAME int = 3
+
 /**
  * <code>MleTask</code> is a class that manages a named thread.
  * <p>
  * MleTask encapsulates an autonamous action, using the
  * <code>channels</code> class, for the Magic Lantern scheduler,
  * <code>MleScheduler</code>.
+ * </p><p>
+ * A task fails if its Runnable panics or, for an
+ * <code>ErrorRunnable</code>, returns an error. The failure policy of the
+ * task determines what happens next.
  * </p>
  *
  * @see MlePhase
@@ -66,6 +87,24 @@ type MleTask struct {
     m_name string
     // A flag indicating whether the task is running.
 	m_running bool
+	// The tasks which must complete before this task runs.
+	m_dependencies []*MleTask
+	// The names of the tasks which must complete before this task runs.
+	m_dependencyNames []string
+	// The policy applied when the task fails.
+	m_failurePolicy int
+	// The number of times a failed task is run again.
+	m_retries int
+	// The frames on which the task runs.
+	m_schedule _RunSchedule
+	// The error of the last invocation, nil if it succeeded.
+	m_lastError error
+	// The number of attempts made by the last invocation.
+	m_attempts int
+	// The time the last invocation started.
+	m_lastStart time.Time
+	// The time the last invocation ended.
+	m_lastEnd time.Time
 	// The task work group.
 	m_wg sync.WaitGroup
 	// Internal lock used for protecting sensitve code.
@@ -109,22 +148,423 @@ func (t *MleTask) GetName() string {
 	return t.m_name
 }
 
+/**
+ * Declares that the specified task must complete before this task runs.
+ * <p>
+ * The dependency may belong to the same phase, in which case the phase
+ * orders the tasks, or to an earlier phase of the scheduler. Dependencies
+ * should be declared before the task is added to a phase, so that cycles
+ * are detected by <code>AddTask</code>.
+ * </p>
+ *
+ * @param task The task this task depends on.
+ *
+ * @see MlePhase#AddTask
+ */
+func (t *MleTask) AddDependency(task *MleTask) {
+	if task == nil {
+		return
+	}
+	t.lock.Lock()
+	t.m_dependencies = append(t.m_dependencies, task)
+	t.lock.Unlock()
+}
+
+/**
+ * Declares that the task with the specified name must complete before
+ * this task runs.
+ *
+ * @param name The name of the task this task depends on.
+ *
+ * @see AddDependency
+ */
+func (t *MleTask) AddDependencyWithName(name string) {
+	if name == "" {
+		return
+	}
+	t.lock.Lock()
+	t.m_dependencyNames = append(t.m_dependencyNames, name)
+	t.lock.Unlock()
+}
+
+/**
+ * Get the tasks this task depends on, declared by reference.
+ *
+ * @return A copy of the dependencies is returned.
+ */
+func (t *MleTask) GetDependencies() []*MleTask {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]*MleTask(nil), t.m_dependencies...)
+}
+
+/**
+ * Get the names of the tasks this task depends on, declared by name.
+ *
+ * @return A copy of the dependency names is returned.
+ */
+func (t *MleTask) GetDependencyNames() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]string(nil), t.m_dependencyNames...)
+}
+
+// Determine whether this task depends on the specified task.
+func (t *MleTask) dependsOn(task *MleTask) bool {
+	for _, dep := range t.GetDependencies() {
+		if dep == task {
+			return true
+		}
+	}
+	if task.GetName() != "" {
+		for _, name := range t.GetDependencyNames() {
+			if name == task.GetName() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/**
+ * Set the policy applied when the task fails.
+ *
+ * @param policy One of <code>MLE_TASK_FAILURE_IGNORE</code>,
+ * <code>MLE_TASK_FAILURE_RETRY</code>, <code>MLE_TASK_FAILURE_DISABLE</code>
+ * or <code>MLE_TASK_FAILURE_ABORT_FRAME</code>.
+ * @param retries The number of times a failed task is run again, used by
+ * the <code>MLE_TASK_FAILURE_RETRY</code> policy.
+ *
+ * @throws MleRuntimeException This exception is thrown if the
+ * policy is unknown or the number of retries is negative.
+ */
+func (t *MleTask) SetFailurePolicy(policy int, retries int) *mle_core.MleError {
+	if (policy < MLE_TASK_FAILURE_IGNORE) || (policy > MLE_TASK_FAILURE_ABORT_FRAME) {
+		return mle_core.NewMleError("MleTask: unknown failure policy "+strconv.Itoa(policy)+".", 0, nil)
+	}
+	if retries < 0 {
+		return mle_core.NewMleError("MleTask: negative number of retries "+strconv.Itoa(retries)+".", 0, nil)
+	}
+	t.lock.Lock()
+	t.m_failurePolicy = policy
+	t.m_retries = retries
+	t.lock.Unlock()
+	return nil
+}
+
+/**
+ * Get the policy applied when the task fails.
+ *
+ * @return The failure policy and the number of retries are returned.
+ */
+func (t *MleTask) GetFailurePolicy() (int, int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_failurePolicy, t.m_retries
+}
+
+/**
+ * Enable or disable the task. A phase skips a disabled task, and the tasks
+ * which depend on it run as if it had completed.
+ *
+ * @param enabled <b>true</b> to enable the task; <b>false</b> to disable it.
+ */
+func (t *MleTask) SetEnabled(enabled bool) {
+	t.lock.Lock()
+	t.m_schedule.m_disabled = !enabled
+	t.lock.Unlock()
+}
+
+/**
+ * Determine whether the task is enabled.
+ *
+ * @return <b>true</b> is returned if the task is enabled.
+ */
+func (t *MleTask) IsEnabled() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return !t.m_schedule.m_disabled
+}
+
+/**
+ * Run the task every <i>interval</i> frames. Frames are counted by the
+ * scheduler from 0, and the task first runs on frame <i>offset</i>. On
+ * the frames the task is not due, the phase skips it and the tasks which
+ * depend on it run as if it had completed.
+ *
+ * @param interval The number of frames between runs; 1 runs every frame.
+ * @param offset The first frame run, used to spread tasks with the same
+ * interval over different frames.
+ *
+ * @throws MleRuntimeException This exception is thrown if the interval
+ * is not positive or the offset is negative.
+ */
+func (t *MleTask) SetFrameInterval(interval int, offset int) *mle_core.MleError {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_schedule.setFrameInterval(interval, offset)
+}
+
+/**
+ * Get the number of frames between runs of the task.
+ *
+ * @return The interval and offset are returned.
+ */
+func (t *MleTask) GetFrameInterval() (int, int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.m_schedule.m_interval == 0 {
+		return 1, t.m_schedule.m_offset
+	}
+	return t.m_schedule.m_interval, t.m_schedule.m_offset
+}
+
+/**
+ * Run the task at most once every <i>period</i>, measured by the frame
+ * time of the scheduler.
+ *
+ * @param period The minimum time between runs; 0 runs regardless of time.
+ *
+ * @see MleScheduler#SetClock
+ */
+func (t *MleTask) SetTimeInterval(period time.Duration) {
+	t.lock.Lock()
+	t.m_schedule.m_period = period
+	t.lock.Unlock()
+}
+
+/**
+ * Get the minimum time between runs of the task.
+ *
+ * @return The period is returned.
+ */
+func (t *MleTask) GetTimeInterval() time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_schedule.m_period
+}
+
+/**
+ * Set the condition which must hold for the task to run. The condition is
+ * evaluated by the phase when the task is due, before it is invoked.
+ *
+ * @param condition The condition. If <b>nil</b>, the task runs whenever
+ * it is due.
+ */
+func (t *MleTask) SetRunCondition(condition func() bool) {
+	t.lock.Lock()
+	t.m_schedule.m_condition = condition
+	t.lock.Unlock()
+}
+
+// Determine whether the task runs on the specified frame, recording the run
+// if it does.
+func (t *MleTask) shouldRun(frame int64, now time.Time) bool {
+	return t.m_schedule.shouldRun(&t.lock, frame, now)
+}
+
+/**
+ * Get the error of the last invocation of the task.
+ *
+ * @return The error returned by the Runnable, or a
+ * <code>PanicError</code> if it panicked, is returned. <b>nil</b> is
+ * returned if the last invocation succeeded.
+ */
+func (t *MleTask) GetLastError() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_lastError
+}
+
+/**
+ * Get the number of attempts made by the last invocation of the task.
+ *
+ * @return The number of times the Runnable was run is returned.
+ */
+func (t *MleTask) GetAttempts() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_attempts
+}
+
+/**
+ * Get the time the last invocation of the task started.
+ *
+ * @return The start time is returned, or the zero time if the task has
+ * not run.
+ */
+func (t *MleTask) GetLastStartTime() time.Time {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_lastStart
+}
+
+/**
+ * Get the time the last invocation of the task ended.
+ *
+ * @return The end time is returned, or the zero time if the task has
+ * not run.
+ */
+func (t *MleTask) GetLastEndTime() time.Time {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_lastEnd
+}
+
+/**
+ * Get the duration of the last invocation of the task, including retries.
+ *
+ * @return The duration is returned.
+ */
+func (t *MleTask) GetLastDuration() time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_lastEnd.Sub(t.m_lastStart)
+}
+
+// Run the Runnable in the calling goroutine, applying the failure policy.
+// The run has completed when the Runnable returns. The error of the last
+// attempt is returned. If ctx has an "mle_phase" pprof label, the Runnable
+// runs with an "mle_task" label as well.
+func (t *MleTask) execute(ctx context.Context, runnable mle_util.Runnable) error {
+	if runnable == nil {
+		return nil
+	}
+	policy, retries := t.GetFailurePolicy()
+	if policy != MLE_TASK_FAILURE_RETRY {
+		retries = 0
+	}
+
+	var err error
+	attempts := 0
+	run := func(ctx context.Context) {
+		for (attempts == 0) || ((err != nil) && (attempts <= retries) && (ctx.Err() == nil)) {
+			attempts++
+			err = mle_util.RunRunnable(ctx, runnable, make(chan bool, 1))
+		}
+	}
+	start := time.Now()
+	if _, labelled := pprof.Label(ctx, "mle_phase"); labelled {
+		pprof.Do(ctx, pprof.Labels("mle_task", _TaskLabel(t)), run)
+	} else {
+		run(ctx)
+	}
+	end := time.Now()
+
+	t.lock.Lock()
+	t.m_lastError = err
+	t.m_attempts = attempts
+	t.m_lastStart = start
+	t.m_lastEnd = end
+	if (err != nil) && (policy == MLE_TASK_FAILURE_DISABLE) {
+		t.m_schedule.m_disabled = true
+	}
+	t.lock.Unlock()
+	return err
+}
+
+// _TaskRunnable runs a task on its own thread, applying its failure policy.
+type _TaskRunnable struct {
+	// The task being run.
+	m_task *MleTask
+	// The Runnable of the task.
+	m_runnable mle_util.Runnable
+}
+
+// Run implements the Runnable interface.
+func (r *_TaskRunnable) Run(done chan bool) {
+	r.RunError(context.Background())
+	if done != nil {
+		done <- true
+	}
+}
+
+// RunError implements the ErrorRunnable interface.
+func (r *_TaskRunnable) RunError(ctx context.Context) error {
+	return r.m_task.execute(ctx, r.m_runnable)
+}
+
+// String implements the IObject interface.
+func (r *_TaskRunnable) String() string {
+	return r.m_task.GetName()
+}
+
 /**
  * Executes task by starting a thread with the Runnable
  * specified during construction.
  */
 func (t *MleTask) Invoke() {
+	t.InvokeContext(context.Background())
+}
+
+/**
+ * Executes task by starting a thread with the Runnable
+ * specified during construction. If the Runnable is a
+ * <code>ContextRunnable</code>, the cancellation of <i>ctx</i>
+ * is propagated to it.
+ * <p>
+ * The task has completed when the Runnable returns. A panic is recovered
+ * and, like an error returned by an <code>ErrorRunnable</code>, handled by
+ * the task's failure policy and reported by <code>GetLastError</code>.
+ * </p>
+ *
+ * @param ctx The context used to cancel the task.
+ */
+func (t *MleTask) InvokeContext(ctx context.Context) {
 	t.lock.Lock()
 
 	t.m_running = true
+	runnable := &_TaskRunnable{t, t.m_task}
 	if t.m_name != "" {
-		t.m_thread = mle_util.NewThreadWithRunnableAndName(t.m_task, t.m_name)
+		t.m_thread = mle_util.NewThreadWithRunnableAndName(runnable, t.m_name)
 	} else {
-		t.m_thread = mle_util.NewThreadWithRunnable(t.m_task)
+		t.m_thread = mle_util.NewThreadWithRunnable(runnable)
 	}
-	t.m_thread.Start(&t.m_wg)
+	t.m_thread.StartContext(ctx, &t.m_wg)
+
+	t.lock.Unlock()
+}
 
+// Executes the task on a worker of the specified pool. The onDone function
+// is called with the error of the task when it has completed, unless an
+// error is returned.
+func (t *MleTask) invokeOnPool(ctx context.Context, pool *MleWorkerPool, onDone func(err error)) *mle_core.MleError {
+	t.lock.Lock()
+	runnable := t.m_task
+	t.m_thread = nil
+	if runnable == nil {
+		t.m_running = false
+		t.lock.Unlock()
+		onDone(nil)
+		return nil
+	}
+	t.m_running = true
+	t.m_wg.Add(1)
 	t.lock.Unlock()
+
+	complete := func() {
+		t.lock.Lock()
+		t.m_running = false
+		t.lock.Unlock()
+		t.m_wg.Done()
+	}
+	err := pool.Submit(func() {
+		// The worker is released when the Runnable returns.
+		err := t.execute(ctx, runnable)
+		complete()
+		onDone(err)
+	})
+	if err != nil {
+		complete()
+	}
+	return err
+}
+
+/**
+ * Waits for all invocations of this task to complete.
+ */
+func (t *MleTask) Wait() {
+	t.m_wg.Wait()
 }
 
 /**
@@ -141,6 +581,9 @@ func (t *MleTask) IsRunning() bool {
 	 
 	if t.m_running == false {
 		status = false
+	} else if t.m_thread == nil {
+		// Running on a worker pool.
+		status = true
 	} else {
 		if (t.m_thread.IsAlive()) {
 			status = true
@@ -0,0 +1,157 @@
+/**
+ * @file MleTaskGraph.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package scheduler
+
+// Import go packages.
+import (
+	"strings"
+
+	mle_core "github.com/mle/runtime/core"
+)
+
+// _TaskGraph is the dependency graph of the tasks of a phase.
+type _TaskGraph struct {
+	// The tasks in execution order; a task follows all of its dependencies.
+	m_order []*MleTask
+	// The dependencies of each task within the phase.
+	m_dependencies map[*MleTask][]*MleTask
+	// The tasks within the phase depending on each task.
+	m_dependents map[*MleTask][]*MleTask
+}
+
+// Build the dependency graph of the specified tasks.
+//
+// Dependencies which are not among the tasks are ignored; they are
+// satisfied by an earlier phase. The order of independent tasks is the
+// order in which they were added.
+//
+// Parameters
+//   tasks - The tasks of the phase.
+//
+// Return
+//   The graph is returned, or an error naming the tasks of a dependency cycle.
+func _NewTaskGraph(tasks []*MleTask) (*_TaskGraph, *mle_core.MleError) {
+	g := new(_TaskGraph)
+	g.m_dependencies = make(map[*MleTask][]*MleTask)
+	g.m_dependents = make(map[*MleTask][]*MleTask)
+	dependents := g.m_dependents
+	pending := make(map[*MleTask]int)
+	for _, task := range tasks {
+		for _, dep := range tasks {
+			if task.dependsOn(dep) {
+				g.m_dependencies[task] = append(g.m_dependencies[task], dep)
+				dependents[dep] = append(dependents[dep], task)
+			}
+		}
+		pending[task] = len(g.m_dependencies[task])
+	}
+
+	// Kahn's algorithm, keeping the order in which tasks were added.
+	ready := make([]*MleTask, 0, len(tasks))
+	for _, task := range tasks {
+		if pending[task] == 0 {
+			ready = append(ready, task)
+		}
+	}
+	for len(ready) > 0 {
+		task := ready[0]
+		ready = ready[1:]
+		g.m_order = append(g.m_order, task)
+		for _, dependent := range dependents[task] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(g.m_order) < len(tasks) {
+		var names []string
+		for _, task := range tasks {
+			if pending[task] > 0 {
+				names = append(names, _TaskLabel(task))
+			}
+		}
+		msg := "Task dependency cycle among: " + strings.Join(names, ", ") + "."
+		return nil, mle_core.NewMleError(msg, 0, nil)
+	}
+	return g, nil
+}
+
+// Get the dependencies, by reference and by name, which are not among the tasks.
+func _UnresolvedDependencies(deps []*MleTask, depNames []string, tasks []*MleTask) ([]*MleTask, []string) {
+	var refs []*MleTask
+	var names []string
+
+	contains := func(dep *MleTask) bool {
+		for _, t := range tasks {
+			if t == dep {
+				return true
+			}
+		}
+		return false
+	}
+	for _, dep := range deps {
+		if !contains(dep) {
+			refs = append(refs, dep)
+		}
+	}
+	for _, name := range depNames {
+		found := false
+		for _, t := range tasks {
+			if t.GetName() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			names = append(names, name)
+		}
+	}
+	return refs, names
+}
+
+// Get a label identifying a task in messages.
+func _TaskLabel(task *MleTask) string {
+	if task.GetName() != "" {
+		return task.GetName()
+	}
+	return "empty"
+}
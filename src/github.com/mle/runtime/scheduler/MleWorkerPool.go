@@ -0,0 +1,301 @@
+/**
+ * @file MleWorkerPool.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package scheduler
+
+// Import go packages.
+import (
+	"runtime"
+	"sync"
+
+	mle_core "github.com/mle/runtime/core"
+)
+
+/**
+ * <code>MleWorkerPoolStats</code> holds the metrics of a
+ * <code>MleWorkerPool</code>.
+ */
+type MleWorkerPoolStats struct {
+	// The number of workers.
+	m_size int
+	// The number of jobs waiting for a worker.
+	m_queued int
+	// The largest number of jobs that have waited for a worker.
+	m_maxQueued int
+	// The number of workers running a job.
+	m_active int
+	// The number of jobs submitted.
+	m_submitted int64
+	// The number of jobs completed.
+	m_completed int64
+}
+
+/**
+ * Get the number of workers.
+ *
+ * @return The pool size is returned.
+ */
+func (stats MleWorkerPoolStats) GetSize() int {
+	return stats.m_size
+}
+
+/**
+ * Get the number of jobs waiting for a worker.
+ *
+ * @return The queue depth is returned.
+ */
+func (stats MleWorkerPoolStats) GetQueueDepth() int {
+	return stats.m_queued
+}
+
+/**
+ * Get the largest number of jobs that have waited for a worker.
+ *
+ * @return The maximum queue depth is returned.
+ */
+func (stats MleWorkerPoolStats) GetMaxQueueDepth() int {
+	return stats.m_maxQueued
+}
+
+/**
+ * Get the number of workers running a job.
+ *
+ * @return The number of active workers is returned.
+ */
+func (stats MleWorkerPoolStats) GetActiveWorkers() int {
+	return stats.m_active
+}
+
+/**
+ * Get the number of jobs submitted.
+ *
+ * @return The submitted job count is returned.
+ */
+func (stats MleWorkerPoolStats) GetSubmittedCount() int64 {
+	return stats.m_submitted
+}
+
+/**
+ * Get the number of jobs completed.
+ *
+ * @return The completed job count is returned.
+ */
+func (stats MleWorkerPoolStats) GetCompletedCount() int64 {
+	return stats.m_completed
+}
+
+/**
+ * The <code>MleWorkerPool</code> class runs jobs on a fixed number of
+ * reusable worker goroutines. Jobs are queued until a worker is free and
+ * are started in the order they were submitted.
+ *
+ * @see MleScheduler#SetWorkerPoolSize
+ * @see MlePhase#SetWorkerPoolSize
+ */
+type MleWorkerPool struct {
+	// The jobs waiting for a worker.
+	m_queue []func()
+	// Flag indicating that the pool has been closed.
+	m_closed bool
+	// The number of running frames which may still submit jobs.
+	m_users int
+	// Flag indicating that the pool is closed once it has no users.
+	m_retired bool
+	// Flag indicating that each worker is locked to an OS thread.
+	m_lockOSThread bool
+	// The metrics.
+	m_stats MleWorkerPoolStats
+	// Signals workers that a job is queued or the pool is closed.
+	m_cond *sync.Cond
+	// The running workers.
+	m_workers sync.WaitGroup
+	// Internal lock used for protecting sensitve code.
+	lock sync.Mutex
+}
+
+/**
+ * Creates a new MleWorkerPool and starts its workers.
+ *
+ * @param size The number of workers, at least 1.
+ */
+func NewMleWorkerPool(size int) *MleWorkerPool {
+	return newMleWorkerPool(size, false)
+}
+
+// Create a pool, optionally locking each worker to an OS thread.
+func newMleWorkerPool(size int, lockOSThread bool) *MleWorkerPool {
+	if size < 1 {
+		size = 1
+	}
+	p := new(MleWorkerPool)
+	p.m_lockOSThread = lockOSThread
+	p.m_stats.m_size = size
+	p.m_cond = sync.NewCond(&p.lock)
+	p.m_workers.Add(size)
+	for i := 0; i < size; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Run queued jobs until the pool is closed and the queue is empty.
+func (pool *MleWorkerPool) work() {
+	defer pool.m_workers.Done()
+	if pool.m_lockOSThread {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+	}
+
+	pool.lock.Lock()
+	for {
+		for (len(pool.m_queue) == 0) && !pool.m_closed {
+			pool.m_cond.Wait()
+		}
+		if len(pool.m_queue) == 0 {
+			pool.lock.Unlock()
+			return
+		}
+		job := pool.m_queue[0]
+		pool.m_queue[0] = nil
+		pool.m_queue = pool.m_queue[1:]
+		pool.m_stats.m_queued--
+		pool.m_stats.m_active++
+		pool.lock.Unlock()
+
+		job()
+
+		pool.lock.Lock()
+		pool.m_stats.m_active--
+		pool.m_stats.m_completed++
+	}
+}
+
+/**
+ * Queue a job to be run by a worker.
+ *
+ * @param job The job to run.
+ *
+ * @return An error is returned if the pool has been closed.
+ */
+func (pool *MleWorkerPool) Submit(job func()) *mle_core.MleError {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	if pool.m_closed {
+		return mle_core.NewMleError("MleWorkerPool: pool is closed.", 0, nil)
+	}
+	pool.m_queue = append(pool.m_queue, job)
+	pool.m_stats.m_submitted++
+	pool.m_stats.m_queued++
+	if pool.m_stats.m_queued > pool.m_stats.m_maxQueued {
+		pool.m_stats.m_maxQueued = pool.m_stats.m_queued
+	}
+	pool.m_cond.Signal()
+	return nil
+}
+
+/**
+ * Get the pool metrics.
+ *
+ * @return A copy of the metrics is returned.
+ */
+func (pool *MleWorkerPool) GetStats() MleWorkerPoolStats {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	return pool.m_stats
+}
+
+/**
+ * Get the number of workers.
+ *
+ * @return The pool size is returned.
+ */
+func (pool *MleWorkerPool) GetSize() int {
+	return pool.m_stats.m_size
+}
+
+// Record that a running frame may submit jobs, so that the pool is not
+// closed by retire until the frame releases it. A nil pool is ignored.
+func (pool *MleWorkerPool) acquire() {
+	if pool == nil {
+		return
+	}
+	pool.lock.Lock()
+	pool.m_users++
+	pool.lock.Unlock()
+}
+
+// Release the pool acquired by a frame, closing it if it has been retired
+// and the frame was its last user. A nil pool is ignored.
+func (pool *MleWorkerPool) release() {
+	if pool == nil {
+		return
+	}
+	pool.lock.Lock()
+	pool.m_users--
+	closing := pool.m_retired && (pool.m_users == 0)
+	pool.lock.Unlock()
+	if closing {
+		pool.Close()
+	}
+}
+
+// Close the pool once no running frame has acquired it.
+func (pool *MleWorkerPool) retire() {
+	pool.lock.Lock()
+	pool.m_retired = true
+	closing := pool.m_users == 0
+	pool.lock.Unlock()
+	if closing {
+		pool.Close()
+	}
+}
+
+/**
+ * Close the pool. Queued jobs are still run; <code>Close</code> returns
+ * once the workers have finished them, so it must not be called by a job
+ * run on the pool.
+ */
+func (pool *MleWorkerPool) Close() {
+	pool.lock.Lock()
+	pool.m_closed = true
+	pool.m_cond.Broadcast()
+	pool.lock.Unlock()
+	pool.m_workers.Wait()
+}
@@ -50,6 +50,10 @@ import (
 // Since go does not have a "Class" object to determine available classes that can
 // be retrieved using a method like "forName", we create a global list of registered
 // "classes".
+//
+// Deprecated: Register classes with the typed factories of the core
+// MleClassRegistry (e.g. RegisterActorClass). Classes found here are still
+// created, through reflection, when they are not in the MleClassRegistry.
 var GClassRegistry map[string]interface{}
 
 // MethodExists will determine if a method exists on a specified interface.
@@ -147,3 +151,41 @@ func InstanceOf(objectPtr interface{}, typePtr interface{}) bool {
 	}
 	return false
 }
+
+// GetEmbedded finds the embedded base class of a specified type.
+//
+// Go has no inheritance, so a class which extends a Magic Lantern base class
+// (e.g. MleActor) does so by embedding it, either by value or by pointer.
+//
+// Parameters
+//   any  - The object to search.
+//   base - The type of the base class (e.g. reflect.TypeOf(MleActor{})).
+//
+// Return
+//   A pointer to the base class is returned. If any is itself a pointer to
+//   the base class, then any is returned. nil is returned if any neither is
+//   nor embeds the base class.
+func GetEmbedded(any interface{}, base reflect.Type) interface{} {
+	if any == nil {
+		return nil
+	}
+	value := reflect.ValueOf(any)
+	if value.Type() == reflect.PtrTo(base) {
+		return any
+	}
+	if (value.Kind() != reflect.Ptr) || value.IsNil() || (value.Elem().Kind() != reflect.Struct) {
+		return nil
+	}
+
+	field := value.Elem().FieldByName(base.Name())
+	if !field.IsValid() {
+		return nil
+	}
+	if field.Type() == reflect.PtrTo(base) {
+		return field.Interface()
+	}
+	if (field.Type() == base) && field.CanAddr() {
+		return field.Addr().Interface()
+	}
+	return nil
+}
@@ -41,6 +41,11 @@
 package util
 
 // Import go packages.
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
 
 // Runnable is an interface used to create Magic Lantern Threads.
 type Runnable interface {
@@ -60,3 +65,131 @@ type Runnable interface {
 	//          has completed execution.
 	Run(done chan bool)
 }
+
+// ContextRunnable is a Runnable which supports cancellation.
+//
+// When a Thread is started with a context, the RunContext method is called
+// instead of Run. The method should return promptly once the context is done.
+type ContextRunnable interface {
+	// Extend Runnable interface.
+	Runnable
+
+	// RunContext is called in a separately executing goroutine when the Thread
+	// is started with a context.
+	//
+	// Parameters
+	//   ctx  - The context whose cancellation asks the Run method to stop.
+	//   done - The channel that should be used to notify that the Run method
+	//          has completed execution.
+	RunContext(ctx context.Context, done chan bool)
+}
+
+// ErrorRunnable is a Runnable which reports failure by returning an error.
+//
+// When a Thread or a task runs an ErrorRunnable, the RunError method is
+// called instead of Run or RunContext. The run has completed when the
+// method returns.
+type ErrorRunnable interface {
+	// Extend Runnable interface.
+	Runnable
+
+	// RunError is called in a separately executing goroutine.
+	//
+	// Parameters
+	//   ctx - The context whose cancellation asks the method to stop.
+	//
+	// Return
+	//   nil is returned on success. Otherwise an error describing the
+	//   failure is returned.
+	RunError(ctx context.Context) error
+}
+
+// RunnableFunc adapts a function to the ErrorRunnable interface.
+type RunnableFunc struct {
+	// The name returned by String.
+	m_name string
+	// The function to run.
+	m_fn func(ctx context.Context) error
+}
+
+// NewRunnableFunc creates an ErrorRunnable which calls the specified function.
+//
+// Parameters
+//   name - The name of the runnable.
+//   fn   - The function to call.
+func NewRunnableFunc(name string, fn func(ctx context.Context) error) *RunnableFunc {
+	p := new(RunnableFunc)
+	p.m_name = name
+	p.m_fn = fn
+	return p
+}
+
+// Run implements the Runnable interface.
+func (r *RunnableFunc) Run(done chan bool) {
+	r.RunContext(context.Background(), done)
+}
+
+// RunContext implements the ContextRunnable interface.
+func (r *RunnableFunc) RunContext(ctx context.Context, done chan bool) {
+	r.m_fn(ctx)
+	if done != nil {
+		done <- true
+	}
+}
+
+// RunError implements the ErrorRunnable interface.
+func (r *RunnableFunc) RunError(ctx context.Context) error {
+	return r.m_fn(ctx)
+}
+
+// String implements the IObject interface.
+func (r *RunnableFunc) String() string {
+	return r.m_name
+}
+
+// PanicError is the error reported when a Runnable panics.
+type PanicError struct {
+	// The value passed to panic.
+	Value interface{}
+	// The stack trace of the goroutine which panicked.
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// RunRunnable runs the Runnable in the calling goroutine, recovering from
+// a panic.
+//
+// An ErrorRunnable's RunError method is called, a ContextRunnable's
+// RunContext method is called, otherwise the Run method is called.
+//
+// Parameters
+//   ctx      - The context passed to the Runnable.
+//   runnable - The Runnable to run.
+//   done     - The channel passed to the Run and RunContext methods. It must
+//              be buffered, or received from, if the Runnable signals it.
+//
+// Return
+//   The error returned by an ErrorRunnable, or a PanicError if the Runnable
+//   panicked, is returned. Otherwise nil is returned.
+func RunRunnable(ctx context.Context, runnable Runnable, done chan bool) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{r, debug.Stack()}
+		}
+	}()
+
+	switch r := runnable.(type) {
+	case ErrorRunnable:
+		return r.RunError(ctx)
+	case ContextRunnable:
+		r.RunContext(ctx, done)
+	default:
+		runnable.Run(done)
+	}
+	return nil
+}
+
@@ -41,25 +41,69 @@ package util
 
 // Import go packages.
 import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
 	"sync"
+	"time"
 )
 
+// ThreadState is the lifecycle state of a Thread.
+type ThreadState int
+
+/** The Thread has not been started. */
+const THREAD_NEW ThreadState = 0
+/** The Thread has been started and has not yet completed. */
+const THREAD_RUNNING ThreadState = 1
+/** The Thread completed without an error. */
+const THREAD_COMPLETED ThreadState = 2
+/** The Thread completed with an error or a recovered panic. */
+const THREAD_FAILED ThreadState = 3
+
+// String returns the name of the thread state.
+func (s ThreadState) String() string {
+	switch s {
+	case THREAD_NEW:
+		return "new"
+	case THREAD_RUNNING:
+		return "running"
+	case THREAD_COMPLETED:
+		return "completed"
+	case THREAD_FAILED:
+		return "failed"
+	}
+	return fmt.Sprintf("ThreadState(%d)", int(s))
+}
+
 type Thread struct {
 	// The name of the thread.
 	m_name string
 	// The object that will do the thread execution.
 	m_runnable Runnable
-	// The object is alive and running.
-	m_alive bool
+	// The lifecycle state of the thread.
+	m_state ThreadState
 	// Channel for observing when a thread has completed.
 	m_done chan bool
+	// Channel closed when the current execution has completed.
+	m_finished chan struct{}
+	// Function used to interrupt the current execution.
+	m_cancel context.CancelFunc
+	// The error reported by the last execution.
+	m_err error
+	// The time the last execution started.
+	m_start time.Time
+	// The time the last execution completed.
+	m_end time.Time
+	// Internal lock used for protecting sensitve code.
+	lock sync.Mutex
 }
 
 func NewThread() *Thread {
 	p := new(Thread)
 	p.m_name = "Unknown Thread"
 	p.m_runnable = nil
-	p.m_alive = false
+	p.m_state = THREAD_NEW
 	p.m_done = make(chan bool)
 	return p
 }
@@ -68,7 +112,7 @@ func NewThreadWithRunnable(runnable Runnable) *Thread {
 	p := new(Thread)
 	p.m_name = "Unknown Thread"
 	p.m_runnable = runnable
-	p.m_alive = false
+	p.m_state = THREAD_NEW
 	p.m_done = make(chan bool)
 	return p
 }
@@ -77,53 +121,217 @@ func NewThreadWithRunnableAndName(runnable Runnable, name string) *Thread {
 	p := new(Thread)
 	p.m_name = name
 	p.m_runnable = runnable
-	p.m_alive = false
+	p.m_state = THREAD_NEW
 	p.m_done = make(chan bool)
 	return p
 }
 
-// Run will execute the thread.
+// Run will execute the thread and wait for it to complete.
 //
 // If this thread was constructed using a separate Runnable run object,
 // then that Runnable object's run method is called; otherwise,
-// this method does nothing and returns.
+// this method does nothing and returns. The thread has completed when the
+// Runnable's method returns, as it does for Start. If done is nil, the
+// Runnable is passed a channel owned by the thread.
+//
+// Parameters
+//   done - The channel the Runnable signals on completion, or nil.
 func (t *Thread) Run(done chan bool) {
-	if t.m_runnable != nil {
-		t.m_alive = true
-		go t.m_runnable.Run(done)
-		if done != nil {
-			// Wait for Run goroutine to complete.
-			<-done
-		}
-		t.m_alive = false
+	if done == nil {
+		done = make(chan bool, 1)
+	}
+	if t.start(context.Background(), nil, done) {
+		t.Join(0)
 	}
 }
 
 // Start will begin the thread execution.
 //
 // Parameters
-//   wg - A reference to a synchronization WaitGroup.
+//   wg - A reference to a synchronization WaitGroup, or nil.
 func (t *Thread) Start(wg *sync.WaitGroup) {
-	if t.m_runnable != nil {
-		// Start the runnable.
-		t.m_alive = true
+	t.StartContext(context.Background(), wg)
+}
+
+// StartContext will begin the thread execution with a context.
+//
+// If the Runnable is a ContextRunnable, its RunContext method is called
+// with a context derived from ctx, which is also cancelled by Interrupt;
+// otherwise, its Run method is called and cancellation is ignored. The thread
+// has completed, and wg is released, when the Runnable's method returns; a
+// signal on the done channel is received but does not complete the thread,
+// so the state and error are always those of the finished execution. A panic
+// in the Runnable is recovered and reported by GetError.
+//
+// A Thread that is already running is not started again.
+//
+// Parameters
+//   ctx - The context used to cancel the thread execution.
+//   wg  - A reference to a synchronization WaitGroup, or nil.
+func (t *Thread) StartContext(ctx context.Context, wg *sync.WaitGroup) {
+	t.start(ctx, wg, t.m_done)
+}
+
+// Begin an execution, returning whether the thread was started.
+func (t *Thread) start(ctx context.Context, wg *sync.WaitGroup, done chan bool) bool {
+	if t.m_runnable == nil {
+		return false
+	}
+
+	t.lock.Lock()
+	if t.m_state == THREAD_RUNNING {
+		t.lock.Unlock()
+		return false
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	finished := make(chan struct{})
+	t.m_state = THREAD_RUNNING
+	t.m_cancel = cancel
+	t.m_finished = finished
+	t.m_err = nil
+	t.m_start = time.Now()
+	t.m_end = time.Time{}
+	t.lock.Unlock()
+
+	g_threads.add(t)
+	if wg != nil {
 		wg.Add(1)
-		go t.m_runnable.Run(t.m_done)
-
-		// Establish a goroutine to indicate when the thread has
-		// completed running.
-		go func(waitgroup *sync.WaitGroup) {
-			// Wait for runnable to complete.
-			<-t.m_done
-			defer waitgroup.Done()
-			t.m_alive = false
-		}(wg)
 	}
+
+	// Start the runnable.
+	returned := make(chan error, 1)
+	go func() {
+		returned <- RunRunnable(ctx, t.m_runnable, done)
+	}()
+
+	// Establish a goroutine to indicate when the thread has
+	// completed running. The done signal is drained so that the
+	// Runnable is not blocked sending it before it returns.
+	go func() {
+		var err error
+		for running := true; running; {
+			select {
+			case <-done:
+			case err = <-returned:
+				running = false
+			}
+		}
+		t.finish(finished, err)
+		cancel()
+		g_threads.remove(t)
+		if wg != nil {
+			wg.Done()
+		}
+	}()
+	return true
+}
+
+// Record the completion of the current execution, together with its error.
+func (t *Thread) finish(finished chan struct{}, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.m_finished != finished {
+		// Ignore an execution that is no longer current.
+		close(finished)
+		return
+	}
+	t.m_end = time.Now()
+	t.m_err = err
+	if err != nil {
+		t.m_state = THREAD_FAILED
+	} else {
+		t.m_state = THREAD_COMPLETED
+	}
+	close(finished)
+}
+
+// Join waits for the current execution of the Thread to complete.
+//
+// Parameters
+//   timeout - The maximum time to wait; zero or less waits without a limit.
+//
+// Return
+//   true is returned if the Thread is not running when Join returns;
+//   false is returned if the timeout expired first.
+func (t *Thread) Join(timeout time.Duration) bool {
+	t.lock.Lock()
+	finished := t.m_finished
+	t.lock.Unlock()
+	if finished == nil {
+		return true
+	}
+
+	if timeout <= 0 {
+		<-finished
+		return true
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-finished:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// Interrupt requests that the current execution of the Thread stop by
+// cancelling its context. Only a ContextRunnable or ErrorRunnable observes
+// the request; the Thread is still running until the Runnable completes.
+func (t *Thread) Interrupt() {
+	t.lock.Lock()
+	cancel := t.m_cancel
+	t.lock.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// GetState returns the lifecycle state of the Thread.
+func (t *Thread) GetState() ThreadState {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_state
 }
 
 // IsAlive can be used to determine if the Thread is alive and active.
 func (t *Thread) IsAlive() bool {
-	return t.m_alive
+	return t.GetState() == THREAD_RUNNING
+}
+
+// GetError returns the error reported by the last execution of the Thread,
+// either returned by an ErrorRunnable or recovered from a panic.
+func (t *Thread) GetError() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_err
+}
+
+// GetStartTime returns the time the last execution started, or the zero
+// time if the Thread has not been started.
+func (t *Thread) GetStartTime() time.Time {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.m_start
+}
+
+// GetElapsedTime returns how long the last execution ran, or has been
+// running so far if the Thread is still alive.
+func (t *Thread) GetElapsedTime() time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	switch {
+	case t.m_start.IsZero():
+		return 0
+	case t.m_end.IsZero():
+		return time.Since(t.m_start)
+	}
+	return t.m_end.Sub(t.m_start)
+}
+
+// GetName returns the name of the Thread.
+func (t *Thread) GetName() string {
+	return t.m_name
 }
 
 // String returns a string representation of this thread, including the thread's name,
@@ -131,3 +339,53 @@ func (t *Thread) IsAlive() bool {
 func (t *Thread) String() string {
 	return t.m_name
 }
+
+// The registry of running threads.
+type _ThreadRegistry struct {
+	m_threads map[*Thread]bool
+	lock      sync.Mutex
+}
+
+var g_threads = _ThreadRegistry{m_threads: make(map[*Thread]bool)}
+
+func (r *_ThreadRegistry) add(t *Thread) {
+	r.lock.Lock()
+	r.m_threads[t] = true
+	r.lock.Unlock()
+}
+
+func (r *_ThreadRegistry) remove(t *Thread) {
+	r.lock.Lock()
+	delete(r.m_threads, t)
+	r.lock.Unlock()
+}
+
+// GetLiveThreads returns the threads that are currently running, oldest
+// first.
+func GetLiveThreads() []*Thread {
+	g_threads.lock.Lock()
+	threads := make([]*Thread, 0, len(g_threads.m_threads))
+	for t := range g_threads.m_threads {
+		threads = append(threads, t)
+	}
+	g_threads.lock.Unlock()
+
+	sort.SliceStable(threads, func(i, j int) bool {
+		return threads[i].GetStartTime().Before(threads[j].GetStartTime())
+	})
+	return threads
+}
+
+// DumpThreads writes a line for each running thread, giving its name,
+// state, elapsed time and Runnable. It is intended for diagnosing hangs.
+//
+// Parameters
+//   w - The writer the dump is written to.
+func DumpThreads(w io.Writer) {
+	threads := GetLiveThreads()
+	fmt.Fprintf(w, "%d live thread(s)\n", len(threads))
+	for _, t := range threads {
+		fmt.Fprintf(w, "  %s: %s for %v, runnable %v\n",
+			t.GetName(), t.GetState(), t.GetElapsedTime(), t.m_runnable)
+	}
+}
@@ -0,0 +1,551 @@
+/**
+ * @file Yaml.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package util
+
+// Import go packages.
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// A line of YAML, without its indentation and comment.
+type _YamlLine struct {
+	m_number int
+	m_indent int
+	m_text string
+}
+
+// The state of decoding a YAML document.
+type _YamlDecoder struct {
+	m_lines []*_YamlLine
+	m_next int
+}
+
+// DecodeYaml decodes a YAML document into the values encoding/json decodes
+// into an interface{}: map[string]interface{}, []interface{}, string, bool,
+// float64 and nil; integers are decoded as int64. The returned value may
+// be marshaled to JSON, so a YAML document can be decoded into the structs
+// of a JSON format by their "json" tags.
+//
+// Only the subset of YAML used by configuration files is supported:
+//
+//   - block mappings with plain or quoted keys, and block sequences,
+//     indented with spaces;
+//   - plain, single quoted and double quoted scalars on a single line;
+//   - flow sequences and mappings, which may continue on further lines;
+//   - comments, and a "---" marker starting the document.
+//
+// Anything else is rejected with an error giving its line rather than
+// being read as a plain scalar: anchors, aliases, tags, block scalars
+// (| and >), multi-line plain and quoted scalars, complex keys (?),
+// directives (%), reserved indicators (@ and `) and multiple documents.
+func DecodeYaml(r io.Reader) (interface{}, error) {
+	decoder := new(_YamlDecoder)
+	scanner := bufio.NewScanner(r)
+	number := 0
+	for scanner.Scan() {
+		number++
+		raw := strings.TrimRight(scanner.Text(), " \t\r")
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed in indentation", number)
+		}
+		text, err := yamlStripComment(text)
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %v", number, err)
+		}
+		if (text == "") || (text == "---" && len(decoder.m_lines) == 0) {
+			continue
+		}
+		if (text == "---") || (text == "...") {
+			return nil, fmt.Errorf("yaml: line %d: multiple documents are not supported", number)
+		}
+		if strings.HasPrefix(text, "--- ") {
+			return nil, fmt.Errorf("yaml: line %d: content after the document marker is not supported", number)
+		}
+		decoder.m_lines = append(decoder.m_lines, &_YamlLine{number, len(raw) - len(strings.TrimLeft(raw, " ")), text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(decoder.m_lines) == 0 {
+		return nil, nil
+	}
+
+	value, err := decoder.parseBlock(decoder.m_lines[0].m_indent)
+	if (err == nil) && (decoder.m_next < len(decoder.m_lines)) {
+		err = decoder.errorf("unexpected indentation")
+	}
+	return value, err
+}
+
+// Report an error at the current line.
+func (d *_YamlDecoder) errorf(format string, args ...interface{}) error {
+	number := 0
+	if d.m_next < len(d.m_lines) {
+		number = d.m_lines[d.m_next].m_number
+	} else if len(d.m_lines) > 0 {
+		number = d.m_lines[len(d.m_lines) - 1].m_number
+	}
+	return fmt.Errorf("yaml: line %d: " + format, append([]interface{}{number}, args...)...)
+}
+
+// Get the current line, or nil at the end of the document.
+func (d *_YamlDecoder) current() *_YamlLine {
+	if d.m_next < len(d.m_lines) {
+		return d.m_lines[d.m_next]
+	}
+	return nil
+}
+
+// Determine whether a line is an item of a block sequence.
+func yamlIsItem(text string) bool {
+	return (text == "-") || strings.HasPrefix(text, "- ")
+}
+
+// Parse the block node starting at the current line, which is indented by
+// indent.
+func (d *_YamlDecoder) parseBlock(indent int) (interface{}, error) {
+	line := d.current()
+	if yamlIsItem(line.m_text) {
+		return d.parseSequence(indent)
+	}
+	if _, _, found, err := yamlSplitKey(line.m_text); err != nil {
+		return nil, d.errorf("%v", err)
+	} else if found {
+		return d.parseMapping(indent)
+	}
+	d.m_next++
+	text := d.joinFlow(line.m_text, indent)
+	if next := d.current(); (next != nil) && (next.m_indent > indent) {
+		return nil, d.errorf("multi-line scalars are not supported")
+	}
+	value, err := yamlParseInline(text)
+	if err != nil {
+		d.m_next--
+		return nil, d.errorf("%v", err)
+	}
+	return value, nil
+}
+
+// Join the lines of a flow collection which continues past its first line.
+// The continuation lines must be indented further than indent.
+func (d *_YamlDecoder) joinFlow(text string, indent int) string {
+	if (text[0] != '[') && (text[0] != '{') {
+		return text
+	}
+	for next := d.current(); (next != nil) && (next.m_indent > indent) && (yamlFlowDepth(text) > 0); next = d.current() {
+		text += " " + next.m_text
+		d.m_next++
+	}
+	return text
+}
+
+// Count the flow collections left open at the end of text.
+func yamlFlowDepth(text string) int {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"', '\'':
+			if end, err := yamlQuoteEnd(text, i); err == nil {
+				i = end
+			}
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		}
+	}
+	return depth
+}
+
+// Parse a block sequence whose items are indented by indent.
+func (d *_YamlDecoder) parseSequence(indent int) (interface{}, error) {
+	items := []interface{}{}
+	for line := d.current(); (line != nil) && (line.m_indent == indent) && yamlIsItem(line.m_text); line = d.current() {
+		rest := strings.TrimLeft(line.m_text[1:], " ")
+		if rest == "" {
+			// The item is on the following lines, or is null.
+			d.m_next++
+			var item interface{}
+			if next := d.current(); (next != nil) && (next.m_indent > indent) {
+				var err error
+				if item, err = d.parseBlock(next.m_indent); err != nil {
+					return nil, err
+				}
+			}
+			items = append(items, item)
+			continue
+		}
+
+		// The item starts on this line; its continuation lines are indented
+		// to the column it starts in.
+		line.m_indent += len(line.m_text) - len(rest)
+		line.m_text = rest
+		item, err := d.parseBlock(line.m_indent)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if line := d.current(); (line != nil) && (line.m_indent > indent) {
+		return nil, d.errorf("unexpected indentation")
+	}
+	return items, nil
+}
+
+// Parse a block mapping whose keys are indented by indent.
+func (d *_YamlDecoder) parseMapping(indent int) (interface{}, error) {
+	mapping := make(map[string]interface{})
+	for line := d.current(); (line != nil) && (line.m_indent == indent) && ! yamlIsItem(line.m_text); line = d.current() {
+		key, rest, found, err := yamlSplitKey(line.m_text)
+		if err != nil {
+			return nil, d.errorf("%v", err)
+		}
+		if ! found {
+			return nil, d.errorf("expected a key")
+		}
+		if _, duplicate := mapping[key]; duplicate {
+			return nil, d.errorf("duplicate key %q", key)
+		}
+		d.m_next++
+
+		var value interface{}
+		next := d.current()
+		if rest != "" {
+			if value, err = yamlParseInline(d.joinFlow(rest, indent)); err != nil {
+				d.m_next--
+				return nil, d.errorf("%v", err)
+			}
+			next = d.current()
+			if (next != nil) && (next.m_indent > indent) {
+				return nil, d.errorf("unexpected indentation")
+			}
+		} else if (next != nil) && (next.m_indent > indent) {
+			value, err = d.parseBlock(next.m_indent)
+		} else if (next != nil) && (next.m_indent == indent) && yamlIsItem(next.m_text) {
+			// A sequence may be indented as far as its key.
+			value, err = d.parseSequence(indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		mapping[key] = value
+	}
+	if line := d.current(); (line != nil) && (line.m_indent > indent) {
+		return nil, d.errorf("unexpected indentation")
+	}
+	return mapping, nil
+}
+
+// Find the end of the quoted scalar starting at text[start].
+func yamlQuoteEnd(text string, start int) (int, error) {
+	quote := text[start]
+	for i := start + 1; i < len(text); i++ {
+		switch {
+		case (quote == '"') && (text[i] == '\\'):
+			i++
+		case (quote == '\'') && (text[i] == '\'') && (i + 1 < len(text)) && (text[i + 1] == '\''):
+			i++
+		case text[i] == quote:
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated quoted scalar")
+}
+
+// Remove a comment from a line.
+func yamlStripComment(text string) (string, error) {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"', '\'':
+			if (i == 0) || strings.ContainsRune(" \t[{,:-", rune(text[i - 1])) {
+				end, err := yamlQuoteEnd(text, i)
+				if err != nil {
+					return "", err
+				}
+				i = end
+			}
+		case '#':
+			if (i == 0) || (text[i - 1] == ' ') || (text[i - 1] == '\t') {
+				return strings.TrimRight(text[:i], " \t"), nil
+			}
+		}
+	}
+	return text, nil
+}
+
+// Split "key: value" at its colon. found is false if the text is not a
+// mapping entry.
+func yamlSplitKey(text string) (key string, rest string, found bool, err error) {
+	end := 0
+	if (text[0] == '"') || (text[0] == '\'') {
+		if end, err = yamlQuoteEnd(text, 0); err != nil {
+			return "", "", false, err
+		}
+		end++
+		if (end < len(text)) && (text[end] != ':') {
+			return "", "", false, nil
+		}
+	} else if (text[0] == '[') || (text[0] == '{') {
+		return "", "", false, nil
+	}
+	for i := end; i < len(text); i++ {
+		if (text[i] == ':') && ((i + 1 == len(text)) || (text[i + 1] == ' ')) {
+			if err := yamlCheckNode(text); err != nil {
+				return "", "", false, err
+			}
+			value, err := yamlParseScalar(strings.TrimRight(text[:i], " "), true)
+			if err != nil {
+				return "", "", false, err
+			}
+			return fmt.Sprint(value), strings.TrimLeft(text[i + 1:], " "), true, nil
+		}
+	}
+	return "", "", false, nil
+}
+
+// Parse a value written on a single line: a flow collection or a scalar.
+func yamlParseInline(text string) (interface{}, error) {
+	if (text[0] == '[') || (text[0] == '{') {
+		parser := &_YamlFlow{text, 0}
+		value, err := parser.parse()
+		if err != nil {
+			return nil, err
+		}
+		if parser.skipSpace(); parser.m_pos < len(text) {
+			return nil, fmt.Errorf("unexpected %q after flow collection", text[parser.m_pos:])
+		}
+		return value, nil
+	}
+	if err := yamlCheckNode(text); err != nil {
+		return nil, err
+	}
+	return yamlParseScalar(text, false)
+}
+
+// Reject a node starting with an indicator of a feature that is not
+// supported, which would otherwise be read as a plain scalar.
+func yamlCheckNode(text string) error {
+	if text == "" {
+		return nil
+	}
+	switch text[0] {
+	case '|', '>':
+		return fmt.Errorf("block scalars are not supported")
+	case '&', '*', '!':
+		return fmt.Errorf("anchors, aliases and tags are not supported")
+	case '%':
+		return fmt.Errorf("directives are not supported")
+	case '@', '`':
+		return fmt.Errorf("reserved indicator %q", text[0])
+	case '?':
+		if (len(text) == 1) || (text[1] == ' ') {
+			return fmt.Errorf("complex keys are not supported")
+		}
+	}
+	return nil
+}
+
+// Parse a scalar, resolving plain scalars to null, booleans and numbers.
+// Keys are always strings.
+func yamlParseScalar(text string, key bool) (interface{}, error) {
+	if text == "" {
+		return nil, nil
+	}
+	switch text[0] {
+	case '"':
+		if end, err := yamlQuoteEnd(text, 0); err != nil || end != len(text) - 1 {
+			return nil, fmt.Errorf("invalid quoted scalar %s", text)
+		}
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quoted scalar %s", text)
+		}
+		return value, nil
+	case '\'':
+		if end, err := yamlQuoteEnd(text, 0); err != nil || end != len(text) - 1 {
+			return nil, fmt.Errorf("invalid quoted scalar %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text) - 1], "''", "'"), nil
+	}
+	if key {
+		return text, nil
+	}
+
+	switch text {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1), nil
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1), nil
+	}
+	if i, err := strconv.ParseInt(strings.Replace(text, "0o", "0", 1), 0, 64); err == nil && ! strings.Contains(text, "_") {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil && ! strings.ContainsAny(text, "_xX") {
+		return f, nil
+	}
+	return text, nil
+}
+
+// The state of parsing a flow collection.
+type _YamlFlow struct {
+	m_text string
+	m_pos int
+}
+
+// Skip spaces.
+func (f *_YamlFlow) skipSpace() {
+	for (f.m_pos < len(f.m_text)) && (f.m_text[f.m_pos] == ' ') {
+		f.m_pos++
+	}
+}
+
+// Parse a flow node.
+func (f *_YamlFlow) parse() (interface{}, error) {
+	f.skipSpace()
+	if f.m_pos >= len(f.m_text) {
+		return nil, fmt.Errorf("unterminated flow collection")
+	}
+	switch f.m_text[f.m_pos] {
+	case '[':
+		f.m_pos++
+		items := []interface{}{}
+		for {
+			if f.skipSpace(); (f.m_pos < len(f.m_text)) && (f.m_text[f.m_pos] == ']') {
+				f.m_pos++
+				return items, nil
+			}
+			item, err := f.parse()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			if err := f.separator(']'); err != nil {
+				return nil, err
+			}
+		}
+	case '{':
+		f.m_pos++
+		mapping := make(map[string]interface{})
+		for {
+			if f.skipSpace(); (f.m_pos < len(f.m_text)) && (f.m_text[f.m_pos] == '}') {
+				f.m_pos++
+				return mapping, nil
+			}
+			key, err := f.scalar(true)
+			if err != nil {
+				return nil, err
+			}
+			if (f.m_pos >= len(f.m_text)) || (f.m_text[f.m_pos] != ':') {
+				return nil, fmt.Errorf("expected ':' in flow mapping")
+			}
+			f.m_pos++
+			value, err := f.parse()
+			if err != nil {
+				return nil, err
+			}
+			mapping[fmt.Sprint(key)] = value
+			if err := f.separator('}'); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return f.scalar(false)
+}
+
+// Consume the ',' between entries, leaving the closing bracket.
+func (f *_YamlFlow) separator(closing byte) error {
+	f.skipSpace()
+	if f.m_pos >= len(f.m_text) {
+		return fmt.Errorf("unterminated flow collection")
+	}
+	if f.m_text[f.m_pos] == ',' {
+		f.m_pos++
+	} else if f.m_text[f.m_pos] != closing {
+		return fmt.Errorf("expected ',' or '%c' in flow collection", closing)
+	}
+	return nil
+}
+
+// Parse a scalar in a flow collection.
+func (f *_YamlFlow) scalar(key bool) (interface{}, error) {
+	f.skipSpace()
+	start := f.m_pos
+	if err := yamlCheckNode(f.m_text[start:]); err != nil {
+		return nil, err
+	}
+	if (start < len(f.m_text)) && ((f.m_text[start] == '"') || (f.m_text[start] == '\'')) {
+		end, err := yamlQuoteEnd(f.m_text, start)
+		if err != nil {
+			return nil, err
+		}
+		f.m_pos = end + 1
+	} else {
+		for f.m_pos < len(f.m_text) {
+			c := f.m_text[f.m_pos]
+			if (c == ',') || (c == ']') || (c == '}') || (c == '[') || (c == '{') {
+				break
+			}
+			if (c == ':') && key {
+				break
+			}
+			f.m_pos++
+		}
+	}
+	text := strings.TrimRight(f.m_text[start:f.m_pos], " ")
+	if f.skipSpace(); key {
+		return yamlParseScalar(text, true)
+	}
+	if text == "" {
+		return nil, fmt.Errorf("expected a value in flow collection")
+	}
+	return yamlParseScalar(text, false)
+}
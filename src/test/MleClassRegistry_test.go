@@ -0,0 +1,162 @@
+/**
+ * @file MleClassRegistry_test.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_util "github.com/mle/runtime/util"
+)
+
+type reg_Actor struct {
+	*mle_core.MleActor
+}
+
+func reg_NewActor() *reg_Actor {
+	p := new(reg_Actor)
+	p.MleActor = mle_core.NewMleActor()
+	return p
+}
+
+// A class registered in the legacy util.GClassRegistry.
+type reg_LegacyRoleClass struct{}
+
+func (c *reg_LegacyRoleClass) NewInstance() *mle_core.MleRole {
+	return mle_core.NewMleRole()
+}
+
+// The MleClassRegistry object unit test.
+func TestMleClassRegistry(t *testing.T) {
+	registry := mle_core.GetMleClassRegistryInstance()
+	defer registry.Unregister("reg_Actor")
+
+	if err := mle_core.RegisterActorClass("reg_Actor", reg_NewActor); err != nil {
+		t.Fatalf("TestMleClassRegistry: RegisterActorClass() failed: %s", err.What)
+	}
+	if err := mle_core.RegisterRoleClass("reg_Actor", mle_core.NewMleRole); err == nil {
+		t.Errorf("TestMleClassRegistry: duplicate class was registered")
+	}
+	if err := mle_core.RegisterActorClass[*reg_Actor]("reg_Nil", nil); err == nil {
+		t.Errorf("TestMleClassRegistry: nil factory was registered")
+	}
+
+	entry := registry.Lookup("reg_Actor")
+	if (entry == nil) || (entry.GetKind() != mle_core.MLE_CLASS_ACTOR) ||
+		(entry.GetType() != reflect.TypeOf((*reg_Actor)(nil))) {
+		t.Fatalf("TestMleClassRegistry: Lookup() returned %v", entry)
+	}
+	found := false
+	for _, name := range registry.GetClassNames(mle_core.MLE_CLASS_ACTOR) {
+		if name == "reg_Actor" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("TestMleClassRegistry: GetClassNames() did not list reg_Actor")
+	}
+
+	// Create through the Actor class table entry.
+	obj, err := mle_core.NewMleRTActorClassEntryWithClassAndOffset("reg_Actor", 0).CreateActor()
+	if err != nil {
+		t.Fatalf("TestMleClassRegistry: CreateActor() failed: %s", err.What)
+	}
+	if _, ok := (*obj).(*reg_Actor); !ok {
+		t.Errorf("TestMleClassRegistry: CreateActor() returned %T", *obj)
+	}
+	if _, err = mle_core.NewMleRTSetClassEntryWithClassAndOffset("reg_Actor", 0).CreateSet(); err == nil {
+		t.Errorf("TestMleClassRegistry: Actor class was created as a Set")
+	}
+
+	if !registry.Unregister("reg_Actor") || registry.Unregister("reg_Actor") {
+		t.Errorf("TestMleClassRegistry: Unregister() failed")
+	}
+	if _, err = mle_core.NewMleRTActorClassEntryWithClassAndOffset("reg_Actor", 0).CreateActor(); err == nil {
+		t.Errorf("TestMleClassRegistry: unregistered class was created")
+	}
+}
+
+// Test that classes in the legacy util.GClassRegistry may still be created.
+func TestMleClassRegistryLegacy(t *testing.T) {
+	saved := mle_util.GClassRegistry
+	defer func() { mle_util.GClassRegistry = saved }()
+
+	mle_util.GClassRegistry = nil
+	actor := mle_core.NewMleActor()
+	if _, err := mle_core.NewMleRTActorRoleEntryWithClass("reg_LegacyRole").CreateRole(actor); err == nil {
+		t.Errorf("TestMleClassRegistryLegacy: class was found in a nil registry")
+	}
+
+	mle_util.GClassRegistry = map[string]interface{}{"reg_LegacyRole": new(reg_LegacyRoleClass)}
+	obj, err := mle_core.NewMleRTActorRoleEntryWithClass("reg_LegacyRole").CreateRole(actor)
+	if err != nil {
+		t.Fatalf("TestMleClassRegistryLegacy: CreateRole() failed: %s", err.What)
+	}
+	if actor.GetRole() != (*obj).(*mle_core.MleRole) {
+		t.Errorf("TestMleClassRegistryLegacy: Role not bound to Actor")
+	}
+	if _, err = mle_core.NewMleRTGroupEntryWithClass("reg_LegacyRole").CreateGroup(); err == nil {
+		t.Errorf("TestMleClassRegistryLegacy: Role class was created as a Group")
+	}
+}
+
+// Test concurrent registration and lookup.
+func TestMleClassRegistryConcurrent(t *testing.T) {
+	registry := mle_core.GetMleClassRegistryInstance()
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := "reg_Group" + strconv.Itoa(i)
+			mle_core.RegisterGroupClass(name, mle_core.NewMleGroup)
+			if registry.Lookup(name) == nil {
+				t.Errorf("TestMleClassRegistryConcurrent: %s not registered", name)
+			}
+			registry.GetClassNames(mle_core.MLE_CLASS_GROUP)
+			registry.Unregister(name)
+		}(i)
+	}
+	wg.Wait()
+}
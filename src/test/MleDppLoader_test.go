@@ -0,0 +1,423 @@
+/**
+ * @file MleDppLoader_test.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sync"
+	"testing"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_dpp "github.com/mle/runtime/dpp"
+)
+
+// An Actor which records the properties that are loaded.
+type dpp_Actor struct {
+	*mle_core.MleActor
+	props  map[string][]byte
+	inited bool
+}
+
+func (a *dpp_Actor) SetPropertyArray(name string, length int, nElements int, value io.ByteReader) *mle_core.MleError {
+	data := make([]byte, length*nElements)
+	for i := range data {
+		data[i], _ = value.ReadByte()
+	}
+	a.props[name] = data
+	return nil
+}
+
+func (a *dpp_Actor) Init() {
+	a.inited = true
+}
+
+// The Actor class records the Actors it creates.
+type dpp_ActorClass struct {
+	actors []*dpp_Actor
+}
+
+func (c *dpp_ActorClass) NewInstance() *dpp_Actor {
+	p := new(dpp_Actor)
+	p.MleActor = mle_core.NewMleActor()
+	p.props = make(map[string][]byte)
+	c.actors = append(c.actors, p)
+	return p
+}
+
+// A Set which records the properties that are loaded and the Roles
+// that are attached.
+type dpp_Set struct {
+	*mle_core.MleSet
+	props    map[string][]byte
+	parents  []*mle_core.MleRole
+	children []*mle_core.MleRole
+}
+
+func (s *dpp_Set) SetPropertyArray(name string, length int, nElements int, value io.ByteReader) *mle_core.MleError {
+	data := make([]byte, length*nElements)
+	for i := range data {
+		data[i], _ = value.ReadByte()
+	}
+	s.props[name] = data
+	return nil
+}
+
+func (s *dpp_Set) AttachRoles(parent *mle_core.MleRole, child *mle_core.MleRole) {
+	s.parents = append(s.parents, parent)
+	s.children = append(s.children, child)
+}
+
+// An Actor whose properties are bound to its fields by MleActor.
+type dpp_BoundActor struct {
+	*mle_core.MleActor
+	Count int32
+	Label string
+}
+
+var dpp_boundActors []*dpp_BoundActor
+
+func dpp_NewBoundActor() *dpp_BoundActor {
+	p := new(dpp_BoundActor)
+	p.MleActor = mle_core.NewMleActor()
+	dpp_boundActors = append(dpp_boundActors, p)
+	return p
+}
+
+func dpp_NewSet() *dpp_Set {
+	p := new(dpp_Set)
+	p.MleSet = mle_core.NewMleSet()
+	p.props = make(map[string][]byte)
+	return p
+}
+
+var dpp_setupOnce sync.Once
+var dpp_actorClass = new(dpp_ActorClass)
+
+// Register the classes and tables used by the playprint built by dpp_NewPlayprint.
+func dpp_Setup() {
+	dpp_setupOnce.Do(func() {
+		mle_core.RegisterActorClass("dpp_Actor", dpp_actorClass.NewInstance)
+		mle_core.RegisterRoleClass("dpp_Role", mle_core.NewMleRole)
+		mle_core.RegisterSetClass("dpp_Set", dpp_NewSet)
+		mle_core.RegisterGroupClass("dpp_Group", mle_core.NewMleGroup)
+		mle_core.RegisterSceneClass("dpp_Scene", mle_core.NewMleScene)
+		mle_core.RegisterMediaRefClass("dpp_MediaRef", mle_core.NewMleMediaRef)
+
+		tables := mle_core.GetMleTablesInstance()
+		tables.AddActorProperty(mle_core.NewMleRTPropertyEntryWithClassAndField("dpp_Actor", "position"))
+		tables.AddActorProperty(mle_core.NewMleRTPropertyEntryWithClassAndField("dpp_Actor", "name"))
+		tables.AddSetProperty(mle_core.NewMleRTPropertyEntryWithClassAndField("dpp_Set", "size"))
+		tables.AddActorClass(mle_core.NewMleRTActorClassEntryWithClassAndOffset("dpp_Actor", 0))
+
+		// The bound Actor is class 1 and its properties follow those of dpp_Actor.
+		mle_core.RegisterActorClass("dpp_BoundActor", dpp_NewBoundActor)
+		tables.AddActorProperty(mle_core.NewMleRTPropertyEntryWithClassAndField("dpp_BoundActor", "count"))
+		tables.AddActorProperty(mle_core.NewMleRTPropertyEntryWithClassAndField("dpp_BoundActor", "label"))
+		tables.AddActorClass(mle_core.NewMleRTActorClassEntryWithClassAndOffset("dpp_BoundActor", 2))
+		tables.AddRoleClass(mle_core.NewMleRTActorRoleEntryWithClass("dpp_Role"))
+		tables.AddSetClass(mle_core.NewMleRTSetClassEntryWithClassAndOffset("dpp_Set", 0))
+		tables.AddSet(mle_core.NewMleRTSetEntryWithClassAndSet("dpp_Set", nil))
+		tables.AddGroupClass(mle_core.NewMleRTGroupEntryWithClass("dpp_Group"))
+		tables.AddSceneClass(mle_core.NewMleRTSceneEntryWithClass("dpp_Scene"))
+		tables.AddMediaRefClass(mle_core.NewMleRTMediaRefClassEntryWithClass("dpp_MediaRef"))
+	})
+}
+
+// A chunk being written to a playprint.
+type dpp_Chunk struct {
+	chunkType int32
+	data      bytes.Buffer
+}
+
+func (c *dpp_Chunk) op(op byte) *dpp_Chunk {
+	c.data.WriteByte(op)
+	return c
+}
+
+func (c *dpp_Chunk) ints(values ...int32) *dpp_Chunk {
+	for _, v := range values {
+		binary.Write(&c.data, binary.BigEndian, v)
+	}
+	return c
+}
+
+func (c *dpp_Chunk) bytes(data []byte) *dpp_Chunk {
+	c.data.Write(data)
+	return c
+}
+
+// Assemble a playprint from its chunks.
+func dpp_Build(bootScene int32, chunks ...*dpp_Chunk) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, mle_dpp.MLE_DPP_MAGIC)
+	binary.Write(&buf, binary.BigEndian, mle_dpp.MLE_DPP_VERSION)
+	binary.Write(&buf, binary.BigEndian, bootScene)
+	binary.Write(&buf, binary.BigEndian, int32(len(chunks)))
+
+	offset := mle_dpp.MLE_DPP_HEADER_SIZE + len(chunks)*mle_dpp.MLE_DPP_CHUNK_ENTRY_SIZE
+	for _, c := range chunks {
+		binary.Write(&buf, binary.BigEndian, c.chunkType)
+		binary.Write(&buf, binary.BigEndian, int32(offset))
+		binary.Write(&buf, binary.BigEndian, int32(c.data.Len()))
+		offset += c.data.Len()
+	}
+	for _, c := range chunks {
+		buf.Write(c.data.Bytes())
+	}
+	return buf.Bytes()
+}
+
+// Build a playprint with one Set, one Group of two Actors, one Scene and one MediaRef.
+func dpp_NewPlayprint() []byte {
+	set := &dpp_Chunk{chunkType: mle_dpp.MLE_DPP_CHUNK_SET}
+	set.ints(0)
+	set.op(mle_dpp.MLE_DPP_OP_PROPERTY).ints(0, 4, 2).bytes([]byte{0, 1, 0, 2, 0, 3, 0, 4})
+	set.op(mle_dpp.MLE_DPP_OP_END)
+
+	group := &dpp_Chunk{chunkType: mle_dpp.MLE_DPP_CHUNK_GROUP}
+	group.ints(0)
+	group.op(mle_dpp.MLE_DPP_OP_CREATE_ACTOR).ints(0)
+	group.op(mle_dpp.MLE_DPP_OP_PROPERTY).ints(0, 4, 1).bytes([]byte{1, 2, 3, 4})
+	group.op(mle_dpp.MLE_DPP_OP_SET_SET).ints(0)
+	group.op(mle_dpp.MLE_DPP_OP_CREATE_ROLE).ints(0)
+	group.op(mle_dpp.MLE_DPP_OP_END_ACTOR)
+	group.op(mle_dpp.MLE_DPP_OP_CREATE_ACTOR).ints(0)
+	group.op(mle_dpp.MLE_DPP_OP_PROPERTY).ints(1, 5, 1).bytes([]byte("child"))
+	group.op(mle_dpp.MLE_DPP_OP_CREATE_ROLE).ints(0)
+	group.op(mle_dpp.MLE_DPP_OP_PARENT_ROLE).ints(0)
+	group.op(mle_dpp.MLE_DPP_OP_END_ACTOR)
+	group.op(mle_dpp.MLE_DPP_OP_END)
+
+	scene := &dpp_Chunk{chunkType: mle_dpp.MLE_DPP_CHUNK_SCENE}
+	scene.ints(0, 1, 0)
+
+	mediaref := &dpp_Chunk{chunkType: mle_dpp.MLE_DPP_CHUNK_MEDIAREF}
+	mediaref.ints(0, 1, 7, 3).bytes([]byte("abc"))
+
+	return dpp_Build(0, set, group, scene, mediaref)
+}
+
+// The MleDppInput object unit test.
+func TestMleDppInput(t *testing.T) {
+	input, err := mle_dpp.NewMleDppInputWithBytes(dpp_NewPlayprint())
+	if err != nil {
+		t.Fatalf("TestMleDppInput: NewMleDppInputWithBytes() failed: %s", err.Error())
+	}
+	if input.GetVersion() != mle_dpp.MLE_DPP_VERSION {
+		t.Errorf("TestMleDppInput: GetVersion() returned %d", input.GetVersion())
+	}
+	if input.GetBootScene() != 0 {
+		t.Errorf("TestMleDppInput: GetBootScene() returned %d", input.GetBootScene())
+	}
+	if input.GetNumberOfChunks() != 4 {
+		t.Fatalf("TestMleDppInput: GetNumberOfChunks() returned %d", input.GetNumberOfChunks())
+	}
+	chunk, _ := input.GetChunk(3)
+	if chunk.GetType() != mle_dpp.MLE_DPP_CHUNK_MEDIAREF {
+		t.Errorf("TestMleDppInput: chunk 3 has type %d", chunk.GetType())
+	}
+	if _, err = input.GetChunk(4); err == nil {
+		t.Errorf("TestMleDppInput: GetChunk(4) did not fail")
+	}
+
+	// Invalid playprints.
+	if _, err = mle_dpp.NewMleDppInputWithBytes([]byte("MLDP")); err == nil {
+		t.Errorf("TestMleDppInput: truncated playprint was accepted")
+	}
+	bad := dpp_NewPlayprint()
+	bad[0] = 'X'
+	if _, err = mle_dpp.NewMleDppInputWithBytes(bad); err == nil {
+		t.Errorf("TestMleDppInput: bad magic was accepted")
+	}
+	bad = dpp_NewPlayprint()
+	bad = bad[:len(bad)-1]
+	if _, err = mle_dpp.NewMleDppInputWithBytes(bad); err == nil {
+		t.Errorf("TestMleDppInput: chunk outside of playprint was accepted")
+	}
+}
+
+// The MleDppLoader object unit test.
+func TestMleDppLoader(t *testing.T) {
+	dpp_Setup()
+
+	input, err := mle_dpp.NewMleDppInputWithBytes(dpp_NewPlayprint())
+	if err != nil {
+		t.Fatalf("TestMleDppLoader: NewMleDppInputWithBytes() failed: %s", err.Error())
+	}
+	loader := mle_dpp.NewMleDppLoader(input)
+
+	obj, err := loader.LoadBootScene()
+	if err != nil {
+		t.Fatalf("TestMleDppLoader: LoadBootScene() failed: %s", err.Error())
+	}
+	scene := obj.(*mle_core.MleScene)
+	if mle_core.GetCurrentScene() != scene {
+		t.Errorf("TestMleDppLoader: boot Scene is not current")
+	}
+	if scene.GetNumberOfGroups() != 1 {
+		t.Fatalf("TestMleDppLoader: Scene has %d Groups", scene.GetNumberOfGroups())
+	}
+	group := scene.GetGroup(0)
+	if group.GetNumberOfActors() != 2 {
+		t.Fatalf("TestMleDppLoader: Group has %d Actors", group.GetNumberOfActors())
+	}
+
+	// Verify the Set.
+	entry, _ := mle_core.GetMleTablesInstance().GetSet(0)
+	set, _ := loader.LoadSet(0)
+	if entry.GetSet() != set.(*dpp_Set).MleSet {
+		t.Errorf("TestMleDppLoader: Set was not recorded in the Set table")
+	}
+	if !bytes.Equal(set.(*dpp_Set).props["size"], []byte{0, 1, 0, 2, 0, 3, 0, 4}) {
+		t.Errorf("TestMleDppLoader: Set property not loaded")
+	}
+
+	// Verify the Actors and Roles.
+	root := group.GetActor(0)
+	child := group.GetActor(1)
+	if (root.GetRole() == nil) || (child.GetRole() == nil) {
+		t.Fatalf("TestMleDppLoader: Actor Roles not created")
+	}
+	if root.GetRole().GetActor() != root {
+		t.Errorf("TestMleDppLoader: Role not bound to Actor")
+	}
+	s := set.(*dpp_Set)
+	if (len(s.children) != 2) || (s.parents[0] != nil) || (s.children[0] != root.GetRole()) ||
+		(s.parents[1] != root.GetRole()) || (s.children[1] != child.GetRole()) {
+		t.Errorf("TestMleDppLoader: Roles not attached through the Set")
+	}
+
+	// Verify the MediaRef.
+	if loader.GetNumberOfMediaRefs() != 1 {
+		t.Fatalf("TestMleDppLoader: GetNumberOfMediaRefs() returned %d", loader.GetNumberOfMediaRefs())
+	}
+	obj, err = loader.LoadMediaRef(0)
+	if err != nil {
+		t.Fatalf("TestMleDppLoader: LoadMediaRef() failed: %s", err.Error())
+	}
+	mediaref := obj.(*mle_core.MleMediaRef)
+	ref := mediaref.GetNextMediaRef(nil)
+	flags, _ := mediaref.GetMediaRefFlags(ref)
+	media, _ := mediaref.GetMediaRefBuffer(ref)
+	if (flags != 7) || !bytes.Equal(media, []byte("abc")) {
+		t.Errorf("TestMleDppLoader: media not registered")
+	}
+}
+
+// Verify that Actor properties and initialization are applied to loaded Actors.
+func TestMleDppLoaderActor(t *testing.T) {
+	dpp_Setup()
+
+	input, _ := mle_dpp.NewMleDppInputWithBytes(dpp_NewPlayprint())
+	loader := mle_dpp.NewMleDppLoader(input)
+
+	dpp_actorClass.actors = nil
+	obj, err := loader.LoadGroup(0)
+	if err != nil {
+		t.Fatalf("TestMleDppLoaderActor: LoadGroup() failed: %s", err.Error())
+	}
+	group := obj.(*mle_core.MleGroup)
+	if len(dpp_actorClass.actors) != 2 {
+		t.Fatalf("TestMleDppLoaderActor: %d Actors created", len(dpp_actorClass.actors))
+	}
+	for i, actor := range dpp_actorClass.actors {
+		if !actor.inited {
+			t.Errorf("TestMleDppLoaderActor: Actor %d not initialized", i)
+		}
+		if group.GetActor(i) != actor.MleActor {
+			t.Errorf("TestMleDppLoaderActor: Actor %d not added to the Group", i)
+		}
+	}
+	if !bytes.Equal(dpp_actorClass.actors[0].props["position"], []byte{1, 2, 3, 4}) {
+		t.Errorf("TestMleDppLoaderActor: position property not loaded")
+	}
+	if string(dpp_actorClass.actors[1].props["name"]) != "child" {
+		t.Errorf("TestMleDppLoaderActor: name property not loaded")
+	}
+
+	if _, err = loader.LoadGroup(1); err == nil {
+		t.Errorf("TestMleDppLoaderActor: LoadGroup(1) did not fail")
+	}
+}
+
+// Verify that properties are loaded through the MleActor property binding,
+// and that the errors of the binding fail the load.
+func TestMleDppLoaderBoundActor(t *testing.T) {
+	dpp_Setup()
+
+	newPlayprint := func(count ...byte) []byte {
+		group := &dpp_Chunk{chunkType: mle_dpp.MLE_DPP_CHUNK_GROUP}
+		group.ints(0)
+		group.op(mle_dpp.MLE_DPP_OP_CREATE_ACTOR).ints(1)
+		group.op(mle_dpp.MLE_DPP_OP_PROPERTY).ints(0, int32(len(count)), 1).bytes(count)
+		group.op(mle_dpp.MLE_DPP_OP_PROPERTY).ints(1, 0, 1)
+		group.op(mle_dpp.MLE_DPP_OP_END_ACTOR)
+		group.op(mle_dpp.MLE_DPP_OP_END)
+
+		// A MediaRef whose only media is empty, ending the chunk.
+		mediaref := &dpp_Chunk{chunkType: mle_dpp.MLE_DPP_CHUNK_MEDIAREF}
+		mediaref.ints(0, 1, 7, 0)
+		return dpp_Build(0, group, mediaref)
+	}
+
+	input, _ := mle_dpp.NewMleDppInputWithBytes(newPlayprint(0, 0, 1, 2))
+	loader := mle_dpp.NewMleDppLoader(input)
+	dpp_boundActors = nil
+	_, err := loader.LoadGroup(0)
+	if err != nil {
+		t.Fatalf("TestMleDppLoaderBoundActor: LoadGroup() failed: %s", err.Error())
+	}
+	if (len(dpp_boundActors) != 1) || (dpp_boundActors[0].Count != 0x0102) {
+		t.Errorf("TestMleDppLoaderBoundActor: count property not loaded")
+	}
+	if _, err = loader.LoadMediaRef(0); err != nil {
+		t.Errorf("TestMleDppLoaderBoundActor: LoadMediaRef() failed: %s", err.Error())
+	}
+
+	// A property whose size does not match its field is an error.
+	input, _ = mle_dpp.NewMleDppInputWithBytes(newPlayprint(1, 2))
+	loader = mle_dpp.NewMleDppLoader(input)
+	if _, err = loader.LoadGroup(0); err == nil {
+		t.Errorf("TestMleDppLoaderBoundActor: malformed property was loaded")
+	}
+}
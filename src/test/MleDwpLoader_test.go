@@ -0,0 +1,242 @@
+/**
+ * @file MleDwpLoader_test.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_dwp "github.com/mle/runtime/dwp"
+)
+
+// A workprint using the classes registered by dpp_Setup.
+const dwp_Workprint = `#DWP 1.0 ascii
+(Set set0 dpp_Set
+  (Property size int 1 2)
+)
+(Group group0 dpp_Group
+  (Actor root dpp_Actor
+    (Property position float 1.5)
+    (RoleBinding dpp_Role set0)
+  )
+  (Actor child dpp_Actor
+    (Property name string "child actor") ; a quoted value
+    (RoleBinding dpp_Role set0 root)
+  )
+)
+(Scene scene0 dpp_Scene
+  (GroupRef group0)
+  (Group inline dpp_Group (Actor other dpp_Actor))
+)
+(MediaRef image dpp_MediaRef (Media 7 "image.png"))
+(BootScene scene0)
+`
+
+// The MleDwpParser object unit test.
+func TestMleDwpParser(t *testing.T) {
+	wp, err := mle_dwp.ReadWorkprint(strings.NewReader(dwp_Workprint))
+	if err != nil {
+		t.Fatalf("TestMleDwpParser: ReadWorkprint() failed: %s", err.Error())
+	}
+	if wp.GetBootScene() != "scene0" {
+		t.Errorf("TestMleDwpParser: GetBootScene() returned %s", wp.GetBootScene())
+	}
+	if len(wp.GetChildren()) != 4 {
+		t.Errorf("TestMleDwpParser: workprint has %d items", len(wp.GetChildren()))
+	}
+
+	group := wp.FindItem(mle_dwp.MLE_DWP_GROUP, "group0").(*mle_dwp.MleDwpGroup)
+	actors := group.GetActors()
+	if (len(actors) != 2) || (actors[1].GetName() != "child") || (actors[1].GetParent() != group) {
+		t.Fatalf("TestMleDwpParser: Group actors not parsed")
+	}
+	if actors[1].GetLine() != 10 {
+		t.Errorf("TestMleDwpParser: Actor defined on line %d", actors[1].GetLine())
+	}
+	binding := actors[1].GetRoleBinding()
+	if (binding.GetClassName() != "dpp_Role") || (binding.GetSetName() != "set0") || (binding.GetParentActor() != "root") {
+		t.Errorf("TestMleDwpParser: RoleBinding not parsed")
+	}
+	props := actors[1].GetProperties()
+	if (len(props) != 1) || (props[0].GetValues()[0] != "child actor") {
+		t.Errorf("TestMleDwpParser: quoted property not parsed")
+	}
+	if wp.FindItem(mle_dwp.MLE_DWP_GROUP, "inline") == nil {
+		t.Errorf("TestMleDwpParser: inline Group not found")
+	}
+
+	// Property encoding.
+	set := wp.FindItem(mle_dwp.MLE_DWP_SET, "set0").(*mle_dwp.MleDwpSet)
+	data, length, nElements, err := set.GetProperties()[0].GetData()
+	if (err != nil) || (length != 4) || (nElements != 2) || !bytes.Equal(data, []byte{0, 0, 0, 1, 0, 0, 0, 2}) {
+		t.Errorf("TestMleDwpParser: int property not encoded")
+	}
+	_, _, _, err = mle_dwp.NewMleDwpProperty("p", "int", []string{"x"}).GetData()
+	if err == nil {
+		t.Errorf("TestMleDwpParser: invalid int was accepted")
+	}
+
+	// Malformed workprints.
+	bad := []string{
+		"(Set set0 dpp_Set",
+		"(Unknown x)",
+		"(Actor a dpp_Actor)",
+		"(Set set0)",
+		"(Set set0 dpp_Set) (Set set0 dpp_Set)",
+		"(Group g dpp_Group (Actor a dpp_Actor) extra)",
+		"(Media 7 \"unterminated)",
+		"set0",
+	}
+	for _, text := range bad {
+		if _, err = mle_dwp.NewMleDwpParser(text).Parse(); err == nil {
+			t.Errorf("TestMleDwpParser: %q was accepted", text)
+		}
+	}
+}
+
+// The MleDwpLoader object unit test.
+func TestMleDwpLoader(t *testing.T) {
+	dpp_Setup()
+
+	wp, err := mle_dwp.ReadWorkprint(strings.NewReader(dwp_Workprint))
+	if err != nil {
+		t.Fatalf("TestMleDwpLoader: ReadWorkprint() failed: %s", err.Error())
+	}
+	loader := mle_dwp.NewMleDwpLoader(wp)
+
+	dpp_actorClass.actors = nil
+	obj, err := loader.LoadBootScene()
+	if err != nil {
+		t.Fatalf("TestMleDwpLoader: LoadBootScene() failed: %s", err.Error())
+	}
+	scene := obj.(*mle_core.MleScene)
+	if mle_core.GetCurrentScene() != scene {
+		t.Errorf("TestMleDwpLoader: boot Scene is not current")
+	}
+	if (scene.GetNumberOfGroups() != 2) || (scene.GetGroup(0).GetNumberOfActors() != 2) ||
+		(scene.GetGroup(1).GetNumberOfActors() != 1) {
+		t.Fatalf("TestMleDwpLoader: Scene not loaded")
+	}
+
+	// Verify the Actors.
+	actors := dpp_actorClass.actors
+	if len(actors) != 3 {
+		t.Fatalf("TestMleDwpLoader: %d Actors created", len(actors))
+	}
+	if !bytes.Equal(actors[0].props["position"], []byte{0x3f, 0xc0, 0, 0}) {
+		t.Errorf("TestMleDwpLoader: float property not loaded")
+	}
+	if string(actors[1].props["name"]) != "child actor" {
+		t.Errorf("TestMleDwpLoader: string property not loaded")
+	}
+	for i, actor := range actors {
+		if !actor.inited {
+			t.Errorf("TestMleDwpLoader: Actor %d not initialized", i)
+		}
+	}
+
+	// Verify the Set and Roles.
+	obj, _ = loader.LoadSet("set0")
+	set := obj.(*dpp_Set)
+	if !bytes.Equal(set.props["size"], []byte{0, 0, 0, 1, 0, 0, 0, 2}) {
+		t.Errorf("TestMleDwpLoader: Set property not loaded")
+	}
+	root := actors[0].GetRole()
+	if (len(set.children) != 2) || (set.parents[0] != nil) || (set.children[0] != root) ||
+		(set.parents[1] != root) || (set.children[1] != actors[1].GetRole()) {
+		t.Errorf("TestMleDwpLoader: Roles not attached through the Set")
+	}
+
+	// Verify the MediaRef.
+	obj, err = loader.LoadMediaRef("image")
+	if err != nil {
+		t.Fatalf("TestMleDwpLoader: LoadMediaRef() failed: %s", err.Error())
+	}
+	mediaref := obj.(*mle_core.MleMediaRef)
+	ref := mediaref.GetNextMediaRef(nil)
+	flags, _ := mediaref.GetMediaRefFlags(ref)
+	media, _ := mediaref.GetMediaRefBuffer(ref)
+	if (flags != 7) || (string(media) != "image.png") {
+		t.Errorf("TestMleDwpLoader: media not registered")
+	}
+
+	if _, err = loader.LoadGroup("missing"); err == nil {
+		t.Errorf("TestMleDwpLoader: LoadGroup(\"missing\") did not fail")
+	}
+}
+
+// Verify that classes are resolved through the MleTables class tables.
+func TestMleDwpLoaderClasses(t *testing.T) {
+	dpp_Setup()
+	registry := mle_core.GetMleClassRegistryInstance()
+	defer registry.Unregister("dwp_Unlisted")
+	if err := mle_core.RegisterActorClass("dwp_Unlisted", mle_core.NewMleActor); err != nil {
+		t.Fatalf("TestMleDwpLoaderClasses: RegisterActorClass() failed: %s", err.What)
+	}
+
+	wp, err := mle_dwp.ReadWorkprint(strings.NewReader(`#DWP 1.0 ascii
+(Group listed dpp_Group (Actor a dpp_Actor))
+(Group unlisted dpp_Group
+  (Actor b dwp_Unlisted)
+)
+`))
+	if err != nil {
+		t.Fatalf("TestMleDwpLoaderClasses: ReadWorkprint() failed: %s", err.Error())
+	}
+	loader := mle_dwp.NewMleDwpLoader(wp)
+
+	dpp_actorClass.actors = nil
+	obj, err := loader.LoadGroup("listed")
+	if err != nil {
+		t.Fatalf("TestMleDwpLoaderClasses: LoadGroup() failed: %s", err.Error())
+	}
+	actor := dpp_actorClass.actors[0]
+	if (obj.(*mle_core.MleGroup).GetActor(0) != mle_core.ToMleActor(actor)) || (mle_core.ToMleRole(actor) != nil) {
+		t.Errorf("TestMleDwpLoaderClasses: embedded base classes not found")
+	}
+
+	// A registered class which is not in the tables is not loaded.
+	if _, err = loader.LoadGroup("unlisted"); (err == nil) || !strings.Contains(err.What, "line 4") {
+		t.Errorf("TestMleDwpLoaderClasses: LoadGroup() returned %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+/**
+ * @file MleEventCallback_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	mle_util "github.com/mle/runtime/util"
+	mle_event "github.com/mle/runtime/event"
+)
+
+// A log of the events dispatched to its callbacks, shared by the event
+// tests. The log is also a listener counting the events it is notified of.
+type testMleEventCallback_Log struct {
+	// The entries made for the dispatched events, in dispatch order.
+	mEntries []string
+	// The call data of the dispatched events, in dispatch order.
+	mCallData []mle_util.IObject
+	// The number of events dispatched and notified.
+	mCount atomic.Int64
+	lock sync.Mutex
+}
+
+// A callback adding an entry to its log for each event it is dispatched.
+type testMleEventCallback_Callback struct {
+	mle_event.MleEventCallback
+	mLog *testMleEventCallback_Log
+	// Makes the entry for an event; if nil, the entry is the event number
+	// followed by its call data.
+	mFormat func(event *mle_event.MleEvent) string
+	// Called after the entry is made, if not nil.
+	mOnDispatch func(event *mle_event.MleEvent)
+}
+
+// Create a callback logging to l, with the entries made by format.
+func (l *testMleEventCallback_Log) newCallback(format func(event *mle_event.MleEvent) string) *testMleEventCallback_Callback {
+	return &testMleEventCallback_Callback{mLog: l, mFormat: format}
+}
+
+// Get the entries joined by spaces.
+func (l *testMleEventCallback_Log) String() string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return strings.Join(l.mEntries, " ")
+}
+
+// Clear the entries and call data.
+func (l *testMleEventCallback_Log) reset() {
+	l.lock.Lock()
+	l.mEntries = nil
+	l.mCallData = nil
+	l.lock.Unlock()
+}
+
+func (l *testMleEventCallback_Log) EventProcessed(event *mle_event.MleEvent) {
+	l.mCount.Add(1)
+}
+
+func (l *testMleEventCallback_Log) EventDispatched(event *mle_event.MleEvent) {
+	l.mCount.Add(1)
+}
+
+// Format an event as its event number, followed by "=" and its call data
+// if it has any.
+func testMleEventCallback_Entry(event *mle_event.MleEvent) string {
+	entry := fmt.Sprintf("%04x", mle_event.GetEventId(event.GetId()))
+	if event.GetCallData() != nil {
+		entry += "=" + event.GetCallData().String()
+	}
+	return entry
+}
+
+func (c *testMleEventCallback_Callback) Dispatch(event mle_event.MleEvent, clientData mle_util.IObject) bool {
+	format := c.mFormat
+	if format == nil {
+		format = testMleEventCallback_Entry
+	}
+	entry := format(&event)
+	c.mLog.mCount.Add(1)
+	c.mLog.lock.Lock()
+	c.mLog.mEntries = append(c.mLog.mEntries, entry)
+	c.mLog.mCallData = append(c.mLog.mCallData, event.GetCallData())
+	c.mLog.lock.Unlock()
+	if c.mOnDispatch != nil {
+		c.mOnDispatch(&event)
+	}
+	return true
+}
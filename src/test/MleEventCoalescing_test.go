@@ -0,0 +1,125 @@
+/**
+ * @file MleEventCoalescing_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"testing"
+
+	mle_event "github.com/mle/runtime/event"
+	mle_util "github.com/mle/runtime/util"
+)
+
+// Test each coalescing policy over a burst of delayed events.
+func TestMleEventCoalescing(t *testing.T) {
+	dispatcher := mle_event.NewMleEventDispatcher()
+	last := mle_event.MakeId(0x0022, 0x0001)
+	first := mle_event.MakeId(0x0022, 0x0002)
+	merged := mle_event.MakeId(0x0022, 0x0003)
+	counted := mle_event.MakeId(0x0022, 0x0004)
+	plain := mle_event.MakeId(0x0022, 0x0005)
+
+	if dispatcher.SetCoalescePolicy(merged, mle_event.MLE_COALESCE_MERGE, nil) == nil {
+		t.Errorf("TestMleEventCoalescing: merge policy accepted without a function")
+	}
+	if dispatcher.SetCoalescePolicy(merged, 99, nil) == nil {
+		t.Errorf("TestMleEventCoalescing: invalid policy accepted")
+	}
+	dispatcher.SetCoalescePolicy(last, mle_event.MLE_COALESCE_KEEP_LAST, nil)
+	dispatcher.SetCoalescePolicy(first, mle_event.MLE_COALESCE_KEEP_FIRST, nil)
+	dispatcher.SetCoalescePolicy(merged, mle_event.MLE_COALESCE_MERGE,
+		func(pending mle_util.IObject, calldata mle_util.IObject) mle_util.IObject {
+			return newCallData(pending.String() + "+" + calldata.String())
+		})
+	dispatcher.SetCoalescePolicy(counted, mle_event.MLE_COALESCE_COUNT, nil)
+	if dispatcher.GetCoalescePolicy(counted) != mle_event.MLE_COALESCE_COUNT ||
+		dispatcher.GetCoalescePolicy(plain) != mle_event.MLE_COALESCE_NONE {
+		t.Errorf("TestMleEventCoalescing: wrong policies")
+	}
+
+	logs := make(map[int]*testMleEventCallback_Log)
+	for _, id := range []int{last, first, merged, counted, plain} {
+		logs[id] = new(testMleEventCallback_Log)
+		dispatcher.InstallEventCB(id, logs[id].newCallback(nil), nil)
+	}
+
+	for _, name := range []string{"a", "b", "c"} {
+		for _, id := range []int{last, first, merged, counted, plain} {
+			dispatcher.ProcessEvent(id, newCallData(name), mle_event.MLE_EVENT_DELAYED)
+		}
+	}
+	dispatcher.DispatchEvents()
+
+	expected := map[int]string{last: "c", first: "a", merged: "a+b+c"}
+	for id, data := range expected {
+		if len(logs[id].mCallData) != 1 || logs[id].mCallData[0].String() != data {
+			t.Errorf("TestMleEventCoalescing: %04x dispatched %v", id, logs[id].mCallData)
+		}
+	}
+	if len(logs[counted].mCallData) != 1 {
+		t.Fatalf("TestMleEventCoalescing: counted event dispatched %d times", len(logs[counted].mCallData))
+	}
+	count := logs[counted].mCallData[0].(*mle_event.MleEventCountCallData)
+	if count.GetCount() != 3 || count.GetCallData().String() != "c" {
+		t.Errorf("TestMleEventCoalescing: count %d, call data %v", count.GetCount(), count.GetCallData())
+	}
+	if len(logs[plain].mCallData) != 3 {
+		t.Errorf("TestMleEventCoalescing: plain event dispatched %d times", len(logs[plain].mCallData))
+	}
+
+	// Events pushed after dispatching are no longer coalesced into the
+	// dispatched ones, and immediate events are never coalesced.
+	dispatcher.ProcessEvent(last, newCallData("d"), mle_event.MLE_EVENT_DELAYED)
+	dispatcher.ProcessEvent(last, newCallData("e"), mle_event.MLE_EVENT_IMMEDIATE)
+	dispatcher.ProcessEvent(last, newCallData("f"), mle_event.MLE_EVENT_DELAYED)
+	dispatcher.DispatchEvents()
+	if len(logs[last].mCallData) != 3 || logs[last].mCallData[1].String() != "e" || logs[last].mCallData[2].String() != "f" {
+		t.Errorf("TestMleEventCoalescing: dispatched %v", logs[last].mCallData)
+	}
+
+	// Flushing releases the pending events.
+	dispatcher.ProcessEvent(first, newCallData("g"), mle_event.MLE_EVENT_DELAYED)
+	dispatcher.Flush()
+	dispatcher.ProcessEvent(first, newCallData("h"), mle_event.MLE_EVENT_DELAYED)
+	dispatcher.DispatchEvents()
+	if len(logs[first].mCallData) != 2 || logs[first].mCallData[1].String() != "h" {
+		t.Errorf("TestMleEventCoalescing: dispatched %v", logs[first].mCallData)
+	}
+}
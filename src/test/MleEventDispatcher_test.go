@@ -42,6 +42,8 @@ package mle_test
 // import go packages.
 import (
 	"bytes"
+	"strings"
+	"sync"
 	"testing"
 	"strconv"
 
@@ -360,3 +362,125 @@ func TestPrioritizedCB(t *testing.T) {
 	t.Logf("TestPrioritizedCB: dispatching prioritzed events.")
     _machine.DispatchEvents()
 }
+
+// Concurrent producers against a single consumer; run with -race.
+func TestMleEventDispatcherConcurrent(t *testing.T) {
+	const producers = 8
+	const delayed = 500
+	const immediate = 50
+
+	dispatcher := mle_event.NewMleEventDispatcher()
+	event := mle_event.MakeId(0x0001, 0x0001)
+	other := mle_event.MakeId(0x0001, 0x0002)
+	counter := new(testMleEventCallback_Log)
+	dispatcher.InstallEventCB(event, counter.newCallback(nil), nil)
+
+	var producing sync.WaitGroup
+	for i := 0; i < producers; i++ {
+		producing.Add(1)
+		go func() {
+			defer producing.Done()
+			for j := 0; j < delayed; j++ {
+				dispatcher.ProcessEvent(event, nil, mle_event.MLE_EVENT_DELAYED)
+			}
+			for j := 0; j < immediate; j++ {
+				dispatcher.ProcessEvent(event, nil, mle_event.MLE_EVENT_IMMEDIATE)
+			}
+		}()
+	}
+
+	// Registrations change while events are produced and dispatched.
+	stop := make(chan bool)
+	var churning sync.WaitGroup
+	churning.Add(1)
+	go func() {
+		defer churning.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			listener := new(testMleEventCallback_Log)
+			dispatcher.AddListener(listener)
+			id, _ := dispatcher.InstallEventCB(other, listener.newCallback(nil), nil)
+			dispatcher.DisableEventCB(other, id)
+			dispatcher.UninstallEventCB(other, id)
+			dispatcher.RemoveListener(listener)
+		}
+	}()
+
+	// The single consumer.
+	done := make(chan bool)
+	go func() {
+		producing.Wait()
+		close(done)
+	}()
+	for consuming := true; consuming; {
+		select {
+		case <-done:
+			consuming = false
+		default:
+		}
+		dispatcher.DispatchEvents()
+	}
+	close(stop)
+	churning.Wait()
+
+	want := int64(producers * (delayed + immediate))
+	if got := counter.mCount.Load(); got != want {
+		t.Errorf("TestMleEventDispatcherConcurrent: dispatched %d events, expected %d", got, want)
+	}
+	if element := dispatcher.PopEvent(); element != nil {
+		t.Errorf("TestMleEventDispatcherConcurrent: events are left on the queue")
+	}
+}
+
+func TestMleEventDispatcherChangeEventPriority(t *testing.T) {
+	dispatcher := mle_event.NewMleEventDispatcher()
+	first := mle_event.MakeId(0x0001, 0x0001)
+	second := mle_event.MakeId(0x0001, 0x0002)
+	counter := new(testMleEventCallback_Log)
+	dispatcher.InstallEventCB(first, counter.newCallback(nil), nil)
+	dispatcher.InstallEventCB(second, counter.newCallback(nil), nil)
+
+	dispatcher.ProcessEventWithPriority(first, nil, mle_event.MLE_EVENT_DELAYED, 2)
+	dispatcher.ProcessEventWithPriority(second, nil, mle_event.MLE_EVENT_DELAYED, 1)
+	if ! dispatcher.ChangeEventPriority(second, 3) {
+		t.Fatalf("TestMleEventDispatcherChangeEventPriority: ChangeEventPriority() returned false")
+	}
+	dispatcher.DispatchEvents()
+
+	if counter.String() != "0002 0001" {
+		t.Errorf("TestMleEventDispatcherChangeEventPriority: dispatch order is %v", counter.mEntries)
+	}
+}
+
+// Test that delayed events of equal priority are dispatched in the order
+// they were pushed, however many events the dispatcher has handled.
+func TestMleEventDispatcherEqualPriorityOrder(t *testing.T) {
+	event := mle_event.MakeId(0x0001, 0x0003)
+	var expected []string
+	for i := 0; i < 12; i++ {
+		expected = append(expected, "0003=" + strconv.Itoa(i))
+	}
+
+	for history := 0; history < mle_event.MLE_EVENT_QUEUE_SHARDS; history++ {
+		dispatcher := mle_event.NewMleEventDispatcher()
+		log := new(testMleEventCallback_Log)
+		dispatcher.InstallEventCB(event, log.newCallback(nil), nil)
+		for i := 0; i < history; i++ {
+			dispatcher.ProcessEvent(event, nil, mle_event.MLE_EVENT_DELAYED)
+		}
+		dispatcher.DispatchEvents()
+		log.reset()
+
+		for i := 0; i < 12; i++ {
+			dispatcher.ProcessEvent(event, newCallData(strconv.Itoa(i)), mle_event.MLE_EVENT_DELAYED)
+		}
+		dispatcher.DispatchEvents()
+		if log.String() != strings.Join(expected, " ") {
+			t.Errorf("TestMleEventDispatcherEqualPriorityOrder: after %d events, dispatched %v", history, log.mEntries)
+		}
+	}
+}
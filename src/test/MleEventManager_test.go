@@ -286,3 +286,52 @@ func TestHasEventWithName(t *testing.T) {
 
 	testMleEventManager_tearDown()
 }
+
+/**
+ * Test packing and unpacking composite identifiers over the full 16 bits.
+ */
+func TestEventID(t *testing.T) {
+	testMleEventManager_setUp()
+
+	id := mle_event.MakeEventID(0x0025, 0xABCD)
+	if id.GetGroup() != 0x0025 || id.GetEvent() != 0xABCD || id.String() != "0x0025:0xabcd" {
+		t.Errorf("TestEventID: unpacked %s", id)
+	}
+	// The legacy int identifiers hold the same value and round trip.
+	cid := id.Int()
+	if mle_event.MakeId(0x0025, mle_event.GetEventId(cid)) != cid || mle_event.GetGroupId(cid) != 0x0025 {
+		t.Errorf("TestEventID: 0x%x does not round trip", cid)
+	}
+	if mle_event.ToEventID(cid) != id || mle_event.GetEventId(mle_event.MakeId(1, 0x0100)) != 0x0100 {
+		t.Errorf("TestEventID: wrong conversion")
+	}
+
+	for _, invalid := range []int{mle_event.MLE_EVENT_INVALID_ID, mle_event.MakeId(-2, 0), mle_event.MakeId(-1, 5)} {
+		if mle_event.IsValidEventId(invalid) || mle_event.ToEventID(invalid) != mle_event.MLE_INVALID_EVENT_ID {
+			t.Errorf("TestEventID: 0x%x is valid", invalid)
+		}
+	}
+	if mle_event.MakeEventID(-1, 0).Int() != mle_event.MLE_EVENT_INVALID_ID {
+		t.Errorf("TestEventID: negative group is valid")
+	}
+
+	// The manager registers ids above 0xFF, and rejects invalid ones.
+	manager := mle_event.NewMleEventManager()
+	if err := manager.AddEvent(cid, "wide"); err != nil {
+		t.Errorf("TestEventID: AddEvent() failed: %s", err.What)
+	}
+	if manager.GetEventId("wide") != cid || manager.GetEventName(cid) != "wide" {
+		t.Errorf("TestEventID: wide event not registered")
+	}
+	if mle_event.CreateEvent(0x0025) != mle_event.MakeEventID(0x0025, 0xABCE).Int() {
+		t.Errorf("TestEventID: CreateEvent() = 0x%x", mle_event.CreateEvent(0x0025))
+	}
+	if manager.AddEvent(mle_event.MLE_EVENT_INVALID_ID, "") == nil || manager.AddEvent(-1, "") == nil {
+		t.Errorf("TestEventID: invalid event added")
+	}
+	if mle_event.CreateEvent(-1) != mle_event.MLE_EVENT_INVALID_ID {
+		t.Errorf("TestEventID: CreateEvent() in an invalid group")
+	}
+
+	testMleEventManager_tearDown()
+}
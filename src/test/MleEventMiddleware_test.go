@@ -0,0 +1,131 @@
+/**
+ * @file MleEventMiddleware_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"sort"
+	"testing"
+
+	mle_event "github.com/mle/runtime/event"
+)
+
+// Test inspecting, transforming, dropping and delaying events.
+func TestMleEventMiddleware(t *testing.T) {
+	dispatcher := mle_event.NewMleEventDispatcher()
+	kept := mle_event.MakeId(0x0023, 0x0001)
+	dropped := mle_event.MakeId(0x0023, 0x0002)
+	throttled := mle_event.MakeId(0x0023, 0x0003)
+
+	logs := make(map[int]*testMleEventCallback_Log)
+	for _, id := range []int{kept, dropped, throttled} {
+		logs[id] = new(testMleEventCallback_Log)
+		dispatcher.InstallEventCB(id, logs[id].newCallback(nil), nil)
+	}
+
+	var seen []int
+	logger := dispatcher.AddMiddleware(mle_event.MleEventMiddlewareFunc(func(event *mle_event.MleEvent) int {
+		seen = append(seen, event.GetId())
+		return mle_event.MLE_MIDDLEWARE_CONTINUE
+	}))
+	dispatcher.AddMiddleware(mle_event.MleEventMiddlewareFunc(func(event *mle_event.MleEvent) int {
+		if event.GetId() == dropped {
+			return mle_event.MLE_MIDDLEWARE_DROP
+		}
+		if event.GetCallData() != nil {
+			event.SetCallData(newCallData(event.GetCallData().String() + "!"))
+		}
+		return mle_event.MLE_MIDDLEWARE_CONTINUE
+	}))
+	// Throttle to one event per pass: the first is delayed to the next pass.
+	dispatcher.AddMiddleware(mle_event.MleEventMiddlewareFunc(func(event *mle_event.MleEvent) int {
+		if event.GetId() == throttled && event.GetType() == mle_event.MLE_EVENT_IMMEDIATE {
+			return mle_event.MLE_MIDDLEWARE_DELAY
+		}
+		return mle_event.MLE_MIDDLEWARE_CONTINUE
+	}))
+	if dispatcher.AddMiddleware(nil) != nil || dispatcher.GetNumMiddleware() != 3 {
+		t.Errorf("TestMleEventMiddleware: %d middleware", dispatcher.GetNumMiddleware())
+	}
+
+	if ! dispatcher.ProcessEvent(kept, newCallData("a"), mle_event.MLE_EVENT_IMMEDIATE) {
+		t.Errorf("TestMleEventMiddleware: immediate event failed")
+	}
+	if dispatcher.ProcessEvent(dropped, newCallData("b"), mle_event.MLE_EVENT_IMMEDIATE) {
+		t.Errorf("TestMleEventMiddleware: dropped event succeeded")
+	}
+	dispatcher.ProcessEvent(dropped, newCallData("c"), mle_event.MLE_EVENT_DELAYED)
+	dispatcher.ProcessEvent(throttled, newCallData("d"), mle_event.MLE_EVENT_IMMEDIATE)
+	if len(logs[throttled].mCallData) != 0 {
+		t.Errorf("TestMleEventMiddleware: delayed event dispatched immediately")
+	}
+	dispatcher.ProcessEvent(kept, newCallData("e"), mle_event.MLE_EVENT_DELAYED)
+	dispatcher.DispatchEvents()
+
+	if len(logs[kept].mCallData) != 2 || logs[kept].mCallData[0].String() != "a!" || logs[kept].mCallData[1].String() != "e!" {
+		t.Errorf("TestMleEventMiddleware: kept %v", logs[kept].mCallData)
+	}
+	if len(logs[dropped].mCallData) != 0 {
+		t.Errorf("TestMleEventMiddleware: dropped %v", logs[dropped].mCallData)
+	}
+	// The delayed event passes through the chain again when it is dispatched.
+	if len(logs[throttled].mCallData) != 1 || logs[throttled].mCallData[0].String() != "d!!" {
+		t.Errorf("TestMleEventMiddleware: throttled %v", logs[throttled].mCallData)
+	}
+	// Delayed events of the same priority are dispatched in no set order.
+	expected := []int{kept, dropped, throttled, kept, dropped, throttled}
+	if len(seen) != len(expected) {
+		t.Fatalf("TestMleEventMiddleware: middleware saw %04x", seen)
+	}
+	sort.Ints(seen[3:])
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("TestMleEventMiddleware: middleware saw %04x", seen)
+		}
+	}
+
+	if ! dispatcher.RemoveMiddleware(logger) || dispatcher.RemoveMiddleware(logger) {
+		t.Errorf("TestMleEventMiddleware: RemoveMiddleware() failed")
+	}
+	dispatcher.ProcessEvent(kept, nil, mle_event.MLE_EVENT_IMMEDIATE)
+	if len(seen) != len(expected) || dispatcher.GetNumMiddleware() != 2 {
+		t.Errorf("TestMleEventMiddleware: removed middleware was invoked")
+	}
+}
@@ -0,0 +1,137 @@
+/**
+ * @file MleEventPropagator_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"testing"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_event "github.com/mle/runtime/event"
+)
+
+// Test capturing down to and bubbling up from a role.
+func TestMleEventPropagator(t *testing.T) {
+	scene := mle_core.NewMleScene()
+	group := mle_core.NewMleGroup()
+	actor := mle_core.NewMleActor()
+	role := mle_core.NewMleRoleWithActor(actor)
+	group.Add(actor)
+	scene.Add(group)
+
+	propagator := mle_event.NewMleEventPropagator(scene)
+	id := mle_event.MakeId(0x0021, 0x0001)
+	log := new(testMleEventCallback_Log)
+	phases := []string{"none", "capture", "target", "bubble"}
+	nodes := []interface{}{scene, group, actor, role}
+	names := []string{"scene", "group", "actor", "role"}
+	callbacks := make(map[string]*testMleEventCallback_Callback)
+	ids := make(map[string]mle_core.IMleCallbackId)
+	for i, node := range nodes {
+		for _, capture := range []bool{true, false} {
+			name := names[i]
+			if capture {
+				name += "-capture"
+			}
+			callbacks[name] = log.newCallback(func(event *mle_event.MleEvent) string {
+				return name + ":" + phases[event.GetPhase()]
+			})
+			cbId, err := propagator.InstallEventCB(node, id, callbacks[name], nil, capture, 0)
+			if err != nil {
+				t.Fatalf("TestMleEventPropagator: InstallEventCB() failed: %s", err.What)
+			}
+			ids[name] = cbId
+		}
+	}
+	if _, err := propagator.InstallEventCB("scene", id, callbacks["scene"], nil, true, 0); err == nil {
+		t.Errorf("TestMleEventPropagator: installed a callback on a string")
+	}
+
+	ok, err := propagator.ProcessEvent(id, nil, role)
+	if err != nil || ! ok {
+		t.Fatalf("TestMleEventPropagator: ProcessEvent() = %v, %v", ok, err)
+	}
+	expected := "scene-capture:capture group-capture:capture actor-capture:capture " +
+		"role-capture:target role:target actor:bubble group:bubble scene:bubble"
+	if log.String() != expected {
+		t.Errorf("TestMleEventPropagator: dispatched %v", log.mEntries)
+	}
+
+	// Stop at the group while capturing; the group vetoes the default action.
+	log.reset()
+	callbacks["group-capture"].mOnDispatch = func(event *mle_event.MleEvent) {
+		if event.GetCurrentTarget() != group || event.GetTarget() != actor {
+			t.Errorf("TestMleEventPropagator: wrong targets at the group")
+		}
+		event.StopPropagation()
+		event.PreventDefault()
+	}
+	event := mle_event.NewMleEventWithIdEvTypeCalldata(nil, id, mle_event.MLE_EVENT_IMMEDIATE, nil)
+	if ok, _ := propagator.PropagateEvent(event, actor); ok {
+		t.Errorf("TestMleEventPropagator: default action was not prevented")
+	}
+	if ! event.IsPropagationStopped() || (event.GetPhase() != mle_event.MLE_EVENT_PHASE_NONE) {
+		t.Errorf("TestMleEventPropagator: propagation state %v, %d", event.IsPropagationStopped(), event.GetPhase())
+	}
+	if log.String() != "scene-capture:capture group-capture:capture" {
+		t.Errorf("TestMleEventPropagator: dispatched %v", log.mEntries)
+	}
+
+	// Stopping at the target still dispatches its bubble callbacks.
+	log.reset()
+	callbacks["group-capture"].mOnDispatch = nil
+	callbacks["actor-capture"].mOnDispatch = func(event *mle_event.MleEvent) {
+		event.StopPropagation()
+	}
+	if ! propagator.UninstallEventCB(scene, id, ids["scene-capture"]) {
+		t.Errorf("TestMleEventPropagator: UninstallEventCB() failed")
+	}
+	propagator.RemoveNode(scene)
+	propagator.ProcessEvent(id, nil, actor)
+	if log.String() != "group-capture:capture actor-capture:target actor:target" {
+		t.Errorf("TestMleEventPropagator: dispatched %v", log.mEntries)
+	}
+
+	// An actor outside the scene is its own path.
+	path, _ := propagator.GetPath(mle_core.NewMleActor())
+	if len(path) != 1 {
+		t.Errorf("TestMleEventPropagator: path %v", path)
+	}
+}
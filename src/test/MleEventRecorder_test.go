@@ -0,0 +1,282 @@
+/**
+ * @file MleEventRecorder_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	mle_event "github.com/mle/runtime/event"
+	mle_sched "github.com/mle/runtime/scheduler"
+)
+
+// Run four frames of a loop driving dispatcher, calling emit before each
+// frame's task.
+func testMleEventRecorder_Run(t *testing.T, dispatcher *mle_event.MleEventDispatcher,
+	setup func(loop *mle_sched.MleFrameLoop), emit func(frame int)) {
+	clock := &testMleFrameLoop_Clock{now: time.Unix(0, 0)}
+	task := &testMleFrameLoop_Task{clock: clock}
+	loop := testMleFrameLoop_NewLoop(task)
+	loop.SetEventDispatcher(dispatcher)
+	setup(loop)
+	task.onRun = func(frame int) {
+		emit(frame)
+		if frame == 4 {
+			loop.Stop()
+		}
+	}
+	if err := loop.Run(context.Background()); err != nil {
+		t.Fatalf("testMleEventRecorder_Run: Run() failed: %s", err.What)
+	}
+}
+
+func TestMleEventRecorder(t *testing.T) {
+	immediate := mle_event.MakeId(0x0024, 1)
+	delayed := mle_event.MakeId(0x0024, 2)
+	count, err := mle_event.RegisterEventType[int](mle_event.MakeId(0x0024, 3), "")
+	if err != nil {
+		t.Fatalf("TestMleEventRecorder: RegisterEventType() failed: %v", err)
+	}
+	codec := mle_event.NewMleEventCodecMux(mle_event.NewMleStringEventCodec())
+	codec.SetCodec(count.GetId(), mle_event.NewMleTypedEventCodec[int]())
+
+	newDispatcher := func() (*mle_event.MleEventDispatcher, *testMleEventCallback_Log) {
+		dispatcher := mle_event.NewMleEventDispatcher()
+		log := new(testMleEventCallback_Log)
+		dispatcher.InstallGroupCB(0x0024, log.newCallback(nil), nil, 0)
+		return dispatcher, log
+	}
+
+	// Record a run.
+	var buf bytes.Buffer
+	dispatcher, recorded := newDispatcher()
+	recorder, merr := mle_event.NewMleEventRecorder(&buf, codec)
+	if merr != nil {
+		t.Fatalf("TestMleEventRecorder: NewMleEventRecorder() failed: %s", merr.What)
+	}
+	dispatcher.SetRecorder(recorder)
+	testMleEventRecorder_Run(t, dispatcher, func(loop *mle_sched.MleFrameLoop) {
+		loop.SetEventRecorder(recorder)
+	}, func(frame int) {
+		switch frame {
+		case 1:
+			dispatcher.ProcessEvent(immediate, newCallData("one"), mle_event.MLE_EVENT_IMMEDIATE)
+		case 2:
+			dispatcher.ProcessEventWithPriority(delayed, newCallData("low"), mle_event.MLE_EVENT_DELAYED, 1)
+			mle_event.EmitDelayed(dispatcher, count, 42, 5)
+		case 3:
+			dispatcher.ProcessEvent(immediate, nil, mle_event.MLE_EVENT_IMMEDIATE)
+		}
+	})
+	dispatcher.SetRecorder(nil)
+	if err := recorder.Close(); err != nil || recorder.GetError() != nil || recorder.GetCount() != 4 {
+		t.Fatalf("TestMleEventRecorder: recorded %d events, error %v", recorder.GetCount(), recorder.GetError())
+	}
+	if recorded.String() != "0001=one 0003=42 0002=low 0001" {
+		t.Errorf("TestMleEventRecorder: recorded run dispatched %v", recorded.mEntries)
+	}
+
+	// Replay it into a fresh dispatcher.
+	recording, merr := mle_event.LoadMleEventRecording(bytes.NewReader(buf.Bytes()), codec)
+	if merr != nil {
+		t.Fatalf("TestMleEventRecorder: LoadMleEventRecording() failed: %s", merr.What)
+	}
+	if recording.GetVersion() != mle_event.MLE_EVENT_RECORDING_VERSION || len(recording.GetRecords()) != 4 {
+		t.Fatalf("TestMleEventRecorder: loaded version %d with %d events", recording.GetVersion(), len(recording.GetRecords()))
+	}
+	if record := recording.GetRecords()[2]; record.GetFrame() != 1 || record.GetPriority() != 5 {
+		t.Errorf("TestMleEventRecorder: typed event recorded in frame %d with priority %d", record.GetFrame(), record.GetPriority())
+	}
+	replay, replayed := newDispatcher()
+	player := mle_event.NewMleEventPlayer(recording, replay)
+	testMleEventRecorder_Run(t, replay, func(loop *mle_sched.MleFrameLoop) {
+		loop.SetEventPlayer(player)
+	}, func(frame int) {})
+	if replayed.String() != recorded.String() {
+		t.Errorf("TestMleEventRecorder: replay dispatched %v, recorded %v", replayed.mEntries, recorded.mEntries)
+	}
+	if ! player.IsDone() {
+		t.Errorf("TestMleEventRecorder: player is not done")
+	}
+
+	// Seek to a frame.
+	seek, sought := newDispatcher()
+	player = mle_event.NewMleEventPlayer(recording, seek)
+	player.SeekFrame(2)
+	if n := player.ReplayNextFrame(); n != 1 || player.GetFrame() != 3 {
+		t.Errorf("TestMleEventRecorder: replayed %d events, next frame %d", n, player.GetFrame())
+	}
+	if sought.String() != "0001" {
+		t.Errorf("TestMleEventRecorder: seek dispatched %v", sought.mEntries)
+	}
+}
+
+// Test replaying delayed events of equal priority, including those fired by
+// a timer, into a dispatcher that has already dispatched other events.
+func TestMleEventRecorderOrder(t *testing.T) {
+	event := mle_event.MakeId(0x0024, 5)
+	codec := mle_event.NewMleStringEventCodec()
+	newDispatcher := func() (*mle_event.MleEventDispatcher, *testMleEventCallback_Log) {
+		dispatcher := mle_event.NewMleEventDispatcher()
+		log := new(testMleEventCallback_Log)
+		dispatcher.InstallEventCB(event, log.newCallback(nil), nil)
+		return dispatcher, log
+	}
+
+	var buf bytes.Buffer
+	dispatcher, recorded := newDispatcher()
+	recorder, _ := mle_event.NewMleEventRecorder(&buf, codec)
+	dispatcher.SetRecorder(recorder)
+	testMleEventRecorder_Run(t, dispatcher, func(loop *mle_sched.MleFrameLoop) {
+		loop.SetEventRecorder(recorder)
+	}, func(frame int) {
+		switch frame {
+		case 1:
+			dispatcher.PostEventAtFrame(event, newCallData("timer"), 0, 2)
+			for _, name := range []string{"a", "b", "c"} {
+				dispatcher.ProcessEvent(event, newCallData(name), mle_event.MLE_EVENT_DELAYED)
+			}
+		case 2:
+			for _, name := range []string{"d", "e", "f", "g"} {
+				dispatcher.ProcessEvent(event, newCallData(name), mle_event.MLE_EVENT_DELAYED)
+			}
+		case 3:
+			dispatcher.ProcessEvent(event, newCallData("h"), mle_event.MLE_EVENT_DELAYED)
+		}
+	})
+	recorder.Close()
+	expected := "0005=a 0005=b 0005=c 0005=d 0005=e 0005=f 0005=g 0005=timer 0005=h"
+	if recorded.String() != expected || recorder.GetCount() != 9 {
+		t.Fatalf("TestMleEventRecorderOrder: recorded %d events, dispatched %v", recorder.GetCount(), recorded.mEntries)
+	}
+
+	recording, merr := mle_event.LoadMleEventRecording(bytes.NewReader(buf.Bytes()), codec)
+	if merr != nil {
+		t.Fatalf("TestMleEventRecorderOrder: LoadMleEventRecording() failed: %s", merr.What)
+	}
+	for i, record := range recording.GetRecords() {
+		if record.GetSequence() != int64(i) {
+			t.Errorf("TestMleEventRecorderOrder: event %d has sequence %d", i, record.GetSequence())
+		}
+	}
+
+	// Give the replay dispatcher a different history before replaying.
+	replay, replayed := newDispatcher()
+	for i := 0; i < 5; i++ {
+		replay.ProcessEvent(event, nil, mle_event.MLE_EVENT_DELAYED)
+		if i % 2 == 0 {
+			replay.DispatchEvents()
+		}
+	}
+	replay.DispatchEvents()
+	replayed.reset()
+	player := mle_event.NewMleEventPlayer(recording, replay)
+	testMleEventRecorder_Run(t, replay, func(loop *mle_sched.MleFrameLoop) {
+		loop.SetEventPlayer(player)
+	}, func(frame int) {})
+	if replayed.String() != expected {
+		t.Errorf("TestMleEventRecorderOrder: replay dispatched %v", replayed.mEntries)
+	}
+}
+
+// Test replaying a recording whose first event is not in frame 0.
+func TestMleEventPlayerFrames(t *testing.T) {
+	event := mle_event.MakeId(0x0024, 4)
+	codec := mle_event.NewMleStringEventCodec()
+	var buf bytes.Buffer
+	recorder, _ := mle_event.NewMleEventRecorder(&buf, codec)
+	dispatcher := mle_event.NewMleEventDispatcher()
+	dispatcher.SetRecorder(recorder)
+	recorder.SetFrame(3)
+	dispatcher.ProcessEvent(event, newCallData("three"), mle_event.MLE_EVENT_IMMEDIATE)
+	recorder.SetFrame(5)
+	dispatcher.ProcessEvent(event, newCallData("five"), mle_event.MLE_EVENT_IMMEDIATE)
+	recorder.Close()
+
+	recording, merr := mle_event.LoadMleEventRecording(bytes.NewReader(buf.Bytes()), codec)
+	if merr != nil {
+		t.Fatalf("TestMleEventPlayerFrames: LoadMleEventRecording() failed: %s", merr.What)
+	}
+	replay := mle_event.NewMleEventDispatcher()
+	log := new(testMleEventCallback_Log)
+	replay.InstallEventCB(event, log.newCallback(nil), nil)
+	player := mle_event.NewMleEventPlayer(recording, replay)
+
+	// Log the events replayed by the end of each frame.
+	clock := &testMleFrameLoop_Clock{now: time.Unix(0, 0)}
+	task := &testMleFrameLoop_Task{clock: clock}
+	loop := testMleFrameLoop_NewLoop(task)
+	loop.SetEventDispatcher(replay)
+	loop.SetEventPlayer(player)
+	var frames []string
+	task.onRun = func(frame int) {
+		frames = append(frames, strings.Join(log.mEntries, ","))
+		if frame == 7 {
+			loop.Stop()
+		}
+	}
+	loop.Run(context.Background())
+	expected := []string{"", "", "", "0004=three", "0004=three", "0004=three,0004=five", "0004=three,0004=five"}
+	if strings.Join(frames, " ") != strings.Join(expected, " ") {
+		t.Errorf("TestMleEventPlayerFrames: replayed %q", frames)
+	}
+	if ! player.IsDone() || player.GetFrame() != 7 {
+		t.Errorf("TestMleEventPlayerFrames: player at frame %d", player.GetFrame())
+	}
+}
+
+func TestMleEventRecordingInvalid(t *testing.T) {
+	codec := mle_event.NewMleStringEventCodec()
+	invalid := []string{
+		"",
+		`{"format":"mle-events","version":99,"codec":"string"}`,
+		`{"format":"mle-events","version":1,"codec":"mux"}`,
+		`{"format":"mle-events","version":1,"codec":"string"}` + "\n{\"frame\":",
+	}
+	for _, stream := range invalid {
+		if _, err := mle_event.LoadMleEventRecording(strings.NewReader(stream), codec); err == nil {
+			t.Errorf("TestMleEventRecordingInvalid: loaded %q", stream)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/**
+ * @file MleEventRegistry_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	mle_event "github.com/mle/runtime/event"
+)
+
+// Test named groups and allocating events in them.
+func TestMleEventRegistryGroups(t *testing.T) {
+	testMleEventManager_setUp()
+	defer testMleEventManager_setUp()
+	manager := mle_event.NewMleEventManager()
+
+	if manager.GetGroup(mle_event.MLE_SYSTEM_GROUP_NAME) != mle_event.MLE_SYSTEM_GROUP {
+		t.Errorf("TestMleEventRegistryGroups: system group is not named")
+	}
+	// Group 1 has an event, so it is not allocated to a name.
+	manager.AddEvent(mle_event.MakeId(1, 0), "")
+	input, err := manager.RegisterGroup("input")
+	if err != nil || input != 2 {
+		t.Fatalf("TestMleEventRegistryGroups: RegisterGroup() = %d, %v", input, err)
+	}
+	if again, _ := manager.RegisterGroup("input"); again != input {
+		t.Errorf("TestMleEventRegistryGroups: input registered again as %d", again)
+	}
+	if _, err := manager.RegisterGroup(""); err == nil {
+		t.Errorf("TestMleEventRegistryGroups: empty group name accepted")
+	}
+	if manager.AddGroup(input, "physics") == nil || manager.AddGroup(5, "input") == nil {
+		t.Errorf("TestMleEventRegistryGroups: conflicting group names accepted")
+	}
+	if err := manager.AddGroup(5, "physics"); err != nil {
+		t.Errorf("TestMleEventRegistryGroups: AddGroup() failed: %s", err.What)
+	}
+	if manager.GetGroupName(5) != "physics" || manager.GetGroup("audio") != mle_event.MLE_INVALID_GROUP {
+		t.Errorf("TestMleEventRegistryGroups: wrong group names")
+	}
+	groups := manager.GetGroups()
+	if len(groups) != 3 || groups[0] != 0 || groups[1] != input || groups[2] != 5 {
+		t.Errorf("TestMleEventRegistryGroups: groups %v", groups)
+	}
+
+	// Concurrent allocations never collide.
+	var allocating sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		allocating.Add(1)
+		go func() {
+			defer allocating.Done()
+			for j := 0; j < 8; j++ {
+				if _, err := manager.AllocateEvent(input, ""); err != nil {
+					t.Errorf("TestMleEventRegistryGroups: AllocateEvent() failed: %s", err.What)
+				}
+			}
+		}()
+	}
+	allocating.Wait()
+	events := manager.GetEvents(input)
+	if len(events) != 64 || events[0] != mle_event.MakeId(input, 0) || events[63] != mle_event.MakeId(input, 63) {
+		t.Errorf("TestMleEventRegistryGroups: %d events in %d", len(events), input)
+	}
+	if _, err := manager.AllocateEvent(input, ""); err != nil {
+		t.Errorf("TestMleEventRegistryGroups: AllocateEvent() failed: %s", err.What)
+	}
+
+	// A full group reuses free ids, then reports it is full.
+	for eid := 65; eid <= int(mle_event.MLE_MAX_EVENT_ID); eid++ {
+		manager.AddEvent(mle_event.MakeEventID(input, uint16(eid)).Int(), "")
+	}
+	manager.RemoveEvent(mle_event.MakeId(input, 3))
+	if id, _ := manager.AllocateEvent(input, ""); id != mle_event.MakeId(input, 3) {
+		t.Errorf("TestMleEventRegistryGroups: free id was not reused, got 0x%x", id)
+	}
+	if _, err := manager.AllocateEvent(input, ""); err == nil {
+		t.Errorf("TestMleEventRegistryGroups: full group allocated an event")
+	}
+	if mle_event.CreateEvent(input) != mle_event.MLE_EVENT_INVALID_ID {
+		t.Errorf("TestMleEventRegistryGroups: CreateEvent() in a full group")
+	}
+}
+
+// Test exporting and importing the registry.
+func TestMleEventRegistryExport(t *testing.T) {
+	testMleEventManager_setUp()
+	defer testMleEventManager_setUp()
+	manager := mle_event.NewMleEventManager()
+
+	input, _ := manager.RegisterGroup("input")
+	keyDown, _ := manager.AllocateEvent(input, "key-down")
+	keyUp, _ := manager.AllocateEvent(input, "key-up")
+	raw := mle_event.MakeId(0x0024, 0x0007)
+	manager.AddEvent(raw, "")
+
+	var buffer bytes.Buffer
+	if err := manager.ExportRegistry(&buffer); err != nil {
+		t.Fatalf("TestMleEventRegistryExport: ExportRegistry() failed: %s", err.What)
+	}
+	exported := buffer.String()
+	if ! strings.Contains(exported, `"format": "mle-event-registry"`) || ! strings.Contains(exported, `"name": "key-up"`) {
+		t.Errorf("TestMleEventRegistryExport: exported %s", exported)
+	}
+
+	manager.Clear()
+	if err := manager.ImportRegistry(strings.NewReader(exported)); err != nil {
+		t.Fatalf("TestMleEventRegistryExport: ImportRegistry() failed: %s", err.What)
+	}
+	if manager.GetGroup("input") != input || manager.GetEventId("key-down") != keyDown ||
+		manager.GetEventId("key-up") != keyUp || ! manager.HasEvent(raw) {
+		t.Errorf("TestMleEventRegistryExport: registry not restored")
+	}
+	// Importing the same registry again is harmless.
+	if err := manager.ImportRegistry(strings.NewReader(exported)); err != nil {
+		t.Errorf("TestMleEventRegistryExport: reimport failed: %s", err.What)
+	}
+
+	// A conflicting registry imports nothing.
+	conflict := `{"format": "mle-event-registry", "version": 1, "groups": [
+		{"id": 9, "name": "audio", "events": [{"id": 0, "name": "play"}]},
+		{"id": 10, "name": "input", "events": []}]}`
+	if manager.ImportRegistry(strings.NewReader(conflict)) == nil {
+		t.Errorf("TestMleEventRegistryExport: conflicting registry imported")
+	}
+	if manager.GetGroup("audio") != mle_event.MLE_INVALID_GROUP || manager.HasEventByName("play") {
+		t.Errorf("TestMleEventRegistryExport: conflicting registry partly imported")
+	}
+	for _, invalid := range []string{`{"format": "mle-events", "version": 1}`,
+		`{"format": "mle-event-registry", "version": 2}`,
+		`{"format": "mle-event-registry", "version": 1, "groups": [{"id": 9, "events": [{"id": -1}]}]}`} {
+		if manager.ImportRegistry(strings.NewReader(invalid)) == nil {
+			t.Errorf("TestMleEventRegistryExport: imported %s", invalid)
+		}
+	}
+}
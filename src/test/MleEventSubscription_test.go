@@ -0,0 +1,160 @@
+/**
+ * @file MleEventSubscription_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+import (
+	"testing"
+	"time"
+
+	mle_event "github.com/mle/runtime/event"
+)
+
+func TestMleEventSubscription(t *testing.T) {
+	dispatcher := mle_event.NewMleEventDispatcher()
+	first := mle_event.MakeId(0x0020, 0x0001)
+	second := mle_event.MakeId(0x0020, 0x0002)
+	other := mle_event.MakeId(0x0020, 0x0003)
+
+	sub, err := dispatcher.NewSubscription(4, mle_event.MLE_SUBSCRIPTION_DROP, first, second)
+	if err != nil {
+		t.Fatalf("TestMleEventSubscription: NewSubscription() failed: %v", err)
+	}
+	dispatcher.InstallEventCB(other, new(testMleEventCallback_Log).newCallback(nil), nil)
+
+	dispatcher.ProcessEvent(first, newCallData("first"), mle_event.MLE_EVENT_IMMEDIATE)
+	dispatcher.ProcessEvent(other, nil, mle_event.MLE_EVENT_IMMEDIATE)
+	dispatcher.ProcessEvent(second, newCallData("second"), mle_event.MLE_EVENT_DELAYED)
+	dispatcher.DispatchEvents()
+
+	var received []string
+	for len(received) < 2 {
+		select {
+		case event := <-sub.GetChannel():
+			received = append(received, event.GetCallData().String())
+		case <-time.After(time.Second):
+			t.Fatalf("TestMleEventSubscription: received only %v", received)
+		}
+	}
+	if received[0] != "first" || received[1] != "second" {
+		t.Errorf("TestMleEventSubscription: received %v", received)
+	}
+	select {
+	case event := <-sub.GetChannel():
+		t.Errorf("TestMleEventSubscription: received unsubscribed event %v", event)
+	default:
+	}
+
+	// A full channel drops events.
+	for i := 0; i < 6; i++ {
+		dispatcher.ProcessEvent(first, nil, mle_event.MLE_EVENT_IMMEDIATE)
+	}
+	if sub.GetDropped() != 2 {
+		t.Errorf("TestMleEventSubscription: dropped %d events, expected 2", sub.GetDropped())
+	}
+
+	// Close uninstalls the callbacks and closes the channel after the
+	// buffered events.
+	sub.Close()
+	sub.Close()
+	dispatcher.ProcessEvent(first, nil, mle_event.MLE_EVENT_IMMEDIATE)
+	count := 0
+	for range sub.GetChannel() {
+		count++
+	}
+	if count != 4 {
+		t.Errorf("TestMleEventSubscription: %d buffered events after Close(), expected 4", count)
+	}
+
+	if _, err := dispatcher.NewSubscription(1, mle_event.MLE_SUBSCRIPTION_DROP); err == nil {
+		t.Errorf("TestMleEventSubscription: NewSubscription() accepted no events")
+	}
+	if _, err := dispatcher.NewSubscription(1, 7, first); err == nil {
+		t.Errorf("TestMleEventSubscription: NewSubscription() accepted an invalid policy")
+	}
+}
+
+func TestMleEventGroupSubscription(t *testing.T) {
+	dispatcher := mle_event.NewMleEventDispatcher()
+	sub, err := dispatcher.NewGroupSubscription(0, mle_event.MLE_SUBSCRIPTION_BLOCK, 0x0021)
+	if err != nil {
+		t.Fatalf("TestMleEventGroupSubscription: NewGroupSubscription() failed: %v", err)
+	}
+
+	// Events in the group are delivered without callbacks of their own, and
+	// the producer blocks until they are received.
+	produced := make(chan bool)
+	go func() {
+		for i := int16(1); i <= 3; i++ {
+			dispatcher.ProcessEvent(mle_event.MakeId(0x0021, i), nil, mle_event.MLE_EVENT_IMMEDIATE)
+		}
+		dispatcher.ProcessEvent(mle_event.MakeId(0x0022, 1), nil, mle_event.MLE_EVENT_IMMEDIATE)
+		close(produced)
+	}()
+	for i := int16(1); i <= 3; i++ {
+		event := <-sub.GetChannel()
+		if event.GetId() != mle_event.MakeId(0x0021, i) {
+			t.Errorf("TestMleEventGroupSubscription: received event 0x%08x", event.GetId())
+		}
+	}
+	<-produced
+
+	// A disabled event is not delivered.
+	disabled := mle_event.MakeId(0x0021, 4)
+	dispatcher.InstallEventCB(disabled, new(testMleEventCallback_Log).newCallback(nil), nil)
+	dispatcher.DisableEvent(disabled)
+	dispatcher.ProcessEvent(disabled, nil, mle_event.MLE_EVENT_IMMEDIATE)
+
+	// Close releases a blocked producer.
+	blocked := make(chan bool)
+	go func() {
+		dispatcher.ProcessEvent(mle_event.MakeId(0x0021, 5), nil, mle_event.MLE_EVENT_IMMEDIATE)
+		close(blocked)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	sub.Close()
+	select {
+	case <-blocked:
+	case <-time.After(time.Second):
+		t.Fatalf("TestMleEventGroupSubscription: Close() did not release the producer")
+	}
+	if _, ok := <-sub.GetChannel(); ok {
+		t.Errorf("TestMleEventGroupSubscription: channel is not closed")
+	}
+}
@@ -0,0 +1,193 @@
+/**
+ * @file MleEventTimer_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	mle_event "github.com/mle/runtime/event"
+)
+
+func TestMleEventTimer(t *testing.T) {
+	ms := time.Millisecond
+	clock := &testMleFrameLoop_Clock{now: time.Unix(0, 0)}
+	dispatcher := mle_event.NewMleEventDispatcher()
+	dispatcher.SetClock(clock)
+	log := new(testMleEventCallback_Log)
+	dispatcher.InstallGroupCB(0x0025, log.newCallback(nil), nil, 0)
+	dispatched := func() string {
+		dispatcher.DispatchEvents()
+		result := log.String()
+		log.reset()
+		return result
+	}
+
+	after := dispatcher.PostEventAfter(mle_event.MakeId(0x0025, 1), newCallData("after"), 0, 50*ms)
+	cancelled := dispatcher.PostEventAfter(mle_event.MakeId(0x0025, 2), nil, 0, 50*ms)
+	every, err := dispatcher.PostEventEvery(mle_event.MakeId(0x0025, 3), nil, 5, 10*ms)
+	if err != nil {
+		t.Fatalf("TestMleEventTimer: PostEventEvery() failed: %s", err.What)
+	}
+	if ! cancelled.Cancel() || cancelled.Cancel() {
+		t.Errorf("TestMleEventTimer: Cancel() did not report the first cancellation")
+	}
+	if dispatcher.GetNumPendingTimers() != 2 {
+		t.Errorf("TestMleEventTimer: %d pending timers", dispatcher.GetNumPendingTimers())
+	}
+
+	if got := dispatched(); got != "" {
+		t.Errorf("TestMleEventTimer: dispatched %q before any timer fell due", got)
+	}
+	// Missed periods are skipped.
+	clock.Advance(35 * ms)
+	if got := dispatched(); got != "0003" {
+		t.Errorf("TestMleEventTimer: dispatched %q at 35ms", got)
+	}
+	clock.Advance(15 * ms)
+	if got := dispatched(); got != "0003 0001=after" {
+		t.Errorf("TestMleEventTimer: dispatched %q at 50ms", got)
+	}
+	if after.GetFireCount() != 1 || every.GetFireCount() != 2 || cancelled.GetFireCount() != 0 {
+		t.Errorf("TestMleEventTimer: fired %d, %d and %d times",
+			after.GetFireCount(), every.GetFireCount(), cancelled.GetFireCount())
+	}
+	every.Cancel()
+	clock.Advance(time.Second)
+	if got := dispatched(); got != "" || dispatcher.GetNumPendingTimers() != 0 {
+		t.Errorf("TestMleEventTimer: dispatched %q after cancelling", got)
+	}
+
+	// Frame timers.
+	dispatcher.SetFrame(1)
+	dispatcher.PostEventAtFrame(mle_event.MakeId(0x0025, 4), nil, 1, 3)
+	dispatcher.PostEventEveryFrames(mle_event.MakeId(0x0025, 5), nil, 0, 2)
+	var frames []string
+	for frame := int64(2); frame <= 6; frame++ {
+		dispatcher.SetFrame(frame)
+		frames = append(frames, dispatched())
+	}
+	if strings.Join(frames, ",") != ",0004 0005,,0005," {
+		t.Errorf("TestMleEventTimer: frames dispatched %q", frames)
+	}
+	if _, err := dispatcher.PostEventEveryFrames(mle_event.MakeId(0x0025, 6), nil, 0, 0); err == nil {
+		t.Errorf("TestMleEventTimer: PostEventEveryFrames() accepted no frames")
+	}
+}
+
+// Cancelling removes a timer from the pending timers, leaving the others to
+// fire in order.
+func TestMleEventTimerCancel(t *testing.T) {
+	clock := &testMleFrameLoop_Clock{now: time.Unix(0, 0)}
+	dispatcher := mle_event.NewMleEventDispatcher()
+	dispatcher.SetClock(clock)
+	log := new(testMleEventCallback_Log)
+	dispatcher.InstallGroupCB(0x0027, log.newCallback(nil), nil, 0)
+
+	// Post the timers out of order, each firing after its event number in ms.
+	timers := make(map[int]*mle_event.MleEventTimer)
+	for i := 0; i < 20; i++ {
+		n := i * 7 % 20 + 1
+		timers[n] = dispatcher.PostEventAfter(mle_event.MakeId(0x0027, int16(n)), nil, 0, time.Duration(n)*time.Millisecond)
+	}
+	pending := 20
+	var expected []string
+	for n := 1; n <= 20; n++ {
+		if n % 3 != 0 {
+			expected = append(expected, fmt.Sprintf("%04x", n))
+			continue
+		}
+		timers[n].Cancel()
+		pending--
+		if dispatcher.GetNumPendingTimers() != pending {
+			t.Fatalf("TestMleEventTimerCancel: %d pending timers after cancelling %d", dispatcher.GetNumPendingTimers(), n)
+		}
+	}
+
+	clock.Advance(time.Second)
+	dispatcher.DispatchEvents()
+	if log.String() != strings.Join(expected, " ") || dispatcher.GetNumPendingTimers() != 0 {
+		t.Errorf("TestMleEventTimerCancel: dispatched %q", log.String())
+	}
+	// Cancelling a timer that has fired does not change the pending timers.
+	if ! timers[1].Cancel() || timers[1].Cancel() || dispatcher.GetNumPendingTimers() != 0 {
+		t.Errorf("TestMleEventTimerCancel: cancelled a fired timer")
+	}
+}
+
+// The frame loop drives timed events with its clock and frame count.
+func TestMleEventTimerFrameLoop(t *testing.T) {
+	clock := &testMleFrameLoop_Clock{now: time.Unix(0, 0)}
+	task := &testMleFrameLoop_Task{clock: clock}
+	loop := testMleFrameLoop_NewLoop(task)
+	dispatcher := mle_event.NewMleEventDispatcher()
+	loop.SetEventDispatcher(dispatcher)
+
+	var atFrame, atTime int
+	log := new(testMleEventCallback_Log)
+	dispatcher.InstallGroupCB(0x0026, log.newCallback(nil), nil, 0)
+	dispatcher.PostEventAtFrame(mle_event.MakeId(0x0026, 1), nil, 0, 2)
+	dispatcher.PostEventAfter(mle_event.MakeId(0x0026, 2), nil, 0, 250*time.Millisecond)
+	task.onRun = func(frame int) {
+		for _, entry := range log.mEntries {
+			if entry == "0001" && atFrame == 0 {
+				atFrame = frame
+			} else if entry == "0002" && atTime == 0 {
+				atTime = frame
+			}
+		}
+		if frame == 5 {
+			loop.Stop()
+		}
+	}
+	if err := loop.Run(context.Background()); err != nil {
+		t.Fatalf("TestMleEventTimerFrameLoop: Run() failed: %s", err.What)
+	}
+
+	// The frame event is dispatched before the third frame runs, and the
+	// time event before the frame starting at 300ms, at 10 frames a second.
+	if atFrame != 3 || atTime != 4 {
+		t.Errorf("TestMleEventTimerFrameLoop: frame event seen by frame %d, time event by frame %d", atFrame, atTime)
+	}
+}
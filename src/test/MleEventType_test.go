@@ -0,0 +1,161 @@
+/**
+ * @file MleEventType_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	mle_event "github.com/mle/runtime/event"
+)
+
+type testMleEventType_Move struct {
+	X, Y int
+}
+
+func TestMleEventType(t *testing.T) {
+	testMleEventManager_setUp()
+
+	move, err := mle_event.CreateEventType[testMleEventType_Move](0x0017, "move")
+	if err != nil {
+		t.Fatalf("TestMleEventType: CreateEventType() failed: %v", err)
+	}
+	manager := mle_event.NewMleEventManager()
+	if manager.GetEventId("move") != move.GetId() {
+		t.Errorf("TestMleEventType: move is not registered by name")
+	}
+	if manager.GetEventPayloadType(move.GetId()) != reflect.TypeOf(testMleEventType_Move{}) {
+		t.Errorf("TestMleEventType: payload type is %v", manager.GetEventPayloadType(move.GetId()))
+	}
+
+	// The same event may not be registered with another payload type.
+	if _, err := mle_event.RegisterEventType[string](move.GetId(), "move"); err == nil {
+		t.Errorf("TestMleEventType: RegisterEventType() accepted a second payload type")
+	}
+	// Nor under another name.
+	for _, name := range []string{"", "jump"} {
+		if _, err := mle_event.RegisterEventType[testMleEventType_Move](move.GetId(), name); err == nil {
+			t.Errorf("TestMleEventType: RegisterEventType() accepted the name %q", name)
+		}
+	}
+	if manager.GetEventName(move.GetId()) != "move" || manager.HasEventByName("jump") {
+		t.Errorf("TestMleEventType: move was renamed")
+	}
+	if again, err := mle_event.RegisterEventType[testMleEventType_Move](move.GetId(), "move"); err != nil || again.GetId() != move.GetId() {
+		t.Errorf("TestMleEventType: RegisterEventType() with the same payload type failed: %v", err)
+	}
+
+	// Handlers receive the payload in priority order.
+	dispatcher := mle_event.NewMleEventDispatcher()
+	var received []string
+	mle_event.SubscribeWithPriority(dispatcher, move, func(m testMleEventType_Move) {
+		received = append(received, "low")
+	}, 1)
+	id, _ := mle_event.SubscribeWithPriority(dispatcher, move, func(m testMleEventType_Move) {
+		if m.X != 3 || m.Y != 4 {
+			t.Errorf("TestMleEventType: payload is %+v", m)
+		}
+		received = append(received, "high")
+	}, 2)
+
+	if ! mle_event.Emit(dispatcher, move, testMleEventType_Move{3, 4}) {
+		t.Errorf("TestMleEventType: Emit() returned false")
+	}
+	if len(received) != 2 || received[0] != "high" || received[1] != "low" {
+		t.Errorf("TestMleEventType: handlers received %v", received)
+	}
+
+	// Delayed events are dispatched later; untyped call data is not passed
+	// to the handlers.
+	received = nil
+	mle_event.Unsubscribe(dispatcher, move, id)
+	mle_event.EmitDelayed(dispatcher, move, testMleEventType_Move{1, 2}, 0)
+	dispatcher.ProcessEvent(move.GetId(), newCallData("untyped"), mle_event.MLE_EVENT_IMMEDIATE)
+	if len(received) != 0 {
+		t.Errorf("TestMleEventType: handlers received %v before dispatching", received)
+	}
+	dispatcher.DispatchEvents()
+	if len(received) != 1 || received[0] != "low" {
+		t.Errorf("TestMleEventType: handlers received %v", received)
+	}
+}
+
+// Test that concurrent callers are given distinct events.
+func TestMleEventTypeCreateConcurrent(t *testing.T) {
+	testMleEventManager_setUp()
+
+	ids := make([]int, 8)
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if event, err := mle_event.CreateEventType[int](0x0018, ""); err == nil {
+				ids[i] = event.GetId()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			t.Fatalf("TestMleEventTypeCreateConcurrent: created %04x", ids)
+		}
+		seen[id] = true
+	}
+}
+
+func TestMleEventTypeGetPayload(t *testing.T) {
+	count, err := mle_event.RegisterEventType[int](mle_event.MakeId(0x0017, 0x0100), "")
+	if err != nil {
+		t.Fatalf("TestMleEventTypeGetPayload: RegisterEventType() failed: %v", err)
+	}
+
+	event := mle_event.NewMleEventWithIdCalldata(nil, count.GetId(), mle_event.NewTypedCallData(42))
+	if payload, ok := count.GetPayload(event); ! ok || payload != 42 {
+		t.Errorf("TestMleEventTypeGetPayload: GetPayload() returned %v, %v", payload, ok)
+	}
+	event.SetCallData(newCallData("untyped"))
+	if _, ok := count.GetPayload(event); ok {
+		t.Errorf("TestMleEventTypeGetPayload: GetPayload() accepted untyped call data")
+	}
+}
@@ -0,0 +1,275 @@
+/**
+ * @file MleFrameLoop_test.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	mle_event "github.com/mle/runtime/event"
+	mle_sched "github.com/mle/runtime/scheduler"
+)
+
+// A clock advanced only by sleeping, or explicitly by tasks.
+type testMleFrameLoop_Clock struct {
+	now  time.Time
+	lock sync.Mutex
+}
+
+func (c *testMleFrameLoop_Clock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *testMleFrameLoop_Clock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+func (c *testMleFrameLoop_Clock) Advance(d time.Duration) {
+	c.lock.Lock()
+	c.now = c.now.Add(d)
+	c.lock.Unlock()
+}
+
+// A task which takes the scripted time for each frame.
+type testMleFrameLoop_Task struct {
+	clock  *testMleFrameLoop_Clock
+	costs  []time.Duration
+	frames int
+	onRun  func(frame int)
+}
+
+func (task *testMleFrameLoop_Task) Run(done chan bool) {
+	if task.frames < len(task.costs) {
+		task.clock.Advance(task.costs[task.frames])
+	}
+	task.frames++
+	if task.onRun != nil {
+		task.onRun(task.frames)
+	}
+	done <- true
+}
+
+func (task *testMleFrameLoop_Task) String() string {
+	return "Frame Task"
+}
+
+func testMleFrameLoop_NewLoop(task *testMleFrameLoop_Task) *mle_sched.MleFrameLoop {
+	scheduler := mle_sched.NewMleScheduler()
+	phase := mle_sched.NewMlePhaseWithName("Frame Phase")
+	phase.AddTask(mle_sched.NewMleTask(task))
+	scheduler.AddPhase(phase)
+
+	loop, err := mle_sched.NewMleFrameLoop(scheduler, 10)
+	if err != nil {
+		panic(err.What)
+	}
+	loop.SetClock(task.clock)
+	return loop
+}
+
+// Test that a frame rate which is not positive is rejected.
+func TestMleFrameLoopInvalidRate(t *testing.T) {
+	scheduler := mle_sched.NewMleScheduler()
+	for _, rate := range []float64{0, -30} {
+		if loop, err := mle_sched.NewMleFrameLoop(scheduler, rate); (loop != nil) || (err == nil) {
+			t.Errorf("TestMleFrameLoopInvalidRate: NewMleFrameLoop() accepted rate %v", rate)
+		}
+	}
+
+	// An invalid rate leaves the frame rate unchanged, and the loop still runs.
+	clock := &testMleFrameLoop_Clock{now: time.Unix(0, 0)}
+	task := &testMleFrameLoop_Task{clock: clock}
+	loop := testMleFrameLoop_NewLoop(task)
+	for _, rate := range []float64{0, -30} {
+		if err := loop.SetFrameRate(rate); err == nil {
+			t.Errorf("TestMleFrameLoopInvalidRate: SetFrameRate() accepted rate %v", rate)
+		}
+	}
+	if loop.GetFramePeriod() != 100*time.Millisecond {
+		t.Errorf("TestMleFrameLoopInvalidRate: frame period is %v", loop.GetFramePeriod())
+	}
+	task.onRun = func(frame int) {
+		if frame == 2 {
+			loop.Stop()
+		}
+	}
+	if err := loop.Run(context.Background()); err != nil {
+		t.Fatalf("TestMleFrameLoopInvalidRate: Run() failed: %s", err.What)
+	}
+	if loop.GetStats().GetFrameCount() != 2 {
+		t.Errorf("TestMleFrameLoopInvalidRate: ran %d frames", loop.GetStats().GetFrameCount())
+	}
+}
+
+// Test fixed timestep frames, overruns and catching up.
+func TestMleFrameLoopFixed(t *testing.T) {
+	clock := &testMleFrameLoop_Clock{now: time.Unix(0, 0)}
+	ms := time.Millisecond
+	task := &testMleFrameLoop_Task{clock: clock, costs: []time.Duration{10 * ms, 10 * ms, 250 * ms, 10 * ms, 10 * ms, 10 * ms}}
+	loop := testMleFrameLoop_NewLoop(task)
+	loop.SetMaxCatchUpSteps(2)
+	task.onRun = func(frame int) {
+		if frame == 6 {
+			loop.Stop()
+		}
+	}
+
+	if err := loop.Run(context.Background()); err != nil {
+		t.Fatalf("TestMleFrameLoopFixed: Run() failed: %s", err.What)
+	}
+	stats := loop.GetStats()
+	// Frame 3 overruns by 150ms; frames 4 and 5 run back to back to catch up.
+	if (stats.GetFrameCount() != 6) || (stats.GetOverrunCount() != 1) || (stats.GetDroppedFrameCount() != 0) {
+		t.Errorf("TestMleFrameLoopFixed: %d frames, %d overruns, %d dropped",
+			stats.GetFrameCount(), stats.GetOverrunCount(), stats.GetDroppedFrameCount())
+	}
+	if (stats.GetDeltaTime() != 100*ms) || (stats.GetMaxFrameTime() != 250*ms) || (stats.GetLastFrameTime() != 10*ms) {
+		t.Errorf("TestMleFrameLoopFixed: delta %v, max %v, last %v",
+			stats.GetDeltaTime(), stats.GetMaxFrameTime(), stats.GetLastFrameTime())
+	}
+	if stats.GetAverageFrameTime() != 50*ms {
+		t.Errorf("TestMleFrameLoopFixed: average %v", stats.GetAverageFrameTime())
+	}
+	// Five frame periods, plus the last frame.
+	if clock.Now().Sub(time.Unix(0, 0)) != 560*ms {
+		t.Errorf("TestMleFrameLoopFixed: loop ran for %v", clock.Now().Sub(time.Unix(0, 0)))
+	}
+}
+
+// Test dropping timesteps when too far behind.
+func TestMleFrameLoopDropped(t *testing.T) {
+	clock := &testMleFrameLoop_Clock{now: time.Unix(0, 0)}
+	ms := time.Millisecond
+	task := &testMleFrameLoop_Task{clock: clock, costs: []time.Duration{550 * ms}}
+	loop := testMleFrameLoop_NewLoop(task)
+	loop.SetMaxCatchUpSteps(2)
+	task.onRun = func(frame int) {
+		if frame == 3 {
+			loop.Stop()
+		}
+	}
+
+	loop.Run(context.Background())
+	stats := loop.GetStats()
+	if (stats.GetFrameCount() != 3) || (stats.GetDroppedFrameCount() != 3) {
+		t.Errorf("TestMleFrameLoopDropped: %d frames, %d dropped", stats.GetFrameCount(), stats.GetDroppedFrameCount())
+	}
+}
+
+// Test variable timestep frames.
+func TestMleFrameLoopVariable(t *testing.T) {
+	clock := &testMleFrameLoop_Clock{now: time.Unix(0, 0)}
+	ms := time.Millisecond
+	task := &testMleFrameLoop_Task{clock: clock, costs: []time.Duration{30 * ms, 150 * ms, 30 * ms}}
+	loop := testMleFrameLoop_NewLoop(task)
+	if err := loop.SetMode(mle_sched.MLE_FRAME_VARIABLE_TIMESTEP); err != nil {
+		t.Fatalf("TestMleFrameLoopVariable: SetMode() failed: %s", err.What)
+	}
+	if loop.SetMode(0) == nil {
+		t.Errorf("TestMleFrameLoopVariable: invalid mode accepted")
+	}
+	var deltas []time.Duration
+	task.onRun = func(frame int) {
+		deltas = append(deltas, loop.GetStats().GetDeltaTime())
+		if frame == 3 {
+			loop.Stop()
+		}
+	}
+
+	loop.Run(context.Background())
+	expected := []time.Duration{0, 100 * ms, 150 * ms}
+	for i := range expected {
+		if (i >= len(deltas)) || (deltas[i] != expected[i]) {
+			t.Fatalf("TestMleFrameLoopVariable: deltas = %v", deltas)
+		}
+	}
+	if loop.GetStats().GetOverrunCount() != 1 {
+		t.Errorf("TestMleFrameLoopVariable: %d overruns", loop.GetStats().GetOverrunCount())
+	}
+}
+
+// Test pausing, single stepping and exiting on MLE_QUIT.
+func TestMleFrameLoopPauseAndQuit(t *testing.T) {
+	clock := &testMleFrameLoop_Clock{now: time.Unix(0, 0)}
+	task := &testMleFrameLoop_Task{clock: clock}
+	loop := testMleFrameLoop_NewLoop(task)
+	dispatcher := mle_event.NewMleEventDispatcher()
+	if err := loop.SetEventDispatcher(dispatcher); err != nil {
+		t.Fatalf("TestMleFrameLoopPauseAndQuit: SetEventDispatcher() failed: %s", err.What)
+	}
+
+	task.onRun = func(frame int) {
+		switch frame {
+		case 2:
+			loop.Pause()
+			loop.Step()
+		case 3:
+			if !loop.IsPaused() {
+				t.Errorf("TestMleFrameLoopPauseAndQuit: loop is not paused")
+			}
+			loop.Resume()
+		case 4:
+			dispatcher.ProcessEvent(mle_event.MLE_QUIT, nil, mle_event.MLE_EVENT_DELAYED)
+		}
+	}
+
+	if err := loop.Run(context.Background()); err != nil {
+		t.Fatalf("TestMleFrameLoopPauseAndQuit: Run() failed: %s", err.What)
+	}
+	if loop.GetStats().GetFrameCount() != 4 {
+		t.Errorf("TestMleFrameLoopPauseAndQuit: %d frames", loop.GetStats().GetFrameCount())
+	}
+
+	// It is Ok to exit before the first frame.
+	mle_event.SetExitStatus(true)
+	defer mle_event.SetExitStatus(false)
+	if err := loop.Run(context.Background()); err != nil {
+		t.Errorf("TestMleFrameLoopPauseAndQuit: Run() failed: %s", err.What)
+	}
+	if loop.GetStats().GetFrameCount() != 4 {
+		t.Errorf("TestMleFrameLoopPauseAndQuit: frames run after exit")
+	}
+	loop.SetEventDispatcher(nil)
+}
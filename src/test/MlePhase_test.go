@@ -40,8 +40,11 @@
 package mle_test
 
 import (
+	"reflect"
 	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	mle_core "github.com/mle/runtime/core"
 	mle_sched "github.com/mle/runtime/scheduler"
@@ -102,4 +105,118 @@ func TestMlePhaseAddTask(t *testing.T) {
 	if n != 1 {
 		t.Errorf("TestNewMlePhase: expected 1, got %d", n)
 	}
-}
\ No newline at end of file
+}
+
+// A task recording when it starts and ends.
+type testMlePhase_OrderTask struct {
+	mName string
+	log   *[]string
+	lock  *sync.Mutex
+	wait  chan bool
+}
+
+func (task *testMlePhase_OrderTask) record(event string) {
+	task.lock.Lock()
+	*task.log = append(*task.log, event)
+	task.lock.Unlock()
+}
+
+func (task *testMlePhase_OrderTask) Run(done chan bool) {
+	task.record("start " + task.mName)
+	if task.wait != nil {
+		// Only completes if the waited for task runs concurrently.
+		select {
+		case <-task.wait:
+		case <-time.After(time.Second):
+			task.record("timeout " + task.mName)
+		}
+	}
+	task.record("end " + task.mName)
+	done <- true
+}
+
+func (task *testMlePhase_OrderTask) String() string {
+	return task.mName
+}
+
+func TestMlePhaseTaskDependencies(t *testing.T) {
+	var log []string
+	var lock sync.Mutex
+	started := make(chan bool)
+	newTask := func(name string, wait chan bool) *mle_sched.MleTask {
+		return mle_sched.NewMleTaskWithName(&testMlePhase_OrderTask{name, &log, &lock, wait}, name)
+	}
+
+	physics := newTask("physics", started)
+	collision := newTask("collision", nil)
+	collision.AddDependency(physics)
+	render := newTask("render", nil)
+	render.AddDependencyWithName("collision")
+	audio := mle_sched.NewMleTaskWithName(&testMlePhase_SignalTask{started}, "audio")
+
+	phase := mle_sched.NewMlePhaseWithName("Dependency Phase")
+	for _, task := range []*mle_sched.MleTask{render, collision, physics, audio} {
+		if !phase.AddTask(task) {
+			t.Fatalf("TestMlePhaseTaskDependencies: AddTask(%s) failed", task.GetName())
+		}
+	}
+
+	order, err := phase.GetExecutionOrder()
+	if err != nil {
+		t.Fatalf("TestMlePhaseTaskDependencies: GetExecutionOrder() failed: %s", err.What)
+	}
+	var names []string
+	for _, task := range order {
+		names = append(names, task.GetName())
+	}
+	if !reflect.DeepEqual(names, []string{"physics", "audio", "collision", "render"}) {
+		t.Errorf("TestMlePhaseTaskDependencies: execution order = %v", names)
+	}
+
+	phase.Run(nil)
+	expected := []string{"start physics", "end physics", "start collision", "end collision", "start render", "end render"}
+	if !reflect.DeepEqual(log, expected) {
+		t.Errorf("TestMlePhaseTaskDependencies: log = %v", log)
+	}
+}
+
+// A task signaling that it has started.
+type testMlePhase_SignalTask struct {
+	started chan bool
+}
+
+func (task *testMlePhase_SignalTask) Run(done chan bool) {
+	task.started <- true
+	done <- true
+}
+
+func (task *testMlePhase_SignalTask) String() string {
+	return "signal"
+}
+
+func TestMlePhaseTaskCycle(t *testing.T) {
+	a := mle_sched.NewMleTaskWithName(nil, "a")
+	b := mle_sched.NewMleTaskWithName(nil, "b")
+	c := mle_sched.NewMleTaskWithName(nil, "c")
+	a.AddDependencyWithName("c")
+	b.AddDependency(a)
+	c.AddDependency(b)
+
+	phase := mle_sched.NewMlePhase()
+	if !phase.AddTask(a) || !phase.AddTask(b) {
+		t.Fatalf("TestMlePhaseTaskCycle: AddTask() failed")
+	}
+	if phase.AddTask(c) {
+		t.Errorf("TestMlePhaseTaskCycle: cycle was not detected")
+	}
+	if phase.GetNumberOfTasks() != 2 {
+		t.Errorf("TestMlePhaseTaskCycle: expected 2 tasks, got %d", phase.GetNumberOfTasks())
+	}
+
+	self := mle_sched.NewMleTaskWithName(nil, "self")
+	self.AddDependency(self)
+	if phase.AddTask(self) {
+		t.Errorf("TestMlePhaseTaskCycle: self dependency was not detected")
+	}
+}
+
@@ -0,0 +1,148 @@
+/**
+ * @file MleProfiler_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"runtime/pprof"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mle_sched "github.com/mle/runtime/scheduler"
+	mle_util "github.com/mle/runtime/util"
+)
+
+func TestMleProfiler(t *testing.T) {
+	var labelled int32
+	work := func(ctx context.Context) error {
+		if task, _ := pprof.Label(ctx, "mle_task"); task != "" {
+			atomic.AddInt32(&labelled, 1)
+		}
+		time.Sleep(2 * time.Millisecond)
+		return nil
+	}
+
+	scheduler := mle_sched.NewMleScheduler()
+	update := mle_sched.NewMlePhaseWithName("Update")
+	render := mle_sched.NewMlePhaseWithName("Render")
+	scheduler.AddPhase(update)
+	scheduler.AddPhase(render)
+	scheduler.AddTask(update, mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc("ai", work), "ai"))
+	scheduler.AddTask(update, mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc("physics", work), "physics"))
+	scheduler.AddTask(render, mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc("draw", work), "draw"))
+
+	profiler := mle_sched.NewMleProfiler(2)
+	scheduler.SetProfiler(profiler)
+	for i := 0; i < 3; i++ {
+		if err := scheduler.RunFrame(context.Background()); err != nil {
+			t.Fatalf("TestMleProfiler: RunFrame() failed: %s", err.What)
+		}
+	}
+	if labelled != 9 {
+		t.Errorf("TestMleProfiler: %d task runs had a pprof label, expected 9", labelled)
+	}
+
+	// Only the most recent frames are kept.
+	frames := profiler.GetFrames()
+	if (len(frames) != 2) || (profiler.GetLatestFrame().GetNumber() != 3) {
+		t.Fatalf("TestMleProfiler: %d frames kept, expected 2", len(frames))
+	}
+	latest := profiler.GetLatestFrame()
+	if len(latest.GetSpans()) != 5 {
+		t.Errorf("TestMleProfiler: latest frame has %d spans, expected 5", len(latest.GetSpans()))
+	}
+	if (latest.GetDuration() < 4*time.Millisecond) || (latest.GetGoroutineCount() == 0) {
+		t.Errorf("TestMleProfiler: latest frame took %v with %d goroutines", latest.GetDuration(), latest.GetGoroutineCount())
+	}
+
+	// The histograms roll over the most recent samples.
+	stats := profiler.GetTaskStats("Update", "physics")
+	if (stats == nil) || (stats.GetCount() != 2) || (stats.GetTotalCount() != 3) {
+		t.Fatalf("TestMleProfiler: unexpected task stats %v", stats)
+	}
+	if stats.GetMin() < 2*time.Millisecond {
+		t.Errorf("TestMleProfiler: task took %v, less than it slept", stats.GetMin())
+	}
+	total := 0
+	for _, count := range stats.GetHistogram() {
+		total += count
+	}
+	if total != 2 {
+		t.Errorf("TestMleProfiler: histogram holds %d samples, expected 2", total)
+	}
+	if profiler.GetPhaseStats("Render").GetCount() != 2 || profiler.GetFrameStats().GetTotalCount() != 3 {
+		t.Errorf("TestMleProfiler: unexpected phase or frame stats")
+	}
+
+	// The trace holds the frames, phases and tasks of the kept frames.
+	var buf bytes.Buffer
+	if err := profiler.WriteChromeTrace(&buf); err != nil {
+		t.Fatalf("TestMleProfiler: WriteChromeTrace() failed: %s", err.What)
+	}
+	var trace struct {
+		TraceEvents []struct {
+			Name string  `json:"name"`
+			Cat  string  `json:"cat"`
+			Ph   string  `json:"ph"`
+			Dur  float64 `json:"dur"`
+			Tid  int     `json:"tid"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("TestMleProfiler: invalid trace: %s", err)
+	}
+	counts := make(map[string]int)
+	for _, event := range trace.TraceEvents {
+		if event.Ph == "X" {
+			counts[event.Cat]++
+			if (event.Cat == mle_sched.MLE_PROFILE_TASK) && (event.Tid == 0) {
+				t.Errorf("TestMleProfiler: task %s on the scheduler thread", event.Name)
+			}
+		}
+	}
+	if (counts[mle_sched.MLE_PROFILE_FRAME] != 2) || (counts[mle_sched.MLE_PROFILE_PHASE] != 4) ||
+		(counts[mle_sched.MLE_PROFILE_TASK] != 6) {
+		t.Errorf("TestMleProfiler: unexpected trace events %v", counts)
+	}
+	scheduler.Dump()
+}
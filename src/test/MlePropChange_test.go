@@ -0,0 +1,211 @@
+/**
+ * @file MlePropChange_test.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"reflect"
+	"testing"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_event "github.com/mle/runtime/event"
+)
+
+// An Actor which uses the default property change notification.
+type pchg_Actor struct {
+	*mle_core.MleActor
+	Count int32
+	Name  string
+}
+
+func pchg_NewActor() *pchg_Actor {
+	p := new(pchg_Actor)
+	p.MleActor = mle_core.NewMleActor()
+	p.BindProperties(p, "")
+	return p
+}
+
+// A Set which uses the default property change notification.
+type pchg_Set struct {
+	*mle_core.MleSet
+	Count int32
+}
+
+// A listener recording the events it receives.
+type pchg_Listener struct {
+	events []*mle_event.MlePropChangeEvent
+}
+
+func (l *pchg_Listener) PropChangedEvent(event *mle_event.MlePropChangeEvent) {
+	l.events = append(l.events, event)
+}
+
+func (l *pchg_Listener) names() []string {
+	var names []string
+	for _, event := range l.events {
+		names = append(names, event.GetPropertyName())
+	}
+	return names
+}
+
+// A vetoable listener rejecting negative counts.
+type pchg_Veto struct {
+	calls int
+}
+
+func (v *pchg_Veto) VetoablePropChange(event *mle_core.MlePropChangeEvent) *mle_core.MleError {
+	v.calls++
+	if event.GetNewValue().(*mle_core.MleTypedProp).GetValue().(int32) < 0 {
+		return mle_core.NewMleError("count must not be negative", 0, nil)
+	}
+	return nil
+}
+
+// Test named and wildcard listeners.
+func TestMlePropChangeListeners(t *testing.T) {
+	a := pchg_NewActor()
+	count := &pchg_Listener{}
+	all := &pchg_Listener{}
+
+	if err := a.AddPropertyChangeListener("", count); err == nil {
+		t.Errorf("TestMlePropChangeListeners: empty name accepted")
+	}
+	if err := a.AddPropertyChangeListener("count", count); err != nil {
+		t.Fatalf("TestMlePropChangeListeners: AddPropertyChangeListener() failed: %s", err.What)
+	}
+	if err := a.AddPropertyChangeListener(mle_core.MLE_PROP_CHANGE_ALL, all); err != nil {
+		t.Fatalf("TestMlePropChangeListeners: AddPropertyChangeListener() failed: %s", err.What)
+	}
+
+	a.SetProperty("count", mle_core.NewMleIntProp(3))
+	a.SetProperty("name", mle_core.NewMleStringProp("actor"))
+	// An unchanged value is not reported.
+	a.SetProperty("count", mle_core.NewMleIntProp(3))
+
+	if !reflect.DeepEqual(count.names(), []string{"count"}) {
+		t.Errorf("TestMlePropChangeListeners: count listener received %v", count.names())
+	}
+	if !reflect.DeepEqual(all.names(), []string{"count", "name"}) {
+		t.Errorf("TestMlePropChangeListeners: wildcard listener received %v", all.names())
+	}
+	event := count.events[0]
+	if event.GetSource() != a {
+		t.Errorf("TestMlePropChangeListeners: source = %v", event.GetSource())
+	}
+	if (event.GetOldValue().(*mle_core.MleTypedProp).GetValue() != int32(0)) ||
+		(event.GetNewValue().(*mle_core.MleTypedProp).GetValue() != int32(3)) {
+		t.Errorf("TestMlePropChangeListeners: event values = %v, %v", event.GetOldValue(), event.GetNewValue())
+	}
+
+	// Removed listeners are no longer notified.
+	a.RemovePropertyChangeListener("count", count)
+	a.SetProperty("count", mle_core.NewMleIntProp(4))
+	if (len(count.events) != 1) || (len(all.events) != 3) {
+		t.Errorf("TestMlePropChangeListeners: received %v and %v after removal", count.names(), all.names())
+	}
+
+	// Sets share the same notification.
+	s := new(pchg_Set)
+	s.MleSet = mle_core.NewMleSet()
+	s.BindProperties(s, "")
+	setListener := &pchg_Listener{}
+	s.AddPropertyChangeListener("count", setListener)
+	s.SetProperty("count", mle_core.NewMleIntProp(1))
+	if (len(setListener.events) != 1) || (setListener.events[0].GetSource() != s) {
+		t.Errorf("TestMlePropChangeListeners: Set listener received %v", setListener.names())
+	}
+}
+
+// Test vetoing a property change.
+func TestMlePropChangeVeto(t *testing.T) {
+	a := pchg_NewActor()
+	veto := &pchg_Veto{}
+	listener := &pchg_Listener{}
+	a.AddVetoablePropertyChangeListener("count", veto)
+	a.AddPropertyChangeListener("count", listener)
+
+	a.SetProperty("count", mle_core.NewMleIntProp(2))
+	a.SetProperty("count", mle_core.NewMleIntProp(-1))
+	if a.Count != 2 {
+		t.Errorf("TestMlePropChangeVeto: vetoed change was applied, count = %d", a.Count)
+	}
+	if (veto.calls != 2) || (len(listener.events) != 1) {
+		t.Errorf("TestMlePropChangeVeto: %d veto calls, %d events", veto.calls, len(listener.events))
+	}
+
+	a.RemoveVetoablePropertyChangeListener("count", veto)
+	a.SetProperty("count", mle_core.NewMleIntProp(-1))
+	if a.Count != -1 {
+		t.Errorf("TestMlePropChangeVeto: change not applied after removal, count = %d", a.Count)
+	}
+}
+
+// Test routing property changes through the event dispatcher.
+func TestMlePropChangeRouter(t *testing.T) {
+	dispatcher := mle_event.NewMleEventDispatcher()
+	router, err := mle_event.NewMlePropChangeRouter(dispatcher, mle_event.MakeId(1, 1))
+	if err != nil {
+		t.Fatalf("TestMlePropChangeRouter: NewMlePropChangeRouter() failed: %s", err.What)
+	}
+
+	a := pchg_NewActor()
+	listener := &pchg_Listener{}
+	a.AddPropertyChangeListener(mle_core.MLE_PROP_CHANGE_ALL, listener)
+	a.SetPropertyChangeDispatcher(router)
+
+	a.SetProperty("count", mle_core.NewMleIntProp(5))
+	a.SetProperty("name", mle_core.NewMleStringProp("routed"))
+	if len(listener.events) != 0 {
+		t.Errorf("TestMlePropChangeRouter: events delivered before dispatch: %v", listener.names())
+	}
+
+	dispatcher.DispatchEvents()
+	if !reflect.DeepEqual(listener.names(), []string{"count", "name"}) {
+		t.Errorf("TestMlePropChangeRouter: dispatched events = %v", listener.names())
+	}
+
+	// Without a dispatcher, events are delivered immediately.
+	a.SetPropertyChangeDispatcher(nil)
+	a.SetProperty("count", mle_core.NewMleIntProp(6))
+	if len(listener.events) != 3 {
+		t.Errorf("TestMlePropChangeRouter: event not delivered immediately")
+	}
+	router.Dispose()
+}
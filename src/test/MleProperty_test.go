@@ -0,0 +1,193 @@
+/**
+ * @file MleProperty_test.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	mle_core "github.com/mle/runtime/core"
+)
+
+// An Actor with a property of each supported type.
+type prop_Actor struct {
+	*mle_core.MleActor
+	Position mle_core.MleVector3 `mle:"position"`
+	Name     string
+	Count    int32
+	Size     int
+	Scale    float64
+	Visible  bool
+	Image    mle_core.MleMediaRefIndex
+	Weights  []float32
+	hidden   int32
+	changes  []string
+}
+
+func (a *prop_Actor) NotifyPropertyChange(name string, oldProperty mle_core.IMleProp, newProperty mle_core.IMleProp) {
+	a.changes = append(a.changes, name)
+}
+
+func prop_CreateActor() *prop_Actor {
+	p := new(prop_Actor)
+	p.MleActor = mle_core.NewMleActor()
+	return p
+}
+
+func prop_NewActor() *prop_Actor {
+	a := prop_CreateActor()
+	a.BindProperties(a, "")
+	return a
+}
+
+// Encode values in playprint byte order.
+func prop_Encode(values ...interface{}) *bytes.Reader {
+	var buf bytes.Buffer
+	for _, v := range values {
+		binary.Write(&buf, binary.BigEndian, v)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+// Test decoding properties with SetPropertyArray.
+func TestMleActorSetPropertyArray(t *testing.T) {
+	a := prop_NewActor()
+
+	a.SetPropertyArray("position", 4, 3, prop_Encode(float32(1), float32(2), float32(3)))
+	if a.Position != (mle_core.MleVector3{1, 2, 3}) {
+		t.Errorf("TestMleActorSetPropertyArray: position = %v", a.Position)
+	}
+	a.SetPropertyArray("name", 5, 1, bytes.NewReader([]byte("actor")))
+	if a.Name != "actor" {
+		t.Errorf("TestMleActorSetPropertyArray: name = %s", a.Name)
+	}
+	a.SetPropertyArray("count", 4, 1, prop_Encode(int32(-7)))
+	a.SetPropertyArray("size", 4, 1, prop_Encode(int32(12)))
+	a.SetPropertyArray("scale", 8, 1, prop_Encode(float64(0.5)))
+	a.SetPropertyArray("visible", 1, 1, bytes.NewReader([]byte{1}))
+	a.SetPropertyArray("image", 4, 1, prop_Encode(int32(3)))
+	a.SetPropertyArray("weights", 4, 2, prop_Encode(float32(0.25), float32(0.75)))
+	if (a.Count != -7) || (a.Size != 12) || (a.Scale != 0.5) || !a.Visible || (a.Image != 3) ||
+		!reflect.DeepEqual(a.Weights, []float32{0.25, 0.75}) {
+		t.Errorf("TestMleActorSetPropertyArray: properties not decoded: %+v", a)
+	}
+
+	// Malformed data and unbound fields are errors.
+	if a.SetPropertyArray("position", 4, 2, prop_Encode(float32(9), float32(9))) == nil ||
+		a.SetPropertyArray("hidden", 4, 1, prop_Encode(int32(1))) == nil ||
+		a.SetPropertyArray("missing", 4, 1, prop_Encode(int32(1))) == nil {
+		t.Errorf("TestMleActorSetPropertyArray: invalid property did not fail")
+	}
+	if (a.Position != (mle_core.MleVector3{1, 2, 3})) || (a.hidden != 0) {
+		t.Errorf("TestMleActorSetPropertyArray: invalid property was set")
+	}
+
+	expected := []string{"position", "name", "count", "size", "scale", "visible", "image", "weights"}
+	if !reflect.DeepEqual(a.changes, expected) {
+		t.Errorf("TestMleActorSetPropertyArray: changes = %v", a.changes)
+	}
+}
+
+// Test typed properties with GetProperty and SetProperty.
+func TestMleActorSetProperty(t *testing.T) {
+	a := prop_NewActor()
+
+	a.SetProperty("position", mle_core.NewMleVector3Prop(mle_core.MleVector3{4, 5, 6}))
+	prop := a.GetProperty("position")
+	if (prop == nil) || (prop.GetType() != mle_core.PROP_TYPE_VECTOR3) || (prop.GetLength() != 12) {
+		t.Fatalf("TestMleActorSetProperty: GetProperty() returned %v", prop)
+	}
+	if prop.(*mle_core.MleTypedProp).GetValue() != (mle_core.MleVector3{4, 5, 6}) {
+		t.Errorf("TestMleActorSetProperty: position = %v", prop.(*mle_core.MleTypedProp).GetValue())
+	}
+
+	// Setting the same value does not notify listeners.
+	a.SetProperty("position", mle_core.NewMleVector3Prop(mle_core.MleVector3{4, 5, 6}))
+	if len(a.changes) != 1 {
+		t.Errorf("TestMleActorSetProperty: %d changes notified", len(a.changes))
+	}
+
+	// Type mismatches are rejected.
+	a.SetProperty("name", mle_core.NewMleIntProp(1))
+	if a.Name != "" {
+		t.Errorf("TestMleActorSetProperty: int was assigned to a string")
+	}
+
+	// An untyped property is decoded according to the field.
+	a.SetProperty("count", mle_core.NewMlePropWithLengthAndData(4, prop_Encode(int32(42))))
+	if a.Count != 42 {
+		t.Errorf("TestMleActorSetProperty: count = %d", a.Count)
+	}
+	value := a.GetProperty("size").(*mle_core.MleTypedProp).GetValue()
+	if value != int32(0) {
+		t.Errorf("TestMleActorSetProperty: size = %v", value)
+	}
+	if a.GetProperty("missing") != nil {
+		t.Errorf("TestMleActorSetProperty: GetProperty(\"missing\") did not return nil")
+	}
+
+	// The encoded stream round trips.
+	weights := mle_core.NewMleFloatArrayProp([]float32{1, 2, 3})
+	decoded, err := mle_core.DecodeMleProp(mle_core.PROP_TYPE_FLOAT_ARRAY, weights.GetLength(), 1, weights.GetStream())
+	if (err != nil) || !reflect.DeepEqual(decoded.GetValue(), []float32{1, 2, 3}) {
+		t.Errorf("TestMleActorSetProperty: float array did not round trip")
+	}
+}
+
+// Test that Actors created from the tables only accept declared properties.
+func TestMleActorPropertyTable(t *testing.T) {
+	mle_core.RegisterActorClass("prop_Actor", prop_CreateActor)
+	tables := mle_core.GetMleTablesInstance()
+	tables.AddActorProperty(mle_core.NewMleRTPropertyEntryWithClassAndField("prop_Actor", "position"))
+
+	obj, err := mle_core.NewMleRTActorClassEntryWithClassAndOffset("prop_Actor", 0).CreateActor()
+	if err != nil {
+		t.Fatalf("TestMleActorPropertyTable: CreateActor() failed: %s", err.What)
+	}
+	a := (*obj).(*prop_Actor)
+	a.SetProperty("position", mle_core.NewMleVector3Prop(mle_core.MleVector3{1, 1, 1}))
+	a.SetProperty("name", mle_core.NewMleStringProp("undeclared"))
+	if (a.Position != (mle_core.MleVector3{1, 1, 1})) || (a.Name != "") {
+		t.Errorf("TestMleActorPropertyTable: property table not enforced")
+	}
+}
@@ -0,0 +1,248 @@
+/**
+ * @file MleSchedulerConfig_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_sched "github.com/mle/runtime/scheduler"
+	mle_util "github.com/mle/runtime/util"
+)
+
+var config_runs int32
+var config_registerOnce sync.Once
+
+type config_Runnable struct {
+}
+
+func (r *config_Runnable) Run(done chan bool) {
+	atomic.AddInt32(&config_runs, 1)
+	if done != nil {
+		done <- true
+	}
+}
+
+func (r *config_Runnable) String() string {
+	return "config_Runnable"
+}
+
+func config_Register(t *testing.T) {
+	config_registerOnce.Do(func() {
+		for _, name := range []string{"configPhysics", "configDraw"} {
+			if err := mle_core.RegisterRunnableClass(name, func() *config_Runnable { return new(config_Runnable) }); err != nil {
+				t.Fatalf("config_Register: RegisterRunnableClass() failed: %s", err.What)
+			}
+		}
+	})
+}
+
+const config_Valid = `{
+	"workerPoolSize": 2,
+	"phases": [
+		{"name": "Update", "tasks": [
+			{"name": "physics", "runnable": "configPhysics"},
+			{"name": "ai", "runnable": "configPhysics", "after": ["physics"], "frameInterval": 2, "frameOffset": 1,
+			 "failurePolicy": "retry", "retries": 3}
+		]},
+		{"name": "Render", "serial": true, "timeInterval": "10ms", "tasks": [
+			{"name": "draw", "runnable": "configDraw", "after": ["physics"]}
+		]}
+	]
+}`
+
+func TestMleSchedulerConfig(t *testing.T) {
+	config_Register(t)
+
+	config, err := mle_sched.LoadMleSchedulerConfig(strings.NewReader(config_Valid))
+	if err != nil {
+		t.Fatalf("TestMleSchedulerConfig: LoadMleSchedulerConfig() failed: %s", err.What)
+	}
+	scheduler, err := mle_sched.NewMleSchedulerFromConfig(config)
+	if err != nil {
+		t.Fatalf("TestMleSchedulerConfig: NewMleSchedulerFromConfig() failed: %s", err.What)
+	}
+
+	if (scheduler.GetNumberOfPhases() != 2) || (scheduler.GetPhase(1).GetName() != "Render") {
+		t.Fatalf("TestMleSchedulerConfig: phases not configured in order")
+	}
+	if (scheduler.GetWorkerPool() == nil) || !scheduler.GetPhase(1).IsSerial() {
+		t.Errorf("TestMleSchedulerConfig: worker pools not configured")
+	}
+	ai := scheduler.GetPhase(0).GetTaskWithName("ai")
+	if (ai == nil) || (len(ai.GetDependencyNames()) != 1) {
+		t.Fatalf("TestMleSchedulerConfig: task ai not configured")
+	}
+	if interval, offset := ai.GetFrameInterval(); (interval != 2) || (offset != 1) {
+		t.Errorf("TestMleSchedulerConfig: task ai has frame interval %d, offset %d", interval, offset)
+	}
+	if policy, retries := ai.GetFailurePolicy(); (policy != mle_sched.MLE_TASK_FAILURE_RETRY) || (retries != 3) {
+		t.Errorf("TestMleSchedulerConfig: task ai has failure policy %d, retries %d", policy, retries)
+	}
+
+	// Frame 0 runs physics and draw, but not ai.
+	atomic.StoreInt32(&config_runs, 0)
+	if err := scheduler.RunFrame(context.Background()); err != nil {
+		t.Fatalf("TestMleSchedulerConfig: RunFrame() failed: %s", err.What)
+	}
+	if atomic.LoadInt32(&config_runs) != 2 {
+		t.Errorf("TestMleSchedulerConfig: %d tasks ran, expected 2", config_runs)
+	}
+	scheduler.SetWorkerPoolSize(0)
+	scheduler.GetPhase(1).SetSerial(false)
+}
+
+func TestMleSchedulerConfigInvalid(t *testing.T) {
+	config_Register(t)
+
+	invalid := `{"phases": [
+		{"name": "Update", "tasks": [
+			{"name": "physics", "runnable": "configPhysics", "after": ["draw"]},
+			{"name": "physics", "runnable": "configMissing"},
+			{"name": "ai", "runnable": "configPhysics", "failurePolicy": "explode", "timeInterval": "soon"},
+			{"name": "a", "runnable": "configPhysics", "after": ["b"]},
+			{"name": "b", "runnable": "configPhysics", "after": ["a"]}
+		]},
+		{"name": "Render", "tasks": [{"name": "draw", "runnable": "configDraw"}]}
+	]}`
+	_, err := mle_sched.LoadMleSchedulerConfig(strings.NewReader(invalid))
+	if err == nil {
+		t.Fatalf("TestMleSchedulerConfigInvalid: invalid configuration accepted")
+	}
+	for _, problem := range []string{"Task physics is declared more than once", "unregistered Runnable class configMissing",
+		"unknown failure policy", "invalid time interval", "unknown or later task draw", "cycle"} {
+		if !strings.Contains(err.What, problem) {
+			t.Errorf("TestMleSchedulerConfigInvalid: problem %q not reported in %s", problem, err.What)
+		}
+	}
+	if joined, ok := err.Err.(interface{ Unwrap() []error }); !ok || (len(joined.Unwrap()) != 6) {
+		t.Errorf("TestMleSchedulerConfigInvalid: problems not wrapped individually")
+	}
+
+	if _, err := mle_sched.LoadMleSchedulerConfig(strings.NewReader(`{"phases": [], "pool": 2}`)); err == nil {
+		t.Errorf("TestMleSchedulerConfigInvalid: unknown field accepted")
+	}
+	if _, err := mle_sched.LoadMleSchedulerConfigYaml(strings.NewReader("phases: []\npool: 2\n")); err == nil {
+		t.Errorf("TestMleSchedulerConfigInvalid: unknown YAML key accepted")
+	}
+	if _, err := mle_sched.LoadMleSchedulerConfigFile("missing.yaml"); err == nil {
+		t.Errorf("TestMleSchedulerConfigInvalid: missing file accepted")
+	}
+}
+
+// A class in the legacy registry which can not create a Runnable.
+type config_NotRunnableClass struct {
+}
+
+// Test that the workers of the phases built before a task fails to be
+// created are released.
+func TestMleSchedulerConfigBuildFailure(t *testing.T) {
+	config_Register(t)
+	saved := mle_util.GClassRegistry
+	defer func() { mle_util.GClassRegistry = saved }()
+	mle_util.GClassRegistry = map[string]interface{}{"configNotRunnable": new(config_NotRunnableClass)}
+
+	config, err := mle_sched.LoadMleSchedulerConfig(strings.NewReader(`{"workerPoolSize": 2, "phases": [
+		{"name": "Update", "workerPoolSize": 4, "tasks": [{"name": "physics", "runnable": "configPhysics"}]},
+		{"name": "Render", "serial": true, "tasks": [{"name": "draw", "runnable": "configDraw"}]},
+		{"name": "Broken", "workerPoolSize": 2, "tasks": [{"name": "broken", "runnable": "configNotRunnable"}]}
+	]}`))
+	if err != nil {
+		t.Fatalf("TestMleSchedulerConfigBuildFailure: LoadMleSchedulerConfig() failed: %s", err.What)
+	}
+	before := runtime.NumGoroutine()
+	if _, err := mle_sched.NewMleSchedulerFromConfig(config); (err == nil) || !strings.Contains(err.What, "task broken") {
+		t.Fatalf("TestMleSchedulerConfigBuildFailure: NewMleSchedulerFromConfig() returned %v", err)
+	}
+	for deadline := time.Now().Add(time.Second); runtime.NumGoroutine() > before; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("TestMleSchedulerConfigBuildFailure: %d goroutines left running", runtime.NumGoroutine() - before)
+		}
+	}
+}
+
+// config_Valid written as YAML.
+const config_ValidYaml = `# The scheduler of config_Valid.
+workerPoolSize: 2
+phases:
+- name: Update
+  tasks:
+    - name: physics
+      runnable: configPhysics
+    - {name: ai, runnable: configPhysics, after: [physics], frameInterval: 2, frameOffset: 1,
+       failurePolicy: retry, retries: 3}
+- name: "Render"
+  serial: true   # Run the tasks in order.
+  timeInterval: 10ms
+  tasks:
+    - name: draw
+      runnable: 'configDraw'
+      after:
+        - physics
+`
+
+func TestMleSchedulerConfigYaml(t *testing.T) {
+	config_Register(t)
+
+	expected, err := mle_sched.LoadMleSchedulerConfig(strings.NewReader(config_Valid))
+	if err != nil {
+		t.Fatalf("TestMleSchedulerConfigYaml: LoadMleSchedulerConfig() failed: %s", err.What)
+	}
+	path := filepath.Join(t.TempDir(), "scheduler.yml")
+	if err := os.WriteFile(path, []byte(config_ValidYaml), 0644); err != nil {
+		t.Fatalf("TestMleSchedulerConfigYaml: unable to write %s: %v", path, err)
+	}
+	config, err := mle_sched.LoadMleSchedulerConfigFile(path)
+	if err != nil {
+		t.Fatalf("TestMleSchedulerConfigYaml: LoadMleSchedulerConfigFile() failed: %s", err.What)
+	}
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("TestMleSchedulerConfigYaml: loaded %+v, expected %+v", config, expected)
+	}
+}
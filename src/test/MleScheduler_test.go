@@ -40,12 +40,17 @@
 package mle_test
 
 import (
+	"context"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	mle_core "github.com/mle/runtime/core"
 	mle_sched "github.com/mle/runtime/scheduler"
+	mle_util "github.com/mle/runtime/util"
 )
 
 var numPhases int = 4
@@ -251,4 +256,468 @@ func TestSchdulerTest1(t *testing.T) {
 		scheduler.SetExitOk()
 	}()
 	scheduler.Run(nil)
-}
\ No newline at end of file
+}
+
+// A task which runs until its context is cancelled.
+type testMleScheduler_ContextTask struct {
+	runs      int32
+	cancelled int32
+}
+
+func (task *testMleScheduler_ContextTask) Run(done chan bool) {
+	task.RunContext(context.Background(), done)
+}
+
+func (task *testMleScheduler_ContextTask) RunContext(ctx context.Context, done chan bool) {
+	atomic.AddInt32(&task.runs, 1)
+	<-ctx.Done()
+	atomic.AddInt32(&task.cancelled, 1)
+	done <- true
+}
+
+func (task *testMleScheduler_ContextTask) String() string {
+	return "Context Task"
+}
+
+// A task which ignores cancellation until it is released.
+type testMleScheduler_BlockingTask struct {
+	release chan bool
+}
+
+func (task *testMleScheduler_BlockingTask) Run(done chan bool) {
+	<-task.release
+}
+
+func (task *testMleScheduler_BlockingTask) String() string {
+	return "Blocking Task"
+}
+
+/*
+ * Test that cancelling the context stops the scheduler and is propagated to running tasks.
+ */
+func TestMleSchedulerRunContext(t *testing.T) {
+	scheduler := mle_sched.NewMleScheduler()
+	phase := mle_sched.NewMlePhaseWithName("Context Phase")
+	task := &testMleScheduler_ContextTask{}
+	phase.AddTask(mle_sched.NewMleTaskWithName(task, "Context Task"))
+	scheduler.AddPhase(phase)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := scheduler.RunContext(ctx)
+	if (err == nil) || (err.Err != context.DeadlineExceeded) {
+		t.Errorf("TestMleSchedulerRunContext: RunContext() returned %v", err)
+	}
+	if (atomic.LoadInt32(&task.runs) != 1) || (atomic.LoadInt32(&task.cancelled) != 1) {
+		t.Errorf("TestMleSchedulerRunContext: task ran %d times, cancelled %d times", task.runs, task.cancelled)
+	}
+}
+
+/*
+ * Test that Shutdown drains running tasks, and gives up at the deadline.
+ */
+func TestMleSchedulerShutdown(t *testing.T) {
+	// A cooperative task is drained.
+	scheduler := mle_sched.NewMleScheduler()
+	phase := mle_sched.NewMlePhaseWithName("Shutdown Phase")
+	task := &testMleScheduler_ContextTask{}
+	phase.AddTask(mle_sched.NewMleTask(task))
+	scheduler.AddPhase(phase)
+
+	done := make(chan bool, 1)
+	go scheduler.Run(done)
+	for atomic.LoadInt32(&task.runs) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := scheduler.Shutdown(ctx); err != nil {
+		t.Errorf("TestMleSchedulerShutdown: Shutdown() failed: %s", err.What)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("TestMleSchedulerShutdown: Run() did not return")
+	}
+
+	// A task ignoring cancellation exceeds the deadline.
+	scheduler = mle_sched.NewMleScheduler()
+	phase = mle_sched.NewMlePhaseWithName("Blocking Phase")
+	blocking := &testMleScheduler_BlockingTask{make(chan bool)}
+	task2 := mle_sched.NewMleTask(blocking)
+	phase.AddTask(task2)
+	scheduler.AddPhase(phase)
+
+	go scheduler.Run(done)
+	for !task2.IsRunning() {
+		time.Sleep(time.Millisecond)
+	}
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel2()
+	err := scheduler.Shutdown(ctx2)
+	if (err == nil) || (err.Err != context.DeadlineExceeded) {
+		t.Errorf("TestMleSchedulerShutdown: Shutdown() returned %v", err)
+	}
+
+	// Once released, the scheduler stops.
+	close(blocking.release)
+	<-done
+	if err := scheduler.Shutdown(context.Background()); err != nil {
+		t.Errorf("TestMleSchedulerShutdown: Shutdown() of stopped scheduler failed: %s", err.What)
+	}
+}
+
+/*
+ * Test that a task may only depend on tasks in the same or an earlier phase.
+ */
+func TestMleSchedulerTaskDependencies(t *testing.T) {
+	scheduler := mle_sched.NewMleScheduler()
+	update := mle_sched.NewMlePhaseWithName("Update")
+	render := mle_sched.NewMlePhaseWithName("Render")
+	scheduler.AddPhase(update)
+	scheduler.AddPhase(render)
+
+	physics := mle_sched.NewMleTaskWithName(nil, "physics")
+	draw := mle_sched.NewMleTaskWithName(nil, "draw")
+	draw.AddDependencyWithName("physics")
+	if !scheduler.AddTask(update, physics) || !scheduler.AddTask(render, draw) {
+		t.Fatalf("TestMleSchedulerTaskDependencies: AddTask() failed")
+	}
+
+	// A dependency on a later phase, or on an unknown task, is rejected.
+	early := mle_sched.NewMleTaskWithName(nil, "early")
+	early.AddDependency(draw)
+	if scheduler.AddTask(update, early) {
+		t.Errorf("TestMleSchedulerTaskDependencies: dependency on a later phase accepted")
+	}
+	unknown := mle_sched.NewMleTaskWithName(nil, "unknown")
+	unknown.AddDependencyWithName("missing")
+	if scheduler.AddTask(render, unknown) {
+		t.Errorf("TestMleSchedulerTaskDependencies: dependency on an unknown task accepted")
+	}
+	late := mle_sched.NewMlePhaseWithName("Late")
+	late.AddTask(unknown)
+	if scheduler.AddPhase(late) {
+		t.Errorf("TestMleSchedulerTaskDependencies: phase with an unknown dependency accepted")
+	}
+	scheduler.Dump()
+}
+
+
+type testMleScheduler_ErrorHandler struct {
+	failures []string
+}
+
+func (h *testMleScheduler_ErrorHandler) TaskFailed(phase *mle_sched.MlePhase, task *mle_sched.MleTask, err *mle_core.MleError) {
+	h.failures = append(h.failures, phase.GetName()+"/"+task.GetName())
+}
+
+/*
+ * Test that a panicking task is reported and aborts the frame, with and
+ * without a worker pool.
+ */
+func TestMleSchedulerTaskErrors(t *testing.T) {
+	for _, poolSize := range []int{0, 2} {
+		var runs int32
+		count := func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		}
+
+		scheduler := mle_sched.NewMleScheduler()
+		scheduler.SetWorkerPoolSize(poolSize)
+		handler := new(testMleScheduler_ErrorHandler)
+		scheduler.SetTaskErrorHandler(handler)
+		update := mle_sched.NewMlePhaseWithName("Update")
+		render := mle_sched.NewMlePhaseWithName("Render")
+		scheduler.AddPhase(update)
+		scheduler.AddPhase(render)
+
+		bad := mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc("bad", func(ctx context.Context) error {
+			panic("bad task")
+		}), "bad")
+		after := mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc("after", count), "after")
+		after.AddDependency(bad)
+		draw := mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc("draw", count), "draw")
+		scheduler.AddTask(update, bad)
+		scheduler.AddTask(update, after)
+		scheduler.AddTask(render, draw)
+
+		// An ignored failure is reported and the frame completes.
+		if err := scheduler.RunFrame(context.Background()); err != nil {
+			t.Errorf("TestMleSchedulerTaskErrors: RunFrame() failed: %s", err.What)
+		}
+		if runs != 2 {
+			t.Errorf("TestMleSchedulerTaskErrors: %d tasks ran after an ignored failure, expected 2", runs)
+		}
+
+		// A failure aborting the frame skips the remaining tasks.
+		runs = 0
+		bad.SetFailurePolicy(mle_sched.MLE_TASK_FAILURE_ABORT_FRAME, 0)
+		if err := scheduler.RunFrame(context.Background()); err == nil {
+			t.Errorf("TestMleSchedulerTaskErrors: aborted RunFrame() succeeded")
+		}
+		if runs != 0 {
+			t.Errorf("TestMleSchedulerTaskErrors: %d tasks ran in an aborted frame", runs)
+		}
+
+		if (len(handler.failures) != 2) || (handler.failures[0] != "Update/bad") {
+			t.Errorf("TestMleSchedulerTaskErrors: handler received %v", handler.failures)
+		}
+		errs := scheduler.GetTaskErrors()
+		if len(errs) != 2 {
+			t.Fatalf("TestMleSchedulerTaskErrors: %d task errors collected, expected 2", len(errs))
+		}
+		if _, ok := errs[0].Err.(*mle_util.PanicError); !ok {
+			t.Errorf("TestMleSchedulerTaskErrors: task error wraps %v", errs[0].Err)
+		}
+		scheduler.ClearTaskErrors()
+		if len(scheduler.GetTaskErrors()) != 0 {
+			t.Errorf("TestMleSchedulerTaskErrors: task errors not cleared")
+		}
+		scheduler.SetWorkerPoolSize(0)
+	}
+}
+
+/*
+ * Test that tasks and phases run only on the frames they are due.
+ */
+func TestMleSchedulerTaskFrequency(t *testing.T) {
+	runs := make(map[string]int)
+	var ready int32
+	newTask := func(name string) *mle_sched.MleTask {
+		return mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc(name, func(ctx context.Context) error {
+			runs[name]++
+			return nil
+		}), name)
+	}
+
+	scheduler := mle_sched.NewMleScheduler()
+	scheduler.SetWorkerPoolSize(1)
+	clock := new(testMleFrameLoop_Clock)
+	scheduler.SetClock(clock)
+	update := mle_sched.NewMlePhaseWithName("Update")
+	save := mle_sched.NewMlePhaseWithName("Save")
+	scheduler.AddPhase(update)
+	scheduler.AddPhase(save)
+
+	every := newTask("every")
+	third := newTask("third")
+	if third.SetFrameInterval(0, 0) == nil {
+		t.Errorf("TestMleSchedulerTaskFrequency: zero frame interval accepted")
+	}
+	third.SetFrameInterval(3, 1)
+	timed := newTask("timed")
+	timed.SetTimeInterval(50 * time.Millisecond)
+	conditional := newTask("conditional")
+	conditional.SetRunCondition(func() bool { return atomic.LoadInt32(&ready) != 0 })
+	after := newTask("after")
+	after.AddDependency(third)
+	for _, task := range []*mle_sched.MleTask{every, third, timed, conditional, after} {
+		scheduler.AddTask(update, task)
+	}
+	autosave := newTask("autosave")
+	scheduler.AddTask(save, autosave)
+	save.SetFrameInterval(5, 0)
+
+	// Run 10 frames, 20ms apart; the condition holds from frame 5.
+	for i := 0; i < 10; i++ {
+		if i == 5 {
+			atomic.StoreInt32(&ready, 1)
+		}
+		if err := scheduler.RunFrame(context.Background()); err != nil {
+			t.Fatalf("TestMleSchedulerTaskFrequency: RunFrame() failed: %s", err.What)
+		}
+		clock.Advance(20 * time.Millisecond)
+	}
+	scheduler.SetWorkerPoolSize(0)
+
+	// Frames 1, 4 and 7 run the third task; 0, 3, 6 and 9 (every 60ms) the timed task.
+	expected := map[string]int{"every": 10, "third": 3, "timed": 4, "conditional": 5, "after": 10, "autosave": 2}
+	for name, count := range expected {
+		if runs[name] != count {
+			t.Errorf("TestMleSchedulerTaskFrequency: task %s ran %d times, expected %d", name, runs[name], count)
+		}
+	}
+	if scheduler.GetFrameIndex() != 10 {
+		t.Errorf("TestMleSchedulerTaskFrequency: frame index is %d", scheduler.GetFrameIndex())
+	}
+}
+
+type testMleScheduler_PhaseListener struct {
+	changes []string
+	lock sync.Mutex
+}
+
+func (l *testMleScheduler_PhaseListener) PhaseChanged(event *mle_sched.MlePhaseChangeEvent) {
+	l.lock.Lock()
+	l.changes = append(l.changes, event.String())
+	l.lock.Unlock()
+}
+
+func (l *testMleScheduler_PhaseListener) count() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return len(l.changes)
+}
+
+func testMleScheduler_PhaseNames(scheduler *mle_sched.MleScheduler) string {
+	var names []string
+	for i := 0; i < scheduler.GetNumberOfPhases(); i++ {
+		names = append(names, scheduler.GetPhase(i).GetName())
+	}
+	return strings.Join(names, ",")
+}
+
+/*
+ * Test inserting, moving and deleting phases, including while a frame runs.
+ */
+func TestMleSchedulerPhaseChanges(t *testing.T) {
+	scheduler := mle_sched.NewMleScheduler()
+	listener := new(testMleScheduler_PhaseListener)
+	scheduler.AddPhaseChangeListener(listener)
+
+	input := mle_sched.NewMlePhaseWithName("input")
+	update := mle_sched.NewMlePhaseWithName("update")
+	render := mle_sched.NewMlePhaseWithName("render")
+	scheduler.AddPhase(render)
+	scheduler.InsertPhaseBefore(update, render)
+	scheduler.InsertPhaseAt(0, input)
+	if scheduler.InsertPhaseAfter(input, update) {
+		t.Errorf("TestMleSchedulerPhaseChanges: phase registered twice")
+	}
+	if names := testMleScheduler_PhaseNames(scheduler); names != "input,update,render" {
+		t.Fatalf("TestMleSchedulerPhaseChanges: phases are %s", names)
+	}
+
+	// A phase may not move before the phase its tasks depend on.
+	physics := mle_sched.NewMleTaskWithName(nil, "physics")
+	draw := mle_sched.NewMleTaskWithName(nil, "draw")
+	draw.AddDependency(physics)
+	scheduler.AddTask(update, physics)
+	scheduler.AddTask(render, draw)
+	if scheduler.MovePhase(render, 0) {
+		t.Errorf("TestMleSchedulerPhaseChanges: move before a dependency accepted")
+	}
+	if !scheduler.MovePhase(input, 1) || (testMleScheduler_PhaseNames(scheduler) != "update,input,render") {
+		t.Errorf("TestMleSchedulerPhaseChanges: phases are %s after move", testMleScheduler_PhaseNames(scheduler))
+	}
+	if listener.count() != 4 {
+		t.Errorf("TestMleSchedulerPhaseChanges: %d changes delivered, expected 4", listener.count())
+	}
+
+	// Changes made by a task take effect at the end of the frame.
+	var ran []string
+	var lock sync.Mutex
+	record := func(name string) mle_util.Runnable {
+		return mle_util.NewRunnableFunc(name, func(ctx context.Context) error {
+			lock.Lock()
+			ran = append(ran, name)
+			lock.Unlock()
+			return nil
+		})
+	}
+	debug := mle_sched.NewMlePhaseWithName("debug")
+	debug.AddTask(mle_sched.NewMleTaskWithName(record("debug"), "debug"))
+	input.AddTask(mle_sched.NewMleTaskWithName(record("input"), "input"))
+	render.AddTask(mle_sched.NewMleTaskWithName(record("render"), "render"))
+	plugin := mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc("plugin", func(ctx context.Context) error {
+		if !scheduler.InsertPhaseAfter(debug, render) || !scheduler.DeletePhase(input) {
+			t.Errorf("TestMleSchedulerPhaseChanges: phase changes failed while running")
+		}
+		if names := testMleScheduler_PhaseNames(scheduler); names != "update,render,debug" {
+			t.Errorf("TestMleSchedulerPhaseChanges: changed phases are %s while running", names)
+		}
+		if listener.count() != 4 {
+			t.Errorf("TestMleSchedulerPhaseChanges: changes delivered before the end of the frame")
+		}
+		return nil
+	}), "plugin")
+	update.AddTask(plugin)
+
+	scheduler.RunFrame(context.Background())
+	if (strings.Join(ran, ",") != "input,render") || (listener.count() != 6) {
+		t.Errorf("TestMleSchedulerPhaseChanges: frame ran %v with %d changes", ran, listener.count())
+	}
+	update.DeleteTask(plugin)
+	ran = nil
+	scheduler.RunFrame(context.Background())
+	if strings.Join(ran, ",") != "render,debug" {
+		t.Errorf("TestMleSchedulerPhaseChanges: next frame ran %v", ran)
+	}
+	if !scheduler.RemovePhaseChangeListener(listener) {
+		t.Errorf("TestMleSchedulerPhaseChanges: listener not removed")
+	}
+}
+
+/*
+ * Test that a running scheduler without phases picks up an added phase.
+ */
+func TestMleSchedulerAddPhaseWhileRunning(t *testing.T) {
+	scheduler := mle_sched.NewMleScheduler()
+	done := make(chan bool, 1)
+	go scheduler.Run(done)
+	time.Sleep(10 * time.Millisecond)
+
+	phase := mle_sched.NewMlePhaseWithName("late")
+	phase.AddTask(mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc("exit", func(ctx context.Context) error {
+		scheduler.SetExitOk()
+		return nil
+	}), "exit"))
+	scheduler.AddPhase(phase)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("TestMleSchedulerAddPhaseWhileRunning: added phase did not run")
+	}
+}
+
+/*
+ * Test several phase changes, and adding a task to a new phase, while a
+ * frame is blocked.
+ */
+func TestMleSchedulerStagedPhaseChanges(t *testing.T) {
+	scheduler := mle_sched.NewMleScheduler()
+	blocked := mle_sched.NewMlePhaseWithName("blocked")
+	entered := make(chan bool)
+	release := make(chan bool)
+	blocked.AddTask(mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc("block", func(ctx context.Context) error {
+		entered <- true
+		<-release
+		return nil
+	}), "block"))
+	scheduler.AddPhase(blocked)
+
+	finished := make(chan bool)
+	go func() {
+		scheduler.RunFrame(context.Background())
+		finished <- true
+	}()
+	<-entered
+
+	first := mle_sched.NewMlePhaseWithName("first")
+	second := mle_sched.NewMlePhaseWithName("second")
+	third := mle_sched.NewMlePhaseWithName("third")
+	if !scheduler.AddPhase(first) || !scheduler.AddPhase(second) || !scheduler.InsertPhaseAt(1, third) {
+		t.Errorf("TestMleSchedulerStagedPhaseChanges: phases not added while running")
+	}
+	if !scheduler.MovePhase(second, 1) || !scheduler.MovePhase(blocked, 3) {
+		t.Errorf("TestMleSchedulerStagedPhaseChanges: phases not moved while running")
+	}
+	if !scheduler.AddTask(second, mle_sched.NewMleTaskWithName(nil, "task")) {
+		t.Errorf("TestMleSchedulerStagedPhaseChanges: task not added to a new phase while running")
+	}
+	if names := testMleScheduler_PhaseNames(scheduler); names != "second,third,first,blocked" {
+		t.Errorf("TestMleSchedulerStagedPhaseChanges: phases are %s while running", names)
+	}
+
+	close(release)
+	<-finished
+	if names := testMleScheduler_PhaseNames(scheduler); names != "second,third,first,blocked" {
+		t.Errorf("TestMleSchedulerStagedPhaseChanges: phases are %s", names)
+	}
+	if second.GetNumberOfTasks() != 1 {
+		t.Errorf("TestMleSchedulerStagedPhaseChanges: %d tasks in the new phase", second.GetNumberOfTasks())
+	}
+}
@@ -40,10 +40,13 @@
 package mle_test
 
 import (
+	"context"
+	"errors"
 	"time"
 	"strconv"
 	"testing"
 
+	mle_util "github.com/mle/runtime/util"
 	mle_core "github.com/mle/runtime/core"
 	mle_sched "github.com/mle/runtime/scheduler"
 )
@@ -145,4 +148,36 @@ func TestMleTaskIsRunning(t *testing.T) {
 		t.Logf("waiting for simple runnable to complete.")
 		time.Sleep(1000 * time.Millisecond)
 	}
-}
\ No newline at end of file
+}
+
+func TestMleTaskFailurePolicy(t *testing.T) {
+	calls := 0
+	runnable := mle_util.NewRunnableFunc("Failing Task", func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("failure " + strconv.Itoa(calls))
+		}
+		return nil
+	})
+	task := mle_sched.NewMleTaskWithName(runnable, "Failing Task")
+	if err := task.SetFailurePolicy(mle_sched.MLE_TASK_FAILURE_ABORT_FRAME+1, 0); err == nil {
+		t.Errorf("TestMleTaskFailurePolicy: unknown policy accepted.")
+	}
+
+	// Retry twice, succeeding on the last attempt.
+	task.SetFailurePolicy(mle_sched.MLE_TASK_FAILURE_RETRY, 2)
+	task.Invoke()
+	task.Wait()
+	if (task.GetLastError() != nil) || (task.GetAttempts() != 3) {
+		t.Errorf("TestMleTaskFailurePolicy: retry returned %v after %d attempts.", task.GetLastError(), task.GetAttempts())
+	}
+
+	// Disable the task when it fails.
+	calls = 0
+	task.SetFailurePolicy(mle_sched.MLE_TASK_FAILURE_DISABLE, 0)
+	task.Invoke()
+	task.Wait()
+	if (task.GetLastError() == nil) || task.IsEnabled() {
+		t.Errorf("TestMleTaskFailurePolicy: failed task not disabled.")
+	}
+}
@@ -0,0 +1,224 @@
+/**
+ * @file MleWorkerPool_test.go
+ * Created on October 17, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	mle_core "github.com/mle/runtime/core"
+	mle_sched "github.com/mle/runtime/scheduler"
+	mle_util "github.com/mle/runtime/util"
+)
+
+// A task measuring how many tasks run at the same time.
+type testMleWorkerPool_Task struct {
+	running *int32
+	max     *int32
+	order   *[]int
+	lock    *sync.Mutex
+	id      int
+}
+
+func (task *testMleWorkerPool_Task) Run(done chan bool) {
+	n := atomic.AddInt32(task.running, 1)
+	for {
+		max := atomic.LoadInt32(task.max)
+		if (n <= max) || atomic.CompareAndSwapInt32(task.max, max, n) {
+			break
+		}
+	}
+	task.lock.Lock()
+	*task.order = append(*task.order, task.id)
+	task.lock.Unlock()
+	atomic.AddInt32(task.running, -1)
+	done <- true
+}
+
+func (task *testMleWorkerPool_Task) String() string {
+	return "Pool Task"
+}
+
+func TestMleWorkerPool(t *testing.T) {
+	pool := mle_sched.NewMleWorkerPool(2)
+	release := make(chan bool)
+	var started sync.WaitGroup
+	started.Add(2)
+	var completed int32
+	for i := 0; i < 10; i++ {
+		err := pool.Submit(func() {
+			if atomic.AddInt32(&completed, 1) <= 2 {
+				started.Done()
+			}
+			<-release
+		})
+		if err != nil {
+			t.Fatalf("TestMleWorkerPool: Submit() failed: %s", err.What)
+		}
+	}
+
+	// Both workers are busy and the remaining jobs are queued.
+	started.Wait()
+	stats := pool.GetStats()
+	if (stats.GetSize() != 2) || (stats.GetActiveWorkers() != 2) || (stats.GetQueueDepth() != 8) || (stats.GetMaxQueueDepth() < 8) {
+		t.Errorf("TestMleWorkerPool: size %d, active %d, queued %d, max queued %d", stats.GetSize(),
+			stats.GetActiveWorkers(), stats.GetQueueDepth(), stats.GetMaxQueueDepth())
+	}
+
+	close(release)
+	pool.Close()
+	stats = pool.GetStats()
+	if (stats.GetSubmittedCount() != 10) || (stats.GetCompletedCount() != 10) || (stats.GetQueueDepth() != 0) {
+		t.Errorf("TestMleWorkerPool: submitted %d, completed %d, queued %d", stats.GetSubmittedCount(),
+			stats.GetCompletedCount(), stats.GetQueueDepth())
+	}
+	if pool.Submit(func() {}) == nil {
+		t.Errorf("TestMleWorkerPool: closed pool accepted a job")
+	}
+}
+
+func TestMleSchedulerWorkerPool(t *testing.T) {
+	var running, max int32
+	var order []int
+	var lock sync.Mutex
+
+	scheduler := mle_sched.NewMleScheduler()
+	scheduler.SetWorkerPoolSize(2)
+	defer scheduler.SetWorkerPoolSize(0)
+	update := mle_sched.NewMlePhaseWithName("Update")
+	render := mle_sched.NewMlePhaseWithName("Render")
+	render.SetSerial(true)
+	defer render.SetSerial(false)
+	for i := 0; i < 6; i++ {
+		update.AddTask(mle_sched.NewMleTask(&testMleWorkerPool_Task{&running, &max, &order, &lock, i}))
+		render.AddTask(mle_sched.NewMleTask(&testMleWorkerPool_Task{&running, &max, &order, &lock, 10 + i}))
+	}
+	scheduler.AddPhase(update)
+	scheduler.AddPhase(render)
+
+	if err := scheduler.RunFrame(context.Background()); err != nil {
+		t.Fatalf("TestMleSchedulerWorkerPool: RunFrame() failed: %s", err.What)
+	}
+	if max > 2 {
+		t.Errorf("TestMleSchedulerWorkerPool: %d tasks ran at the same time", max)
+	}
+	if stats := scheduler.GetWorkerPool().GetStats(); stats.GetCompletedCount() != 6 {
+		t.Errorf("TestMleSchedulerWorkerPool: scheduler pool completed %d tasks", stats.GetCompletedCount())
+	}
+
+	// The serial phase ran its tasks one at a time, in order.
+	if !render.IsSerial() || (render.GetWorkerPool().GetStats().GetCompletedCount() != 6) {
+		t.Errorf("TestMleSchedulerWorkerPool: serial phase did not use its own worker")
+	}
+	expected := []int{10, 11, 12, 13, 14, 15}
+	for i := range expected {
+		if order[6+i] != expected[i] {
+			t.Fatalf("TestMleSchedulerWorkerPool: serial order = %v", order[6:])
+		}
+	}
+}
+
+// Test resizing the worker pools while a frame is running.
+func TestMleSchedulerWorkerPoolResize(t *testing.T) {
+	var ran []string
+	var lock sync.Mutex
+	started := make(chan bool)
+	resized := make(chan bool)
+	newTask := func(name string, work func()) *mle_sched.MleTask {
+		return mle_sched.NewMleTaskWithName(mle_util.NewRunnableFunc(name, func(ctx context.Context) error {
+			if work != nil {
+				work()
+			}
+			lock.Lock()
+			ran = append(ran, name)
+			lock.Unlock()
+			return nil
+		}), name)
+	}
+
+	scheduler := mle_sched.NewMleScheduler()
+	scheduler.SetWorkerPoolSize(1)
+	defer scheduler.SetWorkerPoolSize(0)
+	update := mle_sched.NewMlePhaseWithName("Update")
+	render := mle_sched.NewMlePhaseWithName("Render")
+	render.SetWorkerPoolSize(1)
+	defer render.SetWorkerPoolSize(0)
+	first := newTask("first", func() {
+		started <- true
+		<-resized
+	})
+	second := newTask("second", nil)
+	second.AddDependency(first)
+	update.AddTask(first)
+	update.AddTask(second)
+	render.AddTask(newTask("third", nil))
+	scheduler.AddPhase(update)
+	scheduler.AddPhase(render)
+
+	schedulerPool := scheduler.GetWorkerPool()
+	renderPool := render.GetWorkerPool()
+	result := make(chan *mle_core.MleError)
+	go func() {
+		result <- scheduler.RunFrame(context.Background())
+	}()
+
+	// The tasks launched after the pools are replaced still run this frame.
+	<-started
+	scheduler.SetWorkerPoolSize(2)
+	render.SetWorkerPoolSize(2)
+	close(resized)
+	if err := <-result; err != nil {
+		t.Fatalf("TestMleSchedulerWorkerPoolResize: RunFrame() failed: %s", err.What)
+	}
+	if len(ran) != 3 {
+		t.Errorf("TestMleSchedulerWorkerPoolResize: ran %v", ran)
+	}
+
+	// The replaced pools are closed at the end of the frame.
+	if (schedulerPool.Submit(func() {}) == nil) || (renderPool.Submit(func() {}) == nil) {
+		t.Errorf("TestMleSchedulerWorkerPoolResize: replaced pool is still open")
+	}
+	if (scheduler.GetWorkerPool().GetSize() != 2) || (render.GetWorkerPool().GetSize() != 2) {
+		t.Errorf("TestMleSchedulerWorkerPoolResize: pools not resized")
+	}
+}
@@ -40,7 +40,9 @@
 package mle_test
 
 import (
+	"context"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 	"testing"
@@ -89,7 +91,7 @@ func TestNewThreadWithRunnable(t *testing.T) {
 		t.Errorf("TestNewThreadWithRunnable: NewThreadWithRunnable() returned nil")
 	}
 
-	// Execute thread but don't wait for completion.
+	// Execute thread and wait for the runnable to signal or return.
 	thread.Run(nil)
 
 	// Execute thread and wait for completion based on done channel.
@@ -132,4 +134,150 @@ func TestThreadMutlipleThreads(t *testing.T) {
 	}
 	// And wait for the threads to complete.
 	wg.Wait()
-}
\ No newline at end of file
+}
+
+type testThread_panicRunnable struct {
+}
+
+func (r *testThread_panicRunnable) Run(done chan bool) {
+	panic("bad runnable")
+}
+
+func (r *testThread_panicRunnable) String() string {
+	return "PanicRunnable"
+}
+
+func TestThreadPanic(t *testing.T) {
+	thread := mle_util.NewThreadWithRunnable(new(testThread_panicRunnable))
+
+	// The panic is recovered and the thread completes.
+	var wg sync.WaitGroup
+	thread.Start(&wg)
+	wg.Wait()
+
+	err, ok := thread.GetError().(*mle_util.PanicError)
+	if !ok {
+		t.Fatalf("TestThreadPanic: GetError() returned %v, not a PanicError", thread.GetError())
+	}
+	if err.Value != "bad runnable" {
+		t.Errorf("TestThreadPanic: panic value is %v", err.Value)
+	}
+}
+
+type testThread_blockingRunnable struct {
+	m_started chan bool
+}
+
+func (r *testThread_blockingRunnable) Run(done chan bool) {
+	r.RunContext(context.Background(), done)
+}
+
+func (r *testThread_blockingRunnable) RunContext(ctx context.Context, done chan bool) {
+	r.m_started <- true
+	<-ctx.Done()
+}
+
+func (r *testThread_blockingRunnable) String() string {
+	return "BlockingRunnable"
+}
+
+func TestThreadLifecycle(t *testing.T) {
+	r := &testThread_blockingRunnable{m_started: make(chan bool, 1)}
+	thread := mle_util.NewThreadWithRunnableAndName(r, "Blocker")
+	if thread.GetState() != mle_util.THREAD_NEW {
+		t.Errorf("TestThreadLifecycle: new thread is %v", thread.GetState())
+	}
+	if !thread.Join(0) {
+		t.Errorf("TestThreadLifecycle: Join() on a new thread returned false")
+	}
+
+	var wg sync.WaitGroup
+	thread.Start(&wg)
+	<-r.m_started
+	if !thread.IsAlive() || thread.GetState() != mle_util.THREAD_RUNNING {
+		t.Fatalf("TestThreadLifecycle: started thread is %v", thread.GetState())
+	}
+	if thread.Join(20 * time.Millisecond) {
+		t.Fatalf("TestThreadLifecycle: Join() did not time out")
+	}
+
+	// The running thread is registered and dumped.
+	var dump strings.Builder
+	mle_util.DumpThreads(&dump)
+	if !strings.Contains(dump.String(), "Blocker: running for ") {
+		t.Errorf("TestThreadLifecycle: dump is %q", dump.String())
+	}
+
+	thread.Interrupt()
+	if !thread.Join(time.Second) {
+		t.Fatalf("TestThreadLifecycle: Join() timed out after Interrupt()")
+	}
+	wg.Wait()
+	if thread.GetState() != mle_util.THREAD_COMPLETED {
+		t.Errorf("TestThreadLifecycle: interrupted thread is %v", thread.GetState())
+	}
+	if thread.GetElapsedTime() < 20*time.Millisecond {
+		t.Errorf("TestThreadLifecycle: elapsed time is %v", thread.GetElapsedTime())
+	}
+	for _, live := range mle_util.GetLiveThreads() {
+		if live == thread {
+			t.Errorf("TestThreadLifecycle: completed thread is still registered")
+		}
+	}
+}
+
+func TestThreadFailedState(t *testing.T) {
+	thread := mle_util.NewThreadWithRunnable(new(testThread_panicRunnable))
+	thread.Run(nil)
+	if thread.GetState() != mle_util.THREAD_FAILED {
+		t.Errorf("TestThreadFailedState: panicked thread is %v", thread.GetState())
+	}
+	if thread.GetError() == nil {
+		t.Errorf("TestThreadFailedState: GetError() returned nil")
+	}
+}
+
+// A Runnable signalling completion before it fails.
+type testThread_lateErrorRunnable struct {
+	m_release chan bool
+	m_fail bool
+}
+
+func (r *testThread_lateErrorRunnable) Run(done chan bool) {
+	done <- true
+	<-r.m_release
+	if r.m_fail {
+		panic("late failure")
+	}
+}
+
+func (r *testThread_lateErrorRunnable) String() string {
+	return "LateErrorRunnable"
+}
+
+func TestThreadLateError(t *testing.T) {
+	r := &testThread_lateErrorRunnable{m_release: make(chan bool), m_fail: true}
+	thread := mle_util.NewThreadWithRunnable(r)
+
+	// The thread is running until the Runnable returns, even though it
+	// has signalled completion.
+	var wg sync.WaitGroup
+	thread.Start(&wg)
+	if thread.Join(20 * time.Millisecond) {
+		t.Fatalf("TestThreadLateError: thread completed before the Runnable returned")
+	}
+	r.m_release <- true
+	wg.Wait()
+	if thread.GetState() != mle_util.THREAD_FAILED || thread.GetError() == nil {
+		t.Errorf("TestThreadLateError: thread is %v with error %v", thread.GetState(), thread.GetError())
+	}
+
+	// A restarted execution reports only its own result.
+	r.m_fail = false
+	thread.Start(&wg)
+	r.m_release <- true
+	wg.Wait()
+	if thread.GetState() != mle_util.THREAD_COMPLETED || thread.GetError() != nil {
+		t.Errorf("TestThreadLateError: restarted thread is %v with error %v", thread.GetState(), thread.GetError())
+	}
+}
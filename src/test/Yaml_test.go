@@ -0,0 +1,131 @@
+/**
+ * @file Yaml_test.go
+ * Created on October 18, 2026. (msm@wizzerworks.com)
+ */
+
+// COPYRIGHT_BEGIN
+//
+// The MIT License (MIT)
+//
+// Copyright (c) 2019 Wizzer Works
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+//  For information concerning this source file, contact Mark S. Millard,
+//  of Wizzer Works at msm@wizzerworks.com.
+//
+//  More information concerning Wizzer Works may be found at
+//
+//      http://www.wizzerworks.com
+//
+// COPYRIGHT_END
+
+// Declare package.
+package mle_test
+
+// Import go packages.
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	mle_util "github.com/mle/runtime/util"
+)
+
+// The YAML decoder unit test.
+func TestDecodeYaml(t *testing.T) {
+	doc := `---
+# A comment.
+name: "a # b"
+quote: 'it''s'
+plain: hello world  # trailing comment
+count: 42
+ratio: 0.5
+enabled: true
+none: ~
+empty:
+list: [1, two, {three: 3}]
+map: {a: 1, b: [x, y]}
+items:
+- first
+- name: second
+  value: 2
+-
+  - nested
+nested:
+  deep:
+    key: value
+`
+	expected := map[string]interface{}{
+		"name":    "a # b",
+		"quote":   "it's",
+		"plain":   "hello world",
+		"count":   int64(42),
+		"ratio":   0.5,
+		"enabled": true,
+		"none":    nil,
+		"empty":   nil,
+		"list":    []interface{}{int64(1), "two", map[string]interface{}{"three": int64(3)}},
+		"map":     map[string]interface{}{"a": int64(1), "b": []interface{}{"x", "y"}},
+		"items": []interface{}{
+			"first",
+			map[string]interface{}{"name": "second", "value": int64(2)},
+			[]interface{}{"nested"},
+		},
+		"nested": map[string]interface{}{"deep": map[string]interface{}{"key": "value"}},
+	}
+	value, err := mle_util.DecodeYaml(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("TestDecodeYaml: DecodeYaml() failed: %v", err)
+	}
+	if !reflect.DeepEqual(value, expected) {
+		t.Errorf("TestDecodeYaml: decoded %#v", value)
+	}
+}
+
+func TestDecodeYamlInvalid(t *testing.T) {
+	invalid := []string{
+		"a: 1\na: 2\n",
+		"a: 1\n  b: 2\n",
+		"a:\n\tb: 1\n",
+		"a: [1, 2\n",
+		"a: |\n  text\n",
+		"a: &anchor 1\n",
+		"a: 'open\n",
+		"a: 1\n---\nb: 2\n",
+		"--- a\n",
+		"%YAML 1.2\n---\na: 1\n",
+		"a: *alias\n",
+		"- !tag 1\n",
+		"a: [&anchor 1]\n",
+		"a: {b: !tag 1}\n",
+		"&anchor a: 1\n",
+		"? a\n: 1\n",
+		"a: @b\n",
+		"a: >\n  folded\n",
+		"a: plain\n  continued\n",
+	}
+	for _, doc := range invalid {
+		if _, err := mle_util.DecodeYaml(strings.NewReader(doc)); err == nil {
+			t.Errorf("TestDecodeYamlInvalid: decoded %q", doc)
+		} else if !strings.HasPrefix(err.Error(), "yaml: line ") {
+			t.Errorf("TestDecodeYamlInvalid: error %q has no line", err.Error())
+		}
+	}
+}